@@ -0,0 +1,42 @@
+package initiator
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/db"
+	"flag"
+	"fmt"
+)
+
+// RunPromoteAdmin grants administrator or, with -role editor or moderator,
+// lesser rights, or takes them away with -revoke. The user has to log in again for the
+// change to take effect.
+func RunPromoteAdmin(args []string) error {
+	flags := flag.NewFlagSet("promote-admin", flag.ContinueOnError)
+	role := flags.String("role", domain.RoleAdmin, "role to grant: admin, editor or moderator")
+	revoke := flags.Bool("revoke", false, "turn the user back into a regular user")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: promote-admin [-role admin|editor|moderator] [-revoke] <email>")
+	}
+	if *role != domain.RoleAdmin && *role != domain.RoleEditor && *role != domain.RoleModerator {
+		return errors.New("role must be admin, editor or moderator")
+	}
+	email := flags.Arg(0)
+
+	dbConn, err := db.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if *revoke {
+		*role = domain.RoleUser
+	}
+	if err := repository.NewPostgresUserRepo(dbConn).SetRole(email, *role); err != nil {
+		return err
+	}
+	fmt.Printf("%s is now %s\n", email, *role)
+	return nil
+}
@@ -0,0 +1,53 @@
+package initiator
+
+import (
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/payment"
+	"log"
+	"time"
+)
+
+const defaultHoldSweepInterval = time.Minute
+
+// InitializePaymentProvider returns the provider selected by
+// PAYMENT_PROVIDER. Only the fake provider is built in so far; it accepts
+// every payment token except "decline" without moving money.
+func InitializePaymentProvider() payment.Provider {
+	if provider := getEnv("PAYMENT_PROVIDER", "fake"); provider != "fake" {
+		log.Fatalf("unknown PAYMENT_PROVIDER %q", provider)
+	}
+	log.Println("Warning: using the fake payment provider, bookings are not actually paid")
+	return payment.NewFake()
+}
+
+// StartHoldSweeper expires seat holds that ran out and retries failed
+// refunds now and then every BOOKING_HOLD_SWEEP_INTERVAL.
+func StartHoldSweeper(bookings *usecase.BookingUsecase) {
+	interval := getEnvDuration("BOOKING_HOLD_SWEEP_INTERVAL", defaultHoldSweepInterval)
+	sweep := func() {
+		released, err := bookings.ReleaseExpiredHolds()
+		if err != nil {
+			log.Printf("failed to release expired seat holds: %v", err)
+			return
+		}
+		if released > 0 {
+			log.Printf("released %d expired seat holds", released)
+		}
+		refunded, err := bookings.RetryRefunds()
+		if err != nil {
+			log.Printf("failed to retry refunds: %v", err)
+			return
+		}
+		if refunded > 0 {
+			log.Printf("refunded %d cancelled bookings", refunded)
+		}
+	}
+	go func() {
+		sweep()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			sweep()
+		}
+	}()
+}
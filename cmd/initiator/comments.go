@@ -0,0 +1,29 @@
+package initiator
+
+import (
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/notify"
+	"log"
+	"os"
+	"time"
+)
+
+// InitializeMentionNotifier posts mention notifications to
+// MENTION_WEBHOOK_URL when it is set and logs them otherwise.
+func InitializeMentionNotifier() notify.MentionNotifier {
+	if url := os.Getenv("MENTION_WEBHOOK_URL"); url != "" {
+		return notify.NewWebhookNotifier(url)
+	}
+	return notify.LogNotifier{}
+}
+
+// commentEditWindow reads how long authors may edit their comments from
+// COMMENT_EDIT_WINDOW.
+func commentEditWindow() time.Duration {
+	window, err := time.ParseDuration(getEnv("COMMENT_EDIT_WINDOW", usecase.DefaultCommentEditWindow.String()))
+	if err != nil || window < 0 {
+		log.Printf("Warning: invalid COMMENT_EDIT_WINDOW, using %s", usecase.DefaultCommentEditWindow)
+		return usecase.DefaultCommentEditWindow
+	}
+	return window
+}
@@ -4,29 +4,96 @@ import (
 	"eskalate-movie-api/internal/handler"
 	"eskalate-movie-api/internal/repository"
 	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/storage"
 
 	"gorm.io/gorm"
 )
 
 type Handlers struct {
-	UserHandler  *handler.UserHandler
-	MovieHandler *handler.MovieHandler
-	DocsHandler  *handler.DocsHandler
+	UserHandler           *handler.UserHandler
+	MovieHandler          *handler.MovieHandler
+	PersonHandler         *handler.PersonHandler
+	ReviewHandler         *handler.ReviewHandler
+	SavedMovieHandler     *handler.SavedMovieHandler
+	CollectionHandler     *handler.CollectionHandler
+	MediaHandler          *handler.MediaHandler
+	TranslationHandler    *handler.TranslationHandler
+	CommentHandler        *handler.CommentHandler
+	ReportHandler         *handler.ReportHandler
+	RecommendationHandler *handler.RecommendationHandler
+	ProviderHandler       *handler.ProviderHandler
+	CinemaHandler         *handler.CinemaHandler
+	BookingHandler        *handler.BookingHandler
+	HistoryHandler        *handler.HistoryHandler
+	DocsHandler           *handler.DocsHandler
 }
 
-func InitializeHandlers(db *gorm.DB) *Handlers {
+func InitializeHandlers(db *gorm.DB, images storage.ImageStore) *Handlers {
 	// Initialize repositories
 	userRepo := repository.NewPostgresUserRepo(db)
 	movieRepo := repository.NewPostgresMovieRepo(db)
+	personRepo := repository.NewPostgresPersonRepo(db)
+	reviewRepo := repository.NewPostgresReviewRepo(db)
+	savedMovieRepo := repository.NewPostgresSavedMovieRepo(db)
+	collectionRepo := repository.NewPostgresCollectionRepo(db)
+	mediaRepo := repository.NewPostgresMediaRepo(db)
+	translationRepo := repository.NewPostgresTranslationRepo(db)
+	commentRepo := repository.NewPostgresCommentRepo(db)
+	reportRepo := repository.NewPostgresReportRepo(db)
+	viewRepo := repository.NewPostgresViewRepo(db)
+	recommendationRepo := repository.NewPostgresRecommendationRepo(db)
+	providerRepo := repository.NewPostgresProviderRepo(db)
+	cinemaRepo := repository.NewPostgresCinemaRepo(db)
+	bookingRepo := repository.NewPostgresBookingRepo(db)
+	historyRepo := repository.NewPostgresHistoryRepo(db)
+
+	// Start background workers
+	posterWorkers := usecase.NewPosterWorkerPool(movieRepo, images, getEnvInt("POSTER_WORKERS", 2))
+	posterWorkers.Start()
+	views := StartViewTracker(viewRepo)
+
+	contentFilter := InitializeContentFilter(reportRepo)
 
 	// Initialize use cases
 	userUsecase := usecase.NewUserUsecase(userRepo)
-	movieUsecase := usecase.NewMovieUsecase(movieRepo)
+	movieUsecase := usecase.NewMovieUsecase(movieRepo, personRepo, savedMovieRepo, mediaRepo, translationRepo, images, posterWorkers, InitializeTrailerVerifier(), contentFilter, viewRepo, views)
+	personUsecase := usecase.NewPersonUsecase(personRepo)
+	reviewUsecase := usecase.NewReviewUsecase(reviewRepo, movieRepo, contentFilter)
+	savedMovieUsecase := usecase.NewSavedMovieUsecase(savedMovieRepo, movieRepo, historyRepo)
+	collectionUsecase := usecase.NewCollectionUsecase(collectionRepo, movieRepo, userRepo)
+	mediaUsecase := usecase.NewMediaUsecase(mediaRepo, movieRepo, images)
+	translationUsecase := usecase.NewTranslationUsecase(translationRepo, movieRepo)
+	commentUsecase := usecase.NewCommentUsecase(commentRepo, movieRepo, userRepo, InitializeMentionNotifier(), commentEditWindow())
+	reportUsecase := usecase.NewReportUsecase(reportRepo, movieRepo, reviewRepo, commentRepo)
+	recommendationUsecase := usecase.NewRecommendationUsecase(recommendationRepo, movieRepo, movieUsecase)
+	StartRecommendationRefresh(recommendationUsecase)
+	providerUsecase := usecase.NewProviderUsecase(providerRepo, movieRepo)
+	StartProviderFeedSync(providerUsecase)
+	cinemaUsecase := usecase.NewCinemaUsecase(cinemaRepo, movieRepo, movieUsecase)
+	bookingUsecase := usecase.NewBookingUsecase(bookingRepo, cinemaRepo, movieRepo, InitializePaymentProvider(),
+		getEnvDuration("BOOKING_HOLD_DURATION", usecase.DefaultSeatHoldDuration),
+		getEnvDuration("BOOKING_CANCEL_CUTOFF", usecase.DefaultCancellationCutoff))
+	StartHoldSweeper(bookingUsecase)
+	historyUsecase := usecase.NewHistoryUsecase(historyRepo, movieRepo, savedMovieRepo)
+	StartHistoryPruner(historyUsecase)
 
 	// Initialize handlers
 	return &Handlers{
-		UserHandler:  handler.NewUserHandler(userUsecase),
-		MovieHandler: handler.NewMovieHandler(movieUsecase),
-		DocsHandler:  handler.NewDocsHandler(),
+		UserHandler:           handler.NewUserHandler(userUsecase),
+		MovieHandler:          handler.NewMovieHandler(movieUsecase),
+		PersonHandler:         handler.NewPersonHandler(personUsecase),
+		ReviewHandler:         handler.NewReviewHandler(reviewUsecase),
+		SavedMovieHandler:     handler.NewSavedMovieHandler(savedMovieUsecase),
+		CollectionHandler:     handler.NewCollectionHandler(collectionUsecase),
+		MediaHandler:          handler.NewMediaHandler(mediaUsecase),
+		TranslationHandler:    handler.NewTranslationHandler(translationUsecase),
+		CommentHandler:        handler.NewCommentHandler(commentUsecase),
+		ReportHandler:         handler.NewReportHandler(reportUsecase),
+		RecommendationHandler: handler.NewRecommendationHandler(recommendationUsecase),
+		ProviderHandler:       handler.NewProviderHandler(providerUsecase),
+		CinemaHandler:         handler.NewCinemaHandler(cinemaUsecase),
+		BookingHandler:        handler.NewBookingHandler(bookingUsecase),
+		HistoryHandler:        handler.NewHistoryHandler(historyUsecase),
+		DocsHandler:           handler.NewDocsHandler(),
 	}
 }
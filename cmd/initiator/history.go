@@ -0,0 +1,33 @@
+package initiator
+
+import (
+	"eskalate-movie-api/internal/usecase"
+	"log"
+	"time"
+)
+
+const defaultHistoryPruneInterval = time.Hour
+
+// StartHistoryPruner deletes viewing history past each user's retention
+// period now and then every HISTORY_PRUNE_INTERVAL.
+func StartHistoryPruner(history *usecase.HistoryUsecase) {
+	interval := getEnvDuration("HISTORY_PRUNE_INTERVAL", defaultHistoryPruneInterval)
+	prune := func() {
+		deleted, err := history.PruneHistory()
+		if err != nil {
+			log.Printf("failed to prune viewing history: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("pruned %d viewing history entries", deleted)
+		}
+	}
+	go func() {
+		prune()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			prune()
+		}
+	}()
+}
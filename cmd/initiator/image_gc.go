@@ -0,0 +1,107 @@
+package initiator
+
+import (
+	"context"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/db"
+	"eskalate-movie-api/pkg/storage"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const defaultImageGCGracePeriod = 24 * time.Hour
+
+func newImageGCUsecase(dbConn *gorm.DB, images storage.ImageStore) *usecase.ImageGCUsecase {
+	return usecase.NewImageGCUsecase(repository.NewPostgresMovieRepo(dbConn), repository.NewPostgresMediaRepo(dbConn), images)
+}
+
+// StartImageGC deletes orphaned images every IMAGE_GC_INTERVAL in the
+// background. The job is off unless the interval is set.
+func StartImageGC(dbConn *gorm.DB, images storage.ImageStore) {
+	interval, err := time.ParseDuration(getEnv("IMAGE_GC_INTERVAL", "0"))
+	if err != nil || interval <= 0 {
+		if err != nil {
+			log.Printf("Warning: invalid IMAGE_GC_INTERVAL, image garbage collection disabled: %v", err)
+		}
+		return
+	}
+	gracePeriod := getEnvDuration("IMAGE_GC_GRACE_PERIOD", defaultImageGCGracePeriod)
+
+	gc := newImageGCUsecase(dbConn, images)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			report, err := gc.CollectOrphans(context.Background(), gracePeriod, false)
+			if err != nil {
+				log.Printf("image garbage collection failed: %v", err)
+				continue
+			}
+			log.Printf("image garbage collection: scanned %d, deleted %d orphans (%d bytes), %d failed",
+				report.Scanned, report.Deleted, report.OrphanBytes, len(report.Failed))
+		}
+	}()
+}
+
+// RunImageGC implements the gc-images command:
+//
+//	go run cmd/main.go gc-images [-dry-run] [-grace 24h]
+func RunImageGC(args []string) error {
+	flags := flag.NewFlagSet("gc-images", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "only report orphaned images, do not delete them")
+	gracePeriod := flags.Duration("grace", defaultImageGCGracePeriod, "keep unreferenced images younger than this")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	// A negative grace period would delete uploads that are still being saved
+	if *gracePeriod < 0 {
+		return fmt.Errorf("invalid -grace %s: must not be negative", *gracePeriod)
+	}
+
+	dbConn, err := db.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	images, err := InitializeImageStore()
+	if err != nil {
+		return fmt.Errorf("failed to initialize image storage: %w", err)
+	}
+
+	report, err := newImageGCUsecase(dbConn, images).CollectOrphans(context.Background(), *gracePeriod, *dryRun)
+	if err != nil {
+		return err
+	}
+	printImageGCReport(os.Stdout, report)
+	if len(report.Failed) > 0 {
+		return fmt.Errorf("%d orphaned images could not be deleted", len(report.Failed))
+	}
+	return nil
+}
+
+func printImageGCReport(w io.Writer, report *dto.ImageGCReport) {
+	action := "deleted"
+	if report.DryRun {
+		action = "would delete"
+	}
+	for _, orphan := range report.Orphans {
+		fmt.Fprintf(w, "%s %s (%d bytes, last modified %s)\n", action, orphan.Key, orphan.Size, orphan.LastModified.Format(time.RFC3339))
+	}
+	for _, failure := range report.Failed {
+		fmt.Fprintf(w, "failed %s\n", failure)
+	}
+	fmt.Fprintf(w, "\nscanned %d images: %d referenced, %d unreferenced but younger than %s, %d orphaned (%d bytes)\n",
+		report.Scanned, report.Referenced, report.TooRecent, report.GracePeriod, len(report.Orphans), report.OrphanBytes)
+	if report.DryRun {
+		fmt.Fprintln(w, "dry run: nothing was deleted")
+	} else {
+		fmt.Fprintf(w, "deleted %d orphaned images\n", report.Deleted)
+	}
+}
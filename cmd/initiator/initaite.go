@@ -2,7 +2,9 @@ package initiator
 
 import (
 	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/repository"
 	"eskalate-movie-api/pkg/db"
+	"eskalate-movie-api/pkg/storage/local"
 	"log"
 
 	"github.com/gin-gonic/gin"
@@ -19,13 +21,52 @@ func InitializeApp() *gin.Engine {
 	}
 
 	// Auto-migrate schema
-	dbConn.AutoMigrate(&domain.User{}, &domain.Movie{})
+	dbConn.AutoMigrate(&domain.User{}, &domain.Movie{}, &domain.Person{}, &domain.Credit{}, &domain.Review{}, &domain.SavedMovie{},
+		&domain.Collection{}, &domain.CollectionEntry{}, &domain.CollectionCollaborator{}, &domain.MovieMedia{}, &domain.MovieSlug{}, &domain.MovieTranslation{}, &domain.MovieStatusChange{},
+		&domain.Comment{}, &domain.CommentMention{}, &domain.Report{},
+		&domain.MovieViewCount{}, &domain.MovieScore{}, &domain.MovieSimilarity{},
+		&domain.Provider{}, &domain.MovieAvailability{},
+		&domain.Cinema{}, &domain.Screen{}, &domain.Screening{},
+		&domain.Seat{}, &domain.Booking{}, &domain.BookingSeat{},
+		&domain.WatchHistory{}, &domain.HistorySettings{})
+
+	// Turn actor names of older movies into people and credits
+	if err := repository.NewPostgresPersonRepo(dbConn).ImportActorCredits(); err != nil {
+		log.Printf("Warning: failed to import actor credits: %v", err)
+	}
+
+	// Enable fuzzy title matching for duplicate detection
+	if err := repository.NewPostgresMovieRepo(dbConn).PrepareDuplicateDetection(); err != nil {
+		log.Printf("Warning: failed to prepare duplicate detection: %v", err)
+	}
+
+	// Give movies created before slugs existed a slug
+	if err := repository.NewPostgresMovieRepo(dbConn).AssignMissingSlugs(); err != nil {
+		log.Printf("Warning: failed to assign movie slugs: %v", err)
+	}
+
+	// Initialize image storage
+	images, err := InitializeImageStore()
+	if err != nil {
+		log.Fatalf("failed to initialize image storage: %v", err)
+	}
+
+	// Periodically delete images nothing refers to any more
+	StartImageGC(dbConn, images)
+
+	// Keep the trending and popular rankings up to date
+	StartPopularityRefresh(dbConn)
 
 	// Initialize handlers
-	handlers := InitializeHandlers(dbConn)
+	handlers := InitializeHandlers(dbConn, images)
 
 	// Setup routes
 	SetupRoutes(r, handlers)
 
+	// Serve locally stored images
+	if store, ok := images.(*local.Store); ok {
+		r.Static(store.RoutePath(), store.Root())
+	}
+
 	return r
 }
@@ -0,0 +1,39 @@
+package initiator
+
+import (
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/wordfilter"
+	"log"
+	"os"
+	"strings"
+)
+
+// InitializeContentFilter builds the word filter from the comma-separated
+// CONTENT_FILTER_WORDS and the file at CONTENT_FILTER_FILE. It returns nil
+// when neither lists any words. CONTENT_FILTER_ACTION chooses whether
+// matching content is rejected (the default) or queued for moderators.
+func InitializeContentFilter(reportRepo repository.ReportRepository) *usecase.ContentFilter {
+	var terms []string
+	if words := os.Getenv("CONTENT_FILTER_WORDS"); words != "" {
+		terms = strings.Split(words, ",")
+	}
+	if path := os.Getenv("CONTENT_FILTER_FILE"); path != "" {
+		fileTerms, err := wordfilter.Load(path)
+		if err != nil {
+			log.Printf("Warning: failed to read CONTENT_FILTER_FILE: %v", err)
+		}
+		terms = append(terms, fileTerms...)
+	}
+	words := wordfilter.New(terms)
+	if words.Empty() {
+		return nil
+	}
+
+	action := getEnv("CONTENT_FILTER_ACTION", usecase.FilterReject)
+	if action != usecase.FilterReject && action != usecase.FilterQueue {
+		log.Printf("Warning: invalid CONTENT_FILTER_ACTION %q, rejecting filtered content", action)
+		action = usecase.FilterReject
+	}
+	return usecase.NewContentFilter(words, action, reportRepo)
+}
@@ -0,0 +1,55 @@
+package initiator
+
+import (
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/internal/usecase"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultViewDedupeWindow          = 30 * time.Minute
+	defaultViewFlushInterval         = 10 * time.Second
+	defaultPopularityRefreshInterval = 10 * time.Minute
+)
+
+// StartViewTracker starts counting movie views. A viewer counts once per
+// VIEW_DEDUPE_WINDOW; counts are written every VIEW_FLUSH_INTERVAL.
+func StartViewTracker(viewRepo repository.ViewRepository) *usecase.ViewTracker {
+	window := getEnvDuration("VIEW_DEDUPE_WINDOW", defaultViewDedupeWindow)
+	flushInterval := getEnvDuration("VIEW_FLUSH_INTERVAL", defaultViewFlushInterval)
+	views := usecase.NewViewTracker(viewRepo, window, flushInterval)
+	views.Start()
+	return views
+}
+
+// StartPopularityRefresh recomputes the trending and popular rankings now and
+// then every POPULARITY_REFRESH_INTERVAL. Trending counts the views of the
+// last TRENDING_WINDOW_DAYS days, halving their weight every
+// TRENDING_HALF_LIFE_DAYS.
+func StartPopularityRefresh(dbConn *gorm.DB) {
+	interval := getEnvDuration("POPULARITY_REFRESH_INTERVAL", defaultPopularityRefreshInterval)
+	windowDays := getEnvInt("TRENDING_WINDOW_DAYS", 7)
+	halfLifeDays := getEnvInt("TRENDING_HALF_LIFE_DAYS", 2)
+	if windowDays < 1 || halfLifeDays < 1 {
+		log.Printf("Warning: TRENDING_WINDOW_DAYS and TRENDING_HALF_LIFE_DAYS must be positive, using 7 and 2")
+		windowDays, halfLifeDays = 7, 2
+	}
+
+	viewRepo := repository.NewPostgresViewRepo(dbConn)
+	refresh := func() {
+		if err := viewRepo.RefreshScores(windowDays, float64(halfLifeDays)); err != nil {
+			log.Printf("failed to refresh movie rankings: %v", err)
+		}
+	}
+	go func() {
+		refresh()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			refresh()
+		}
+	}()
+}
@@ -0,0 +1,91 @@
+package initiator
+
+import (
+	"encoding/json"
+	"errors"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/db"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+)
+
+// StartProviderFeedSync imports the feed at PROVIDER_FEED_FILE on startup and
+// then every PROVIDER_FEED_INTERVAL. The job is off unless both are set.
+func StartProviderFeedSync(providers *usecase.ProviderUsecase) {
+	path := getEnv("PROVIDER_FEED_FILE", "")
+	interval := getEnvDuration("PROVIDER_FEED_INTERVAL", 0)
+	if path == "" || interval <= 0 {
+		return
+	}
+
+	sync := func() {
+		report, err := importProviderFeed(providers, path)
+		if err != nil {
+			log.Printf("failed to import provider feed: %v", err)
+			return
+		}
+		log.Printf("provider feed: %d providers, %d offers saved, %d for unknown movies, %d invalid",
+			report.Providers, report.Offers, report.UnmatchedMovies, len(report.Invalid))
+	}
+	go func() {
+		sync()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			sync()
+		}
+	}()
+}
+
+// RunImportProviders implements the import-providers command:
+//
+//	go run cmd/main.go import-providers feed.json
+func RunImportProviders(args []string) error {
+	flags := flag.NewFlagSet("import-providers", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import-providers <feed.json>")
+	}
+
+	dbConn, err := db.Connect()
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	providers := usecase.NewProviderUsecase(repository.NewPostgresProviderRepo(dbConn), repository.NewPostgresMovieRepo(dbConn))
+	report, err := importProviderFeed(providers, flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	printProviderFeedReport(os.Stdout, report)
+	return nil
+}
+
+func importProviderFeed(providers *usecase.ProviderUsecase, path string) (*dto.ProviderFeedReport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var feed dto.ProviderFeed
+	if err := json.NewDecoder(file).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("invalid feed %s: %w", path, err)
+	}
+	return providers.ImportFeed(&feed)
+}
+
+func printProviderFeedReport(w io.Writer, report *dto.ProviderFeedReport) {
+	for _, issue := range report.Invalid {
+		fmt.Fprintf(w, "skipped offer %d: %s\n", issue.Index, issue.Error)
+	}
+	fmt.Fprintf(w, "\nsynced %d providers: %d offers saved, %d offers for movies not in the catalog, %d invalid offers\n",
+		report.Providers, report.Offers, report.UnmatchedMovies, len(report.Invalid))
+}
@@ -0,0 +1,32 @@
+package initiator
+
+import (
+	"eskalate-movie-api/internal/usecase"
+	"log"
+	"time"
+)
+
+const defaultRecommendationsRefreshInterval = time.Hour
+
+// StartRecommendationRefresh recomputes similar movies now and then every
+// RECOMMENDATIONS_REFRESH_INTERVAL.
+func StartRecommendationRefresh(recommendations *usecase.RecommendationUsecase) {
+	interval := getEnvDuration("RECOMMENDATIONS_REFRESH_INTERVAL", defaultRecommendationsRefreshInterval)
+	refresh := func() {
+		started := time.Now()
+		pairs, err := recommendations.RefreshSimilarities()
+		if err != nil {
+			log.Printf("failed to refresh movie similarities: %v", err)
+			return
+		}
+		log.Printf("refreshed %d movie similarities in %s", pairs, time.Since(started).Round(time.Millisecond))
+	}
+	go func() {
+		refresh()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			refresh()
+		}
+	}()
+}
@@ -24,16 +24,192 @@ func SetupRoutes(r *gin.Engine, h *Handlers) {
 	// Movie routes
 	movies := r.Group("/movies")
 	{
-		// Public routes
-		movies.GET("", h.MovieHandler.GetMovies)
-		movies.GET("/:id", h.MovieHandler.GetMovieByID)
+		// Public routes, personalized when a token is supplied
+		movies.GET("", middleware.OptionalAuthMiddleware(), h.MovieHandler.GetMovies)
+		movies.GET("/trending", middleware.OptionalAuthMiddleware(), h.MovieHandler.GetTrendingMovies)
+		movies.GET("/popular", middleware.OptionalAuthMiddleware(), h.MovieHandler.GetPopularMovies)
+		movies.GET("/:id", middleware.OptionalAuthMiddleware(), h.MovieHandler.GetMovieByID)
+		movies.GET("/:id/reviews", middleware.OptionalAuthMiddleware(), h.ReviewHandler.GetMovieReviews)
+		movies.GET("/:id/media", middleware.OptionalAuthMiddleware(), h.MediaHandler.GetMovieMedia)
+		movies.GET("/:id/poster", middleware.OptionalAuthMiddleware(), h.MovieHandler.GetPosterStatus)
+		movies.GET("/:id/translations", middleware.OptionalAuthMiddleware(), h.TranslationHandler.GetMovieTranslations)
+		movies.GET("/:id/comments", middleware.OptionalAuthMiddleware(), h.CommentHandler.GetMovieComments)
+		movies.GET("/:id/similar", middleware.OptionalAuthMiddleware(), h.RecommendationHandler.GetSimilarMovies)
+		movies.GET("/:id/availability", middleware.OptionalAuthMiddleware(), h.ProviderHandler.GetMovieAvailability)
+		movies.GET("/:id/showtimes", middleware.OptionalAuthMiddleware(), h.CinemaHandler.GetShowtimes)
 
 		// Protected routes
 		protected := movies.Use(middleware.AuthMiddleware())
 		{
 			protected.POST("", h.MovieHandler.CreateMovie)
+			protected.POST("/import", h.MovieHandler.ImportMovies)
 			protected.PUT("/:id", h.MovieHandler.UpdateMovie)
 			protected.DELETE("/:id", h.MovieHandler.DeleteMovie)
+			protected.GET("/:id/status", h.MovieHandler.GetMovieStatus)
+			protected.PUT("/:id/status", h.MovieHandler.ChangeMovieStatus)
+			protected.PUT("/:id/poster", h.MovieHandler.ReplacePoster)
+			protected.POST("/:id/poster/retry", h.MovieHandler.RetryPosterUpload)
+			protected.POST("/:id/poster/upload-url", h.MovieHandler.RequestPosterUpload)
+			protected.POST("/:id/poster/confirm", h.MovieHandler.ConfirmPosterUpload)
+			protected.POST("/:id/credits", h.MovieHandler.AddCredit)
+			protected.DELETE("/:id/credits/:creditId", h.MovieHandler.RemoveCredit)
+			protected.POST("/:id/reviews", h.ReviewHandler.CreateReview)
+			protected.PUT("/:id/reviews/:reviewId", h.ReviewHandler.UpdateReview)
+			protected.DELETE("/:id/reviews/:reviewId", h.ReviewHandler.DeleteReview)
+			protected.POST("/:id/media", h.MediaHandler.UploadMedia)
+			protected.PUT("/:id/media/:mediaId", h.MediaHandler.UpdateMedia)
+			protected.DELETE("/:id/media/:mediaId", h.MediaHandler.DeleteMedia)
+			protected.PUT("/:id/translations/:locale", h.TranslationHandler.PutTranslation)
+			protected.DELETE("/:id/translations/:locale", h.TranslationHandler.DeleteTranslation)
+			protected.POST("/:id/comments", h.CommentHandler.CreateComment)
+		}
+	}
+
+	// Comment routes
+	comments := r.Group("/comments")
+	{
+		// Public routes, personalized when a token is supplied
+		comments.GET("/:id/replies", middleware.OptionalAuthMiddleware(), h.CommentHandler.GetReplies)
+
+		// Protected routes
+		protected := comments.Use(middleware.AuthMiddleware())
+		{
+			protected.PUT("/:id", h.CommentHandler.UpdateComment)
+			protected.DELETE("/:id", h.CommentHandler.DeleteComment)
+			protected.POST("/:id/hide", h.CommentHandler.HideComment)
+			protected.DELETE("/:id/hide", h.CommentHandler.UnhideComment)
+		}
+	}
+
+	// Report routes
+	r.POST("/reports", middleware.AuthMiddleware(), h.ReportHandler.CreateReport)
+
+	// Moderation routes
+	moderation := r.Group("/moderation", middleware.AuthMiddleware(), middleware.ModeratorMiddleware())
+	{
+		moderation.GET("/reports", h.ReportHandler.GetModerationQueue)
+		moderation.POST("/reports/:id/resolve", h.ReportHandler.ResolveReport)
+		moderation.POST("/reports/:id/dismiss", h.ReportHandler.DismissReport)
+		moderation.POST("/reports/:id/take-down", h.ReportHandler.TakeDownContent)
+	}
+
+	// Editorial routes
+	editor := r.Group("/editor", middleware.AuthMiddleware(), middleware.EditorMiddleware())
+	{
+		editor.GET("/review-queue", h.MovieHandler.GetReviewQueue)
+		editor.POST("/movies/:id/approve", h.MovieHandler.ApproveMovie)
+		editor.POST("/movies/:id/reject", h.MovieHandler.RejectMovie)
+
+		editor.POST("/providers", h.ProviderHandler.CreateProvider)
+		editor.PUT("/providers/:id", h.ProviderHandler.UpdateProvider)
+		editor.DELETE("/providers/:id", h.ProviderHandler.DeleteProvider)
+		editor.POST("/providers/import", h.ProviderHandler.ImportFeed)
+		editor.POST("/movies/:id/availability", h.ProviderHandler.CreateAvailability)
+		editor.PUT("/availability/:id", h.ProviderHandler.UpdateAvailability)
+		editor.DELETE("/availability/:id", h.ProviderHandler.DeleteAvailability)
+
+		editor.POST("/cinemas", h.CinemaHandler.CreateCinema)
+		editor.PUT("/cinemas/:id", h.CinemaHandler.UpdateCinema)
+		editor.DELETE("/cinemas/:id", h.CinemaHandler.DeleteCinema)
+		editor.POST("/cinemas/:id/screens", h.CinemaHandler.CreateScreen)
+		editor.PUT("/screens/:id", h.CinemaHandler.UpdateScreen)
+		editor.DELETE("/screens/:id", h.CinemaHandler.DeleteScreen)
+		editor.POST("/screenings", h.CinemaHandler.CreateScreening)
+		editor.PUT("/screenings/:id", h.CinemaHandler.UpdateScreening)
+		editor.DELETE("/screenings/:id", h.CinemaHandler.DeleteScreening)
+		editor.PUT("/screens/:id/seats", h.BookingHandler.ReplaceSeatMap)
+		editor.POST("/tickets/verify", h.BookingHandler.VerifyTicket)
+	}
+
+	// Where-to-watch routes
+	r.GET("/providers", h.ProviderHandler.GetProviders)
+
+	// Cinema routes
+	cinemas := r.Group("/cinemas")
+	{
+		cinemas.GET("", h.CinemaHandler.GetCinemas)
+		cinemas.GET("/:id", h.CinemaHandler.GetCinema)
+		cinemas.GET("/:id/schedule", middleware.OptionalAuthMiddleware(), h.CinemaHandler.GetSchedule)
+	}
+
+	// Booking routes
+	r.GET("/screenings/:id/seats", middleware.OptionalAuthMiddleware(), h.BookingHandler.GetSeatMap)
+	r.POST("/screenings/:id/holds", middleware.AuthMiddleware(), h.BookingHandler.HoldSeats)
+	bookings := r.Group("/bookings", middleware.AuthMiddleware())
+	{
+		bookings.GET("/:id", h.BookingHandler.GetBooking)
+		bookings.POST("/:id/confirm", h.BookingHandler.ConfirmBooking)
+		bookings.POST("/:id/cancel", h.BookingHandler.CancelBooking)
+	}
+
+	// Administration routes
+	admin := r.Group("/admin", middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	{
+		admin.POST("/movies/:id/merge", h.MovieHandler.MergeMovies)
+	}
+
+	// Personal list routes
+	me := r.Group("/me", middleware.AuthMiddleware())
+	{
+		me.GET("/watchlist", h.SavedMovieHandler.GetWatchlist)
+		me.POST("/watchlist", h.SavedMovieHandler.AddToWatchlist)
+		me.PUT("/watchlist/:movieId", h.SavedMovieHandler.UpdateWatchlistItem)
+		me.DELETE("/watchlist/:movieId", h.SavedMovieHandler.RemoveFromWatchlist)
+
+		me.GET("/favorites", h.SavedMovieHandler.GetFavorites)
+		me.POST("/favorites", h.SavedMovieHandler.AddToFavorites)
+		me.PUT("/favorites/:movieId", h.SavedMovieHandler.MoveFavorite)
+		me.DELETE("/favorites/:movieId", h.SavedMovieHandler.RemoveFromFavorites)
+
+		me.GET("/collections", h.CollectionHandler.GetMyCollections)
+
+		me.GET("/recommendations", h.RecommendationHandler.GetRecommendations)
+
+		me.GET("/bookings", h.BookingHandler.GetMyBookings)
+
+		me.GET("/history", h.HistoryHandler.GetHistory)
+		me.DELETE("/history", h.HistoryHandler.ClearHistory)
+		me.GET("/history/settings", h.HistoryHandler.GetSettings)
+		me.PUT("/history/settings", h.HistoryHandler.UpdateSettings)
+		me.PUT("/history/:movieId", h.HistoryHandler.RecordProgress)
+		me.DELETE("/history/:movieId", h.HistoryHandler.DeleteEntry)
+		me.GET("/continue-watching", h.HistoryHandler.GetContinueWatching)
+	}
+
+	// Collection routes
+	collections := r.Group("/collections")
+	{
+		// Public routes
+		collections.GET("", h.CollectionHandler.GetPublicCollections)
+		collections.GET("/:id", middleware.OptionalAuthMiddleware(), h.CollectionHandler.GetCollection)
+
+		// Protected routes
+		protected := collections.Use(middleware.AuthMiddleware())
+		{
+			protected.POST("", h.CollectionHandler.CreateCollection)
+			protected.PUT("/:id", h.CollectionHandler.UpdateCollection)
+			protected.DELETE("/:id", h.CollectionHandler.DeleteCollection)
+			protected.POST("/:id/movies", h.CollectionHandler.AddEntry)
+			protected.PUT("/:id/movies/:movieId", h.CollectionHandler.UpdateEntry)
+			protected.DELETE("/:id/movies/:movieId", h.CollectionHandler.RemoveEntry)
+			protected.POST("/:id/collaborators", h.CollectionHandler.InviteCollaborator)
+			protected.POST("/:id/collaborators/accept", h.CollectionHandler.AcceptInvitation)
+			protected.DELETE("/:id/collaborators/:userId", h.CollectionHandler.RemoveCollaborator)
+		}
+	}
+
+	// People routes
+	people := r.Group("/people")
+	{
+		// Public routes
+		people.GET("", h.PersonHandler.GetPeople)
+		people.GET("/:id", middleware.OptionalAuthMiddleware(), h.PersonHandler.GetPersonByID)
+
+		// Editor routes
+		protected := people.Use(middleware.AuthMiddleware(), middleware.EditorMiddleware())
+		{
+			protected.POST("", h.PersonHandler.CreatePerson)
+			protected.PUT("/:id", h.PersonHandler.UpdatePerson)
 		}
 	}
 }
@@ -0,0 +1,73 @@
+package initiator
+
+import (
+	"eskalate-movie-api/pkg/cloudinary"
+	"eskalate-movie-api/pkg/storage"
+	"eskalate-movie-api/pkg/storage/local"
+	"eskalate-movie-api/pkg/storage/s3"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// InitializeImageStore builds the image backend selected by STORAGE_BACKEND:
+// cloudinary (default), local or s3.
+func InitializeImageStore() (storage.ImageStore, error) {
+	switch backend := getEnv("STORAGE_BACKEND", "cloudinary"); backend {
+	case "cloudinary":
+		return cloudinary.NewStore(
+			os.Getenv("CLOUDINARY_CLOUD_NAME"),
+			os.Getenv("CLOUDINARY_API_KEY"),
+			os.Getenv("CLOUDINARY_API_SECRET"),
+		)
+	case "local":
+		return local.New(
+			getEnv("LOCAL_STORAGE_DIR", "uploads"),
+			getEnv("LOCAL_STORAGE_BASE_URL", "http://localhost:8080/media"),
+		)
+	case "s3":
+		return s3.New(s3.Config{
+			Endpoint:     os.Getenv("S3_ENDPOINT"),
+			Region:       os.Getenv("S3_REGION"),
+			Bucket:       os.Getenv("S3_BUCKET"),
+			AccessKey:    os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:    os.Getenv("S3_SECRET_KEY"),
+			PublicURL:    os.Getenv("S3_PUBLIC_URL"),
+			UsePathStyle: getEnv("S3_USE_PATH_STYLE", "true") == "true",
+		})
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_BACKEND %q", backend)
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvDuration reads a positive duration such as "15m", falling back when
+// the variable is unset or invalid.
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Warning: invalid %s, using %s", key, fallback)
+		return fallback
+	}
+	return duration
+}
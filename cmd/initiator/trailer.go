@@ -0,0 +1,15 @@
+package initiator
+
+import (
+	"eskalate-movie-api/pkg/trailer"
+	"os"
+)
+
+// InitializeTrailerVerifier returns an oEmbed client when TRAILER_VERIFY is
+// true. OEMBED_YOUTUBE_URL and OEMBED_VIMEO_URL can point it at a stub.
+func InitializeTrailerVerifier() trailer.Verifier {
+	if os.Getenv("TRAILER_VERIFY") != "true" {
+		return nil
+	}
+	return trailer.NewOEmbedClient(os.Getenv("OEMBED_YOUTUBE_URL"), os.Getenv("OEMBED_VIMEO_URL"))
+}
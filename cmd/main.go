@@ -3,6 +3,7 @@ package main
 import (
 	"eskalate-movie-api/cmd/initiator"
 	"log"
+	"os"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +14,25 @@ func main() {
 		log.Printf("Warning: .env file not found or error loading it: %v", err)
 	}
 
+	// Maintenance commands
+	if len(os.Args) > 1 {
+		var err error
+		switch os.Args[1] {
+		case "gc-images":
+			err = initiator.RunImageGC(os.Args[2:])
+		case "promote-admin":
+			err = initiator.RunPromoteAdmin(os.Args[2:])
+		case "import-providers":
+			err = initiator.RunImportProviders(os.Args[2:])
+		default:
+			log.Fatalf("unknown command %q", os.Args[1])
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Initialize the application
 	r := initiator.InitializeApp()
 
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Booking statuses. A held booking reserves its seats until ExpiresAt and
+// becomes confirmed once paid.
+const (
+	BookingHeld      = "held"
+	BookingConfirmed = "confirmed"
+	BookingCancelled = "cancelled"
+	BookingExpired   = "expired"
+)
+
+// Seat is a place in a screen's seat map, e.g. row "F", number 12.
+type Seat struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ScreenID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_seats_screen_place" json:"screen_id"`
+	Row        string    `gorm:"size:5;not null;uniqueIndex:idx_seats_screen_place" json:"row"`
+	Number     int       `gorm:"not null;uniqueIndex:idx_seats_screen_place" json:"number"`
+	Accessible bool      `gorm:"not null;default:false" json:"accessible"` // Wheelchair space
+	Screen     *Screen   `gorm:"foreignKey:ScreenID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Label names the seat the way it is printed on tickets, e.g. "F12".
+func (s *Seat) Label() string {
+	return s.Row + strconv.Itoa(s.Number)
+}
+
+// Booking is a user's reservation of seats for a screening. SeatLabels keeps
+// the seats on record after a cancelled or expired booking releases them.
+// Bookings keep their screening from being deleted; see
+// repository.clearScreeningBookings.
+type Booking struct {
+	ID               uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ScreeningID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"screening_id"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Status           string     `gorm:"size:20;not null;index" json:"status"`
+	SeatLabels       []string   `gorm:"type:text[];not null" json:"seat_labels"`
+	Amount           float64    `gorm:"type:numeric(10,2);not null;default:0" json:"amount"`
+	Currency         string     `gorm:"size:3" json:"currency"`
+	ExpiresAt        *time.Time `json:"expires_at"` // End of the hold
+	PaymentProvider  string     `json:"payment_provider"`
+	PaymentReference string     `json:"payment_reference"`
+	Code             *string    `gorm:"size:20;uniqueIndex" json:"code"` // Ticket verification code, set on confirmation
+	ConfirmedAt      *time.Time `json:"confirmed_at"`
+	CancelledAt      *time.Time `json:"cancelled_at"`
+	CheckedInAt      *time.Time `json:"checked_in_at"`
+	RefundedAt       *time.Time `json:"refunded_at"` // Set once the payment of a cancelled booking is paid back
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	Screening        *Screening `gorm:"foreignKey:ScreeningID;constraint:OnDelete:RESTRICT" json:"-"`
+}
+
+// BookingSeat ties a seat to the active booking holding it. The unique index
+// on screening and seat is what prevents double booking: of two concurrent
+// holds on a seat only one insert succeeds. Rows are removed when a booking
+// is cancelled or its hold expires.
+type BookingSeat struct {
+	BookingID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"booking_id"`
+	SeatID      uuid.UUID `gorm:"type:uuid;primaryKey;uniqueIndex:idx_booking_seats_screening_seat,priority:2" json:"seat_id"`
+	ScreeningID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_booking_seats_screening_seat,priority:1" json:"screening_id"`
+	Booking     *Booking  `gorm:"foreignKey:BookingID;constraint:OnDelete:CASCADE" json:"-"`
+	Seat        *Seat     `gorm:"foreignKey:SeatID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Projection formats of a screening.
+const (
+	Format2D     = "2d"
+	Format3D     = "3d"
+	FormatIMAX   = "imax"
+	FormatIMAX3D = "imax_3d"
+)
+
+// Cinema is a movie theater. Screening times are shown in its time zone.
+type Cinema struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name      string    `gorm:"not null" json:"name"`
+	City      string    `gorm:"not null;index" json:"city"`
+	Country   string    `gorm:"size:2;not null" json:"country"` // ISO 3166-1 alpha-2
+	Address   string    `json:"address"`
+	TimeZone  string    `gorm:"not null" json:"time_zone"` // IANA name, e.g. Europe/Berlin
+	Latitude  *float64  `json:"latitude"`
+	Longitude *float64  `json:"longitude"`
+	Website   string    `json:"website"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Screen is an auditorium of a cinema.
+type Screen struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CinemaID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_screens_cinema_name" json:"cinema_id"`
+	Name      string    `gorm:"not null;uniqueIndex:idx_screens_cinema_name" json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Cinema    *Cinema   `gorm:"foreignKey:CinemaID;constraint:OnDelete:CASCADE" json:"cinema,omitempty"`
+}
+
+// Screening is a showing of a movie on a screen. Times are stored in UTC;
+// CinemaID repeats the screen's cinema for lookups by cinema and city.
+type Screening struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID   uuid.UUID `gorm:"type:uuid;not null;index" json:"movie_id"`
+	ScreenID  uuid.UUID `gorm:"type:uuid;not null;index:idx_screenings_screen_start" json:"screen_id"`
+	CinemaID  uuid.UUID `gorm:"type:uuid;not null;index" json:"cinema_id"`
+	StartsAt  time.Time `gorm:"not null;index:idx_screenings_screen_start;index" json:"starts_at"`
+	EndsAt    time.Time `gorm:"not null" json:"ends_at"`
+	Language  string    `gorm:"size:2" json:"language"`  // Spoken language, ISO 639-1
+	Subtitles string    `gorm:"size:2" json:"subtitles"` // Subtitle language, empty for none
+	Format    string    `gorm:"size:10;not null" json:"format"`
+	Price     float64   `gorm:"type:numeric(10,2);not null;default:0" json:"price"` // Per seat; free when 0
+	Currency  string    `gorm:"size:3" json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Movie     *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+	Screen    *Screen   `gorm:"foreignKey:ScreenID;constraint:OnDelete:CASCADE" json:"screen,omitempty"`
+	Cinema    *Cinema   `gorm:"foreignKey:CinemaID;constraint:OnDelete:CASCADE" json:"cinema,omitempty"`
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	VisibilityPrivate  = "private"
+	VisibilityUnlisted = "unlisted" // Reachable through its share URL but not listed publicly
+	VisibilityPublic   = "public"
+
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+)
+
+type Collection struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	OwnerID     uuid.UUID `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Name        string    `gorm:"not null" json:"name"`
+	Description string    `json:"description"`
+	Visibility  string    `gorm:"not null;default:private;index" json:"visibility"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CollectionEntry struct {
+	ID           uuid.UUID   `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CollectionID uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_collection_entries_collection_movie" json:"collection_id"`
+	MovieID      uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_collection_entries_collection_movie" json:"movie_id"`
+	Position     int         `gorm:"not null" json:"position"`
+	Note         string      `json:"note"`
+	AddedBy      uuid.UUID   `gorm:"type:uuid;not null" json:"added_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	Collection   *Collection `gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE" json:"-"`
+	Movie        *Movie      `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"movie,omitempty"`
+}
+
+type CollectionCollaborator struct {
+	ID           uuid.UUID   `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	CollectionID uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_collection_collaborators_collection_user" json:"collection_id"`
+	UserID       uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_collection_collaborators_collection_user" json:"user_id"`
+	CanEdit      bool        `gorm:"not null;default:false" json:"can_edit"`
+	Status       string      `gorm:"not null;default:pending" json:"status"`
+	InvitedBy    uuid.UUID   `gorm:"type:uuid;not null" json:"invited_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	Collection   *Collection `gorm:"foreignKey:CollectionID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a post on a movie page or, with a parent, a reply to one.
+// Deleted comments that still have replies keep their place in the thread
+// without their body.
+type Comment struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID      uuid.UUID  `gorm:"type:uuid;not null;index:idx_comments_movie_parent" json:"movie_id"`
+	ParentID     *uuid.UUID `gorm:"type:uuid;index:idx_comments_movie_parent" json:"parent_id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Body         string     `gorm:"not null" json:"body"`
+	ReplyCount   int        `gorm:"not null;default:0" json:"reply_count"` // Direct replies
+	Hidden       bool       `gorm:"not null;default:false" json:"hidden"`
+	HiddenBy     *uuid.UUID `gorm:"type:uuid" json:"hidden_by"`
+	HiddenReason string     `json:"hidden_reason"`
+	Deleted      bool       `gorm:"not null;default:false" json:"deleted"`
+	EditedAt     *time.Time `json:"edited_at"`
+	CreatedAt    time.Time  `gorm:"index" json:"created_at"`
+	Movie        *Movie     `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+	Parent       *Comment   `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// CommentMention is a user mentioned with @username in a comment.
+type CommentMention struct {
+	CommentID uuid.UUID `gorm:"type:uuid;primaryKey" json:"comment_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"user_id"`
+	Username  string    `gorm:"not null" json:"username"`
+	Comment   *Comment  `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE" json:"-"`
+}
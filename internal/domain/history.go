@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WatchHistory is a user's viewing of a movie: how far they got the last
+// time and how often they watched it to the end. Watching a finished movie
+// again starts a new run on the same entry.
+type WatchHistory struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_watch_histories_user_movie;index:idx_watch_histories_user_last,priority:1" json:"user_id"`
+	MovieID         uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_watch_histories_user_movie" json:"movie_id"`
+	Progress        float64    `gorm:"not null;default:0" json:"progress"` // Percent of the movie watched
+	PositionSeconds int        `gorm:"not null;default:0" json:"position_seconds"`
+	Completed       bool       `gorm:"not null;default:false" json:"completed"`
+	TimesCompleted  int        `gorm:"not null;default:0" json:"times_completed"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	StartedAt       time.Time  `json:"started_at"`
+	LastWatchedAt   time.Time  `gorm:"not null;index:idx_watch_histories_user_last,priority:2" json:"last_watched_at"`
+	Movie           *Movie     `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"movie,omitempty"`
+}
+
+// HistorySettings are a user's privacy choices for their viewing history.
+// Users without a row use the zero value: recording on, kept forever.
+type HistorySettings struct {
+	UserID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Paused        bool      `gorm:"not null;default:false" json:"paused"`     // Progress is not recorded while paused
+	RetentionDays int       `gorm:"not null;default:0" json:"retention_days"` // Entries untouched for longer are deleted; 0 keeps them
+	UpdatedAt     time.Time `json:"updated_at"`
+}
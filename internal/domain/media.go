@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MediaBackdrop = "backdrop"
+	MediaStill    = "still"
+	MediaPoster   = "poster" // Alternate posters; the main poster lives on Movie
+)
+
+// MovieMedia is an image in a movie's gallery. Images are ordered per kind
+// and at most one image of each kind is primary. Language is empty for
+// images without text.
+type MovieMedia struct {
+	ID         uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID    uuid.UUID      `gorm:"type:uuid;not null;index" json:"movie_id"`
+	Kind       string         `gorm:"not null" json:"kind"`
+	Caption    string         `json:"caption"`
+	Language   string         `gorm:"size:2" json:"language"`
+	IsPrimary  bool           `gorm:"not null;default:false" json:"is_primary"`
+	Position   int            `gorm:"not null" json:"position"`
+	URL        string         `gorm:"not null" json:"url"`
+	Key        string         `gorm:"not null" json:"key"`
+	Variants   []ImageVariant `gorm:"serializer:json;type:jsonb" json:"variants"`
+	Width      int            `json:"width"`
+	Height     int            `json:"height"`
+	UploadedBy uuid.UUID      `gorm:"type:uuid;not null" json:"uploaded_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+	Movie      *Movie         `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"movie,omitempty"`
+}
@@ -1,14 +1,91 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Poster processing states. Movies created with an asynchronous upload stay
+// pending until a worker has processed the staged original.
+const (
+	PosterReady      = "ready"
+	PosterPending    = "pending"
+	PosterProcessing = "processing"
+	PosterFailed     = "failed"
+)
+
+// Movie statuses. Only published movies are public, and only once PublishAt
+// has passed; the others are visible to their owner and to editors.
+const (
+	MovieDraft     = "draft"
+	MovieInReview  = "in_review"
+	MoviePublished = "published"
+	MovieArchived  = "archived"
+)
 
 type Movie struct {
-	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Title       string    `gorm:"not null" json:"title"`
-	Description string    `gorm:"not null" json:"description"`
-	Poster      string    `gorm:"not null" json:"poster"`
-	Trailer     string    `gorm:"not null" json:"trailer"`
-	Actors      []string  `gorm:"type:text[];not null" json:"actors"`
-	Genres      []string  `gorm:"type:text[];not null" json:"genres"`
-	UserID      uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	ID               uuid.UUID         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Title            string            `gorm:"not null" json:"title"`
+	NormalizedTitle  string            `gorm:"index" json:"normalized_title"` // See titles.Normalize; used to detect duplicates
+	Slug             string            `gorm:"index" json:"slug"`             // Current slug; see MovieSlug
+	Description      string            `gorm:"not null" json:"description"`
+	Tagline          string            `json:"tagline"`
+	Poster           string            `gorm:"not null" json:"poster"`
+	PosterKey        string            `json:"poster_key"` // Storage key of an uploaded poster; empty for external URLs
+	PosterVariants   []ImageVariant    `gorm:"serializer:json;type:jsonb" json:"poster_variants"`
+	PosterStatus     string            `gorm:"not null;default:'ready';index" json:"poster_status"`
+	PosterError      string            `json:"poster_error"`
+	PosterAttempts   int               `gorm:"not null;default:0" json:"poster_attempts"`
+	PosterUploadKey  string            `json:"poster_upload_key"` // Staged original of a pending or failed upload
+	Trailer          string            `gorm:"not null" json:"trailer"`
+	TrailerProvider  string            `gorm:"size:20" json:"trailer_provider"`
+	TrailerVideoID   string            `json:"trailer_video_id"`
+	TrailerThumbnail string            `json:"trailer_thumbnail"`
+	Actors           []string          `gorm:"type:text[];not null" json:"actors"`
+	Genres           []string          `gorm:"type:text[];not null" json:"genres"`
+	UserID           uuid.UUID         `gorm:"type:uuid;not null" json:"user_id"`
+	ReleaseDate      *time.Time        `gorm:"type:date;index" json:"release_date"`
+	RuntimeMinutes   int               `gorm:"not null;default:0" json:"runtime_minutes"`
+	OriginalLanguage string            `gorm:"size:2" json:"original_language"`
+	Countries        []string          `gorm:"type:text[]" json:"countries"`
+	Certifications   map[string]string `gorm:"serializer:json;type:jsonb" json:"certifications"` // region code -> rating, e.g. "US": "PG-13"
+	ImdbID           string            `gorm:"index" json:"imdb_id"`
+	TmdbID           string            `gorm:"index" json:"tmdb_id"`
+	AverageRating    float64           `gorm:"not null;default:0;index" json:"average_rating"`
+	RatingCount      int               `gorm:"not null;default:0" json:"rating_count"`
+	Status           string            `gorm:"size:20;not null;default:'published';index" json:"status"`
+	PublishAt        *time.Time        `gorm:"index" json:"publish_at"` // When a published movie goes live; nil means right away
+	SubmittedAt      *time.Time        `json:"submitted_at"`            // When the movie last entered review
+}
+
+// MovieStatusChange records a status transition and the reviewer's comment.
+type MovieStatusChange struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID    uuid.UUID `gorm:"type:uuid;not null;index" json:"movie_id"`
+	FromStatus string    `gorm:"size:20;not null" json:"from_status"`
+	ToStatus   string    `gorm:"size:20;not null" json:"to_status"`
+	Comment    string    `json:"comment"`
+	ChangedBy  uuid.UUID `gorm:"type:uuid;not null" json:"changed_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	Movie      *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// MovieSlug records every slug a movie has had. Slugs are never handed to
+// another movie, so links using an old slug keep working.
+type MovieSlug struct {
+	Slug      string    `gorm:"primaryKey" json:"slug"`
+	MovieID   uuid.UUID `gorm:"type:uuid;not null;index" json:"movie_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Movie     *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// ImageVariant is one resized and re-encoded rendition of an uploaded image.
+type ImageVariant struct {
+	Name   string `json:"name"`   // Size name, e.g. thumbnail, card or full for posters
+	Format string `json:"format"` // jpeg or webp
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
 }
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CreditRoleActor    = "actor"
+	CreditRoleDirector = "director"
+	CreditRoleWriter   = "writer"
+)
+
+type Person struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name      string     `gorm:"not null;index" json:"name"`
+	Bio       string     `json:"bio"`
+	BirthDate *time.Time `gorm:"type:date" json:"birth_date"`
+	Photo     string     `json:"photo"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Credit links a person to a movie in a given role. Character is only
+// meaningful for actor credits.
+type Credit struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID   uuid.UUID `gorm:"type:uuid;not null;index" json:"movie_id"`
+	PersonID  uuid.UUID `gorm:"type:uuid;not null;index" json:"person_id"`
+	Role      string    `gorm:"not null" json:"role"`
+	Character string    `json:"character"`
+	Order     int       `gorm:"column:credit_order;not null;default:0" json:"order"`
+	Movie     *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"movie,omitempty"`
+	Person    *Person   `gorm:"foreignKey:PersonID;constraint:OnDelete:CASCADE" json:"person,omitempty"`
+}
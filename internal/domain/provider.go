@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ways a provider offers a movie.
+const (
+	OfferStream = "stream" // Included in a subscription or free
+	OfferRent   = "rent"
+	OfferBuy    = "buy"
+)
+
+// Video qualities of an offer.
+const (
+	QualitySD  = "sd"
+	QualityHD  = "hd"
+	QualityUHD = "uhd"
+)
+
+// Where an availability came from. Feed imports only replace what earlier
+// feed imports added.
+const (
+	AvailabilityManual = "manual"
+	AvailabilityFeed   = "feed"
+)
+
+// Provider is a service movies can be watched on, such as a streaming
+// platform or a digital store.
+type Provider struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Slug        string    `gorm:"size:50;not null;uniqueIndex" json:"slug"` // Stable key used by filters and feeds, e.g. netflix
+	Name        string    `gorm:"not null" json:"name"`
+	LogoURL     string    `json:"logo_url"`
+	HomepageURL string    `json:"homepage_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MovieAvailability is an offer to stream, rent or buy a movie on a provider
+// in one country. Price is empty for streaming included in a subscription.
+type MovieAvailability struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_movie_availabilities_offer" json:"movie_id"`
+	ProviderID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_movie_availabilities_offer;index" json:"provider_id"`
+	Country        string     `gorm:"size:2;not null;uniqueIndex:idx_movie_availabilities_offer;index" json:"country"` // ISO 3166-1 alpha-2
+	Type           string     `gorm:"size:10;not null;uniqueIndex:idx_movie_availabilities_offer" json:"type"`
+	Quality        string     `gorm:"size:10;not null;uniqueIndex:idx_movie_availabilities_offer" json:"quality"`
+	Price          *float64   `gorm:"type:numeric(10,2)" json:"price"`
+	Currency       string     `gorm:"size:3" json:"currency"` // ISO 4217
+	Link           string     `gorm:"not null" json:"link"`
+	AvailableUntil *time.Time `json:"available_until"` // Offers are hidden once they expire
+	Source         string     `gorm:"size:10;not null;default:'manual'" json:"source"`
+	UpdatedBy      *uuid.UUID `gorm:"type:uuid" json:"updated_by"` // Empty for feed imports
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Movie          *Movie     `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+	Provider       *Provider  `gorm:"foreignKey:ProviderID;constraint:OnDelete:CASCADE" json:"provider,omitempty"`
+}
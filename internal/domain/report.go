@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of content that can be reported.
+const (
+	ReportTargetMovie   = "movie"
+	ReportTargetReview  = "review"
+	ReportTargetComment = "comment"
+)
+
+// Report statuses. Open reports make up the moderation queue.
+const (
+	ReportOpen      = "open"
+	ReportResolved  = "resolved"   // Handled without removing the content
+	ReportDismissed = "dismissed"  // Nothing wrong with the content
+	ReportTakenDown = "taken_down" // The content was removed
+)
+
+// ReasonFiltered marks reports filed by the word filter rather than a user.
+const ReasonFiltered = "filtered"
+
+// Report flags a movie, review or comment for moderators. Reports filed by
+// the word filter have no reporter.
+type Report struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TargetType     string     `gorm:"size:20;not null;index:idx_reports_target;uniqueIndex:idx_reports_reporter_target" json:"target_type"`
+	TargetID       uuid.UUID  `gorm:"type:uuid;not null;index:idx_reports_target;uniqueIndex:idx_reports_reporter_target" json:"target_id"`
+	MovieID        uuid.UUID  `gorm:"type:uuid;not null;index" json:"movie_id"`                             // The movie the content belongs to
+	ReporterID     *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_reports_reporter_target" json:"reporter_id"` // One report per user and target
+	Reason         string     `gorm:"size:30;not null" json:"reason"`
+	Details        string     `json:"details"`
+	Status         string     `gorm:"size:20;not null;default:'open';index" json:"status"`
+	ResolvedBy     *uuid.UUID `gorm:"type:uuid" json:"resolved_by"`
+	ResolutionNote string     `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `gorm:"index" json:"created_at"`
+	Movie          *Movie     `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+}
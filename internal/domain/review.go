@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Review struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_movie_user" json:"movie_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reviews_movie_user" json:"user_id"`
+	Rating    int       `gorm:"not null" json:"rating"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Movie     *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+}
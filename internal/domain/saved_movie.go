@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ListWatchlist = "watchlist"
+	ListFavorites = "favorites"
+)
+
+// SavedMovie is an entry of one of a user's personal movie lists. Watched
+// and WatchedAt only apply to the watchlist.
+type SavedMovie struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_saved_movies_user_list_movie" json:"user_id"`
+	List      string     `gorm:"not null;uniqueIndex:idx_saved_movies_user_list_movie" json:"list"`
+	MovieID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_saved_movies_user_list_movie" json:"movie_id"`
+	Position  int        `gorm:"not null" json:"position"`
+	Watched   bool       `gorm:"not null;default:false" json:"watched"`
+	WatchedAt *time.Time `json:"watched_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	Movie     *Movie     `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"movie,omitempty"`
+}
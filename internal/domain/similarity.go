@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovieSimilarity is one of the most similar movies of a movie, as last
+// computed by the recommendation job, with the reasons it was picked.
+type MovieSimilarity struct {
+	MovieID      uuid.UUID `gorm:"type:uuid;primaryKey" json:"movie_id"`
+	SimilarID    uuid.UUID `gorm:"type:uuid;primaryKey;index" json:"similar_id"`
+	Score        float64   `gorm:"not null" json:"score"`
+	ContentScore float64   `gorm:"not null" json:"content_score"`
+	RatingScore  float64   `gorm:"not null" json:"rating_score"`
+	Reasons      []string  `gorm:"type:text[];not null" json:"reasons"`
+	SharedGenres []string  `gorm:"type:text[];not null" json:"shared_genres"`
+	SharedPeople []string  `gorm:"type:text[];not null" json:"shared_people"`
+	ComputedAt   time.Time `json:"computed_at"`
+	Movie        *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+	Similar      *Movie    `gorm:"foreignKey:SimilarID;constraint:OnDelete:CASCADE" json:"-"`
+}
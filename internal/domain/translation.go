@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovieTranslation holds a movie's texts in one locale. Empty description or
+// tagline fall back to the original.
+type MovieTranslation struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MovieID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_movie_translations_movie_locale" json:"movie_id"`
+	Locale      string    `gorm:"size:35;not null;uniqueIndex:idx_movie_translations_movie_locale" json:"locale"` // BCP 47 tag, e.g. fr or pt-BR
+	Title       string    `gorm:"not null" json:"title"`
+	Description string    `json:"description"`
+	Tagline     string    `json:"tagline"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Movie       *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -2,9 +2,27 @@ package domain
 
 import "github.com/google/uuid"
 
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator" // Moderates community content such as comments
+	RoleEditor    = "editor"    // Reviews and publishes movies, and moderates
+	RoleAdmin     = "admin"     // Everything an editor can do, plus administration
+)
+
+// IsEditor reports whether the role may review and publish movies.
+func IsEditor(role string) bool {
+	return role == RoleEditor || role == RoleAdmin
+}
+
+// IsModerator reports whether the role may moderate community content.
+func IsModerator(role string) bool {
+	return role == RoleModerator || IsEditor(role)
+}
+
 type User struct {
 	ID       uuid.UUID `json:"id"`
 	Username string    `json:"username"`
 	Email    string    `json:"email"`
 	Password string    `json:"password"`
+	Role     string    `gorm:"not null;default:'user'" json:"role"`
 }
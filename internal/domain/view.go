@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovieViewCount counts the views of a movie on one day (UTC). A viewer is
+// counted once per dedupe window.
+type MovieViewCount struct {
+	MovieID uuid.UUID `gorm:"type:uuid;primaryKey" json:"movie_id"`
+	Day     time.Time `gorm:"type:date;primaryKey;index" json:"day"`
+	Views   int64     `gorm:"not null;default:0" json:"views"`
+	Movie   *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// MovieScore is the precomputed popularity of a movie, refreshed on a
+// schedule from its view counts.
+type MovieScore struct {
+	MovieID       uuid.UUID `gorm:"type:uuid;primaryKey" json:"movie_id"`
+	TrendingScore float64   `gorm:"not null;default:0;index" json:"trending_score"` // Recent views, decayed by age
+	TotalViews    int64     `gorm:"not null;default:0;index" json:"total_views"`
+	ComputedAt    time.Time `json:"computed_at"`
+	Movie         *Movie    `gorm:"foreignKey:MovieID;constraint:OnDelete:CASCADE" json:"-"`
+}
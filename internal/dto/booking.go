@@ -0,0 +1,97 @@
+package dto
+
+import "time"
+
+// SeatMapRequest replaces a screen's seats, row by row. Seats of a row are
+// numbered from 1.
+type SeatMapRequest struct {
+	Rows []SeatRowRequest `json:"rows" binding:"required,min=1,max=50,dive"`
+}
+
+type SeatRowRequest struct {
+	Row        string `json:"row" binding:"required,max=5,alphanum"`
+	Seats      int    `json:"seats" binding:"required,min=1,max=100"`
+	Accessible []int  `json:"accessible" binding:"max=100,dive,min=1"` // Numbers of wheelchair spaces
+}
+
+// SeatMapResponse lists a screen's seats by row. For a screening, each seat
+// also has a status: available or taken.
+type SeatMapResponse struct {
+	ScreenID    string            `json:"screenId"`
+	ScreeningID string            `json:"screeningId,omitempty"`
+	Rows        []SeatRowResponse `json:"rows"`
+	Available   int               `json:"available"`
+	Total       int               `json:"total"`
+}
+
+type SeatRowResponse struct {
+	Row   string         `json:"row"`
+	Seats []SeatResponse `json:"seats"`
+}
+
+type SeatResponse struct {
+	ID         string `json:"id"`
+	Number     int    `json:"number"`
+	Label      string `json:"label"`
+	Accessible bool   `json:"accessible,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+type HoldSeatsRequest struct {
+	SeatIDs []string `json:"seatIds" binding:"required,min=1,dive,uuid"`
+}
+
+type ConfirmBookingRequest struct {
+	PaymentToken string `json:"paymentToken" binding:"max=200"` // Not needed for free screenings
+}
+
+type VerifyTicketRequest struct {
+	Code string `json:"code" binding:"required,max=20"`
+}
+
+type GetBookingsRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type GetBookingsResponse struct {
+	Bookings   []BookingResponse `json:"bookings"`
+	PageNumber int               `json:"pageNumber"`
+	PageSize   int               `json:"pageSize"`
+	TotalSize  int64             `json:"totalSize"`
+}
+
+// BookingResponse carries the ticket once the booking is confirmed.
+type BookingResponse struct {
+	ID            string          `json:"id"`
+	ScreeningID   string          `json:"screeningId"`
+	Status        string          `json:"status"`
+	Seats         []string        `json:"seats"`
+	Amount        float64         `json:"amount"`
+	Currency      string          `json:"currency,omitempty"`
+	ExpiresAt     *time.Time      `json:"expiresAt,omitempty"` // End of the hold
+	CreatedAt     time.Time       `json:"createdAt"`
+	ConfirmedAt   *time.Time      `json:"confirmedAt,omitempty"`
+	CancelledAt   *time.Time      `json:"cancelledAt,omitempty"`
+	RefundedAt    *time.Time      `json:"refundedAt,omitempty"`
+	RefundPending bool            `json:"refundPending,omitempty"` // The payment of a cancelled booking is still to be paid back
+	Ticket        *TicketResponse `json:"ticket,omitempty"`
+}
+
+// TicketResponse is what is shown at the door. The code is checked with
+// POST /editor/tickets/verify.
+type TicketResponse struct {
+	Code          string     `json:"code"`
+	BookingID     string     `json:"bookingId"`
+	MovieID       string     `json:"movieId"`
+	MovieTitle    string     `json:"movieTitle"`
+	Cinema        string     `json:"cinema"`
+	CinemaAddress string     `json:"cinemaAddress,omitempty"`
+	Screen        string     `json:"screen"`
+	StartsAt      time.Time  `json:"startsAt"`
+	LocalDate     string     `json:"localDate"` // In the cinema's time zone
+	LocalTime     string     `json:"localTime"`
+	Format        string     `json:"format"`
+	Seats         []string   `json:"seats"`
+	CheckedInAt   *time.Time `json:"checkedInAt,omitempty"`
+}
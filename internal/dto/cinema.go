@@ -0,0 +1,121 @@
+package dto
+
+import "time"
+
+type CinemaRequest struct {
+	Name      string   `json:"name" binding:"required,max=100"`
+	City      string   `json:"city" binding:"required,max=100"`
+	Country   string   `json:"country" binding:"required,iso3166_1_alpha2"`
+	Address   string   `json:"address" binding:"max=200"`
+	TimeZone  string   `json:"timeZone" binding:"required,timezone"` // IANA name, e.g. Europe/Berlin
+	Latitude  *float64 `json:"latitude" binding:"omitempty,latitude"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,longitude"`
+	Website   string   `json:"website" binding:"omitempty,url"`
+}
+
+type GetCinemasRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+	City     string `form:"city"`
+	Country  string `form:"country" binding:"omitempty,iso3166_1_alpha2"`
+}
+
+type GetCinemasResponse struct {
+	Cinemas    []CinemaResponse `json:"cinemas"`
+	PageNumber int              `json:"pageNumber"`
+	PageSize   int              `json:"pageSize"`
+	TotalSize  int64            `json:"totalSize"`
+}
+
+type CinemaResponse struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	City      string           `json:"city"`
+	Country   string           `json:"country"`
+	Address   string           `json:"address,omitempty"`
+	TimeZone  string           `json:"timeZone"`
+	Latitude  *float64         `json:"latitude,omitempty"`
+	Longitude *float64         `json:"longitude,omitempty"`
+	Website   string           `json:"website,omitempty"`
+	Screens   []ScreenResponse `json:"screens,omitempty"`
+}
+
+type ScreenRequest struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+type ScreenResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateScreeningRequest takes startsAt either with an offset or as a wall
+// clock time in the cinema's time zone, e.g. 2026-10-20T19:30.
+type CreateScreeningRequest struct {
+	MovieID         string  `json:"movieId" binding:"required,uuid"`
+	ScreenID        string  `json:"screenId" binding:"required,uuid"`
+	StartsAt        string  `json:"startsAt" binding:"required"`
+	DurationMinutes int     `json:"durationMinutes" binding:"omitempty,min=1,max=1000"` // Defaults to the movie's runtime
+	Language        string  `json:"language" binding:"omitempty,len=2,alpha,lowercase"`
+	Subtitles       string  `json:"subtitles" binding:"omitempty,len=2,alpha,lowercase"`
+	Format          string  `json:"format" binding:"required,oneof=2d 3d imax imax_3d"`
+	Price           float64 `json:"price" binding:"min=0,max=99999"` // Per seat; free when 0
+	Currency        string  `json:"currency" binding:"omitempty,iso4217"`
+}
+
+type UpdateScreeningRequest struct {
+	StartsAt        string  `json:"startsAt" binding:"required"`
+	DurationMinutes int     `json:"durationMinutes" binding:"omitempty,min=1,max=1000"`
+	Language        string  `json:"language" binding:"omitempty,len=2,alpha,lowercase"`
+	Subtitles       string  `json:"subtitles" binding:"omitempty,len=2,alpha,lowercase"`
+	Format          string  `json:"format" binding:"required,oneof=2d 3d imax imax_3d"`
+	Price           float64 `json:"price" binding:"min=0,max=99999"` // Per seat; free when 0
+	Currency        string  `json:"currency" binding:"omitempty,iso4217"`
+}
+
+// GetShowtimesRequest takes a date in each cinema's time zone; without one,
+// screenings later today are listed.
+type GetShowtimesRequest struct {
+	City string `form:"city"`
+	Date string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type GetScheduleRequest struct {
+	Date    string   `form:"date" binding:"omitempty,datetime=2006-01-02"`
+	Lang    string   `form:"lang"`
+	Locales []string `form:"-"`
+}
+
+// ScreeningResponse gives times in the cinema's time zone.
+type ScreeningResponse struct {
+	ID        string         `json:"id"`
+	MovieID   string         `json:"movieId"`
+	Screen    ScreenResponse `json:"screen"`
+	StartsAt  time.Time      `json:"startsAt"`
+	EndsAt    time.Time      `json:"endsAt"`
+	LocalDate string         `json:"localDate"` // 2006-01-02
+	LocalTime string         `json:"localTime"` // 15:04
+	Language  string         `json:"language,omitempty"`
+	Subtitles string         `json:"subtitles,omitempty"`
+	Format    string         `json:"format"`
+	Price     float64        `json:"price"`
+	Currency  string         `json:"currency,omitempty"`
+}
+
+// CinemaShowtimesResponse lists a movie's screenings at one cinema.
+type CinemaShowtimesResponse struct {
+	Cinema     CinemaResponse      `json:"cinema"`
+	Screenings []ScreeningResponse `json:"screenings"`
+}
+
+// CinemaScheduleResponse lists a cinema's screenings of one day by movie.
+type CinemaScheduleResponse struct {
+	Cinema CinemaResponse          `json:"cinema"`
+	Date   string                  `json:"date"`
+	Movies []MovieScheduleResponse `json:"movies"`
+}
+
+type MovieScheduleResponse struct {
+	Movie      MovieResponse       `json:"movie"`
+	Screenings []ScreeningResponse `json:"screenings"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import "time"
+
+type CreateCollectionRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=private unlisted public"`
+}
+
+type UpdateCollectionRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"max=2000"`
+	Visibility  string `json:"visibility" binding:"required,oneof=private unlisted public"`
+}
+
+type GetCollectionsRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+	Name     string `form:"name"`
+}
+
+type GetCollectionsResponse struct {
+	Collections []CollectionResponse `json:"collections"`
+	PageNumber  int                  `json:"pageNumber"`
+	PageSize    int                  `json:"pageSize"`
+	TotalSize   int64                `json:"totalSize"`
+}
+
+type CollectionResponse struct {
+	ID          string    `json:"id"`
+	OwnerID     string    `json:"ownerId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Visibility  string    `json:"visibility"`
+	ShareURL    string    `json:"shareUrl,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type CollectionDetailsResponse struct {
+	CollectionResponse
+	CanEdit       bool                      `json:"canEdit"`
+	Entries       []CollectionEntryResponse `json:"entries"`
+	Collaborators []CollaboratorResponse    `json:"collaborators,omitempty"` // Only shown to the owner
+}
+
+type AddCollectionEntryRequest struct {
+	MovieID string `json:"movieId" binding:"required,uuid"`
+	Note    string `json:"note" binding:"max=1000"`
+}
+
+type UpdateCollectionEntryRequest struct {
+	Note     *string `json:"note" binding:"omitempty,max=1000"`
+	Position *int    `json:"position" binding:"omitempty,min=1"`
+}
+
+type CollectionEntryResponse struct {
+	Movie    MovieResponse `json:"movie"`
+	Position int           `json:"position"`
+	Note     string        `json:"note"`
+	AddedBy  string        `json:"addedBy"`
+	AddedAt  time.Time     `json:"addedAt"`
+}
+
+type InviteCollaboratorRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	CanEdit  bool   `json:"canEdit"`
+}
+
+type CollaboratorResponse struct {
+	UserID    string    `json:"userId"`
+	CanEdit   bool      `json:"canEdit"`
+	Status    string    `json:"status"`
+	InvitedAt time.Time `json:"invitedAt"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "time"
+
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required,max=2000"`
+	ParentID string `json:"parentId" binding:"omitempty,uuid"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+type HideCommentRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+type GetCommentsRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+	Sort     string `form:"sort,default=newest" binding:"oneof=newest oldest most_replies"`
+}
+
+type GetRepliesRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=20" binding:"min=1,max=100"`
+}
+
+type GetCommentsResponse struct {
+	Comments   []CommentResponse `json:"comments"`
+	PageNumber int               `json:"pageNumber"`
+	PageSize   int               `json:"pageSize"`
+	TotalSize  int64             `json:"totalSize"`
+}
+
+// CommentResponse is a comment as the viewer may see it. The body of hidden
+// comments is only shown to their author, the movie's owner and moderators;
+// deleted comments keep their place in the thread without author or body.
+type CommentResponse struct {
+	ID           string     `json:"id"`
+	MovieID      string     `json:"movieId"`
+	ParentID     *string    `json:"parentId"`
+	UserID       string     `json:"userId,omitempty"`
+	Body         string     `json:"body"`
+	Mentions     []string   `json:"mentions"`
+	ReplyCount   int        `json:"replyCount"`
+	Hidden       bool       `json:"hidden"`
+	HiddenReason string     `json:"hiddenReason,omitempty"`
+	Deleted      bool       `json:"deleted"`
+	EditedAt     *time.Time `json:"editedAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+// RecordProgressRequest reports how far the user got, as a percentage or as
+// a position in seconds, which needs the movie's runtime.
+type RecordProgressRequest struct {
+	Progress        *float64 `json:"progress" binding:"omitempty,min=0,max=100"`
+	PositionSeconds *int     `json:"positionSeconds" binding:"omitempty,min=0"`
+}
+
+type GetHistoryRequest struct {
+	Page      int   `form:"page,default=1" binding:"min=1"`
+	PageSize  int   `form:"page_size,default=10" binding:"min=1,max=100"`
+	Completed *bool `form:"completed"`
+}
+
+type GetContinueWatchingRequest struct {
+	Limit int `form:"limit,default=10" binding:"min=1,max=50"`
+}
+
+type GetHistoryResponse struct {
+	Entries    []HistoryEntryResponse `json:"entries"`
+	PageNumber int                    `json:"pageNumber"`
+	PageSize   int                    `json:"pageSize"`
+	TotalSize  int64                  `json:"totalSize"`
+}
+
+type HistoryEntryResponse struct {
+	ID              string         `json:"id"`
+	Movie           *MovieResponse `json:"movie,omitempty"`
+	MovieID         string         `json:"movieId"`
+	Progress        float64        `json:"progress"` // Percent
+	PositionSeconds int            `json:"positionSeconds,omitempty"`
+	Completed       bool           `json:"completed"`
+	TimesCompleted  int            `json:"timesCompleted"`
+	CompletedAt     *time.Time     `json:"completedAt,omitempty"`
+	StartedAt       time.Time      `json:"startedAt"`
+	LastWatchedAt   time.Time      `json:"lastWatchedAt"`
+}
+
+// RecordProgressResponse tells whether the progress was kept; it is not
+// while history is paused.
+type RecordProgressResponse struct {
+	Recorded bool                  `json:"recorded"`
+	Entry    *HistoryEntryResponse `json:"entry,omitempty"`
+}
+
+type ClearHistoryResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+type HistorySettingsRequest struct {
+	Paused        bool `json:"paused"`
+	RetentionDays int  `json:"retentionDays" binding:"min=0,max=3650"` // 0 keeps history forever
+}
+
+type HistorySettingsResponse struct {
+	Paused        bool       `json:"paused"`
+	RetentionDays int        `json:"retentionDays"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty"` // Unset until first changed
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type ImageGCReport struct {
+	DryRun      bool            `json:"dryRun"`
+	GracePeriod string          `json:"gracePeriod"`
+	Scanned     int             `json:"scanned"`
+	Referenced  int             `json:"referenced"`
+	TooRecent   int             `json:"tooRecent"` // Unreferenced but within the grace period
+	Orphans     []OrphanedImage `json:"orphans"`
+	OrphanBytes int64           `json:"orphanBytes"`
+	Deleted     int             `json:"deleted"`
+	Failed      []string        `json:"failed,omitempty"`
+}
+
+type OrphanedImage struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type UploadMediaRequest struct {
+	Kind      string `form:"kind" binding:"required,oneof=backdrop still poster"`
+	Caption   string `form:"caption" binding:"max=300"`
+	Language  string `form:"language" binding:"omitempty,len=2,alpha,lowercase"`
+	IsPrimary bool   `form:"isPrimary"`
+	// Image will be handled as a file upload
+}
+
+type UpdateMediaRequest struct {
+	Caption   *string `json:"caption" binding:"omitempty,max=300"`
+	Language  *string `json:"language" binding:"omitempty,max=2"`
+	IsPrimary *bool   `json:"isPrimary"`
+	Position  *int    `json:"position" binding:"omitempty,min=1"`
+}
+
+type GetMediaRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Kind     string `form:"kind" binding:"omitempty,oneof=backdrop still poster"`
+	Language string `form:"language" binding:"omitempty,len=2,alpha"`
+}
+
+type GetMediaResponse struct {
+	Media      []MediaResponse `json:"media"`
+	PageNumber int             `json:"pageNumber"`
+	PageSize   int             `json:"pageSize"`
+	TotalSize  int64           `json:"totalSize"`
+}
+
+type MediaResponse struct {
+	ID        string                   `json:"id"`
+	Kind      string                   `json:"kind"`
+	Caption   string                   `json:"caption,omitempty"`
+	Language  string                   `json:"language,omitempty"`
+	IsPrimary bool                     `json:"isPrimary"`
+	Position  int                      `json:"position"`
+	URL       string                   `json:"url"`
+	Width     int                      `json:"width"`
+	Height    int                      `json:"height"`
+	Images    map[string]ImageResponse `json:"images"` // Keyed by size: thumbnail, medium, full
+	CreatedAt time.Time                `json:"createdAt"`
+}
@@ -1,47 +1,115 @@
 package dto
 
+import "time"
+
 type CreateMovieRequest struct {
-	Title       string   `form:"title" binding:"required,min=1,max=39"`
-	Description string   `form:"description" binding:"required,min=10,max=999"`
-	Genres      []string `form:"genres" binding:"required,dive,required"`
-	Actors      []string `form:"actors" binding:"required,dive,required"`
-	TrailerUrl  string   `form:"trailerUrl" binding:"required,url"`
+	Title            string            `form:"title" binding:"required,min=1,max=39"`
+	Description      string            `form:"description" binding:"required,min=10,max=999"`
+	Tagline          string            `form:"tagline" binding:"max=200"`
+	Genres           []string          `form:"genres" binding:"required,dive,required"`
+	Actors           []string          `form:"actors" binding:"required,dive,required"`
+	TrailerUrl       string            `form:"trailerUrl" binding:"required,url"`
+	ReleaseDate      string            `form:"releaseDate" binding:"omitempty,datetime=2006-01-02"`
+	RuntimeMinutes   int               `form:"runtimeMinutes" binding:"min=0,max=1000"`
+	OriginalLanguage string            `form:"originalLanguage" binding:"omitempty,len=2,alpha,lowercase"`
+	Countries        []string          `form:"countries" binding:"omitempty,dive,iso3166_1_alpha2"`
+	Certifications   map[string]string `form:"-" binding:"omitempty,dive,keys,iso3166_1_alpha2,endkeys,required,max=10"`
+	ImdbID           string            `form:"imdbId" binding:"omitempty,startswith=tt,min=9,max=10"`
+	TmdbID           string            `form:"tmdbId" binding:"omitempty,numeric,max=10"`
+	Async            bool              `form:"async"`          // Process the poster in the background
+	AllowDuplicate   bool              `form:"allowDuplicate"` // Create the movie even if it looks like an existing one
 	// Poster will be handled as a file upload
 }
 
-type CreateMovieResponse struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Genres      []string `json:"genres"`
-	Actors      []string `json:"actors"`
-	TrailerUrl  string   `json:"trailerUrl"`
-	Poster      string   `json:"poster"`
-}
+type CreateMovieResponse = MovieResponse
 
 type UpdateMovieRequest struct {
-	Title       string   `json:"title" binding:"required,min=1,max=39"`
-	Description string   `json:"description" binding:"required,min=10,max=999"`
-	Genres      []string `json:"genres" binding:"required,dive,required"`
-	Actors      []string `json:"actors" binding:"required,dive,required"`
-	TrailerUrl  string   `json:"trailerUrl" binding:"required,url"`
-	Poster      string   `json:"poster" binding:"required,url"`
+	Title            string            `json:"title" binding:"required,min=1,max=39"`
+	Description      string            `json:"description" binding:"required,min=10,max=999"`
+	Tagline          string            `json:"tagline" binding:"max=200"`
+	Genres           []string          `json:"genres" binding:"required,dive,required"`
+	Actors           []string          `json:"actors" binding:"required,dive,required"`
+	TrailerUrl       string            `json:"trailerUrl" binding:"required,url"`
+	Poster           string            `json:"poster" binding:"required,url"`
+	ReleaseDate      string            `json:"releaseDate" binding:"omitempty,datetime=2006-01-02"`
+	RuntimeMinutes   int               `json:"runtimeMinutes" binding:"min=0,max=1000"`
+	OriginalLanguage string            `json:"originalLanguage" binding:"omitempty,len=2,alpha,lowercase"`
+	Countries        []string          `json:"countries" binding:"omitempty,dive,iso3166_1_alpha2"`
+	Certifications   map[string]string `json:"certifications" binding:"omitempty,dive,keys,iso3166_1_alpha2,endkeys,required,max=10"`
+	ImdbID           string            `json:"imdbId" binding:"omitempty,startswith=tt,min=9,max=10"`
+	TmdbID           string            `json:"tmdbId" binding:"omitempty,numeric,max=10"`
+}
+
+type UpdateMovieResponse = MovieResponse
+
+// ImportMovieRequest describes one movie of a bulk import. The poster is
+// kept as an external URL.
+type ImportMovieRequest = UpdateMovieRequest
+
+type ImportMoviesRequest struct {
+	Movies          []ImportMovieRequest `json:"movies" binding:"required,min=1,max=100,dive"`
+	AllowDuplicates bool                 `json:"allowDuplicates"` // Import movies even if they look like existing ones
+}
+
+type ImportMoviesResponse struct {
+	Movies []MovieResponse `json:"movies"`
 }
 
-type UpdateMovieResponse struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Genres      []string `json:"genres"`
-	Actors      []string `json:"actors"`
-	TrailerUrl  string   `json:"trailerUrl"`
-	Poster      string   `json:"poster"`
+// DuplicateCandidate is an existing movie a new one may duplicate.
+type DuplicateCandidate struct {
+	Movie      MovieResponse `json:"movie"`
+	Reasons    []string      `json:"reasons"`    // external_id, title_year or similar_title
+	Similarity float64       `json:"similarity"` // Title similarity from 0 to 1
+}
+
+// ImportConflict reports a movie of an import that may already exist, either
+// in the database or earlier in the same import.
+type ImportConflict struct {
+	Index            int                  `json:"index"`
+	Title            string               `json:"title"`
+	DuplicateOfIndex *int                 `json:"duplicateOfIndex,omitempty"`
+	Candidates       []DuplicateCandidate `json:"candidates,omitempty"`
+}
+
+type MergeMoviesRequest struct {
+	DuplicateID string `json:"duplicateId" binding:"required,uuid"`
+}
+
+type MergeMoviesResponse struct {
+	Movie                  MovieResponse `json:"movie"`
+	MovedReviews           int64         `json:"movedReviews"`
+	MovedListEntries       int64         `json:"movedListEntries"`
+	MovedCollectionEntries int64         `json:"movedCollectionEntries"`
+	MovedMedia             int64         `json:"movedMedia"`
+	MovedCredits           int64         `json:"movedCredits"`
+	MovedComments          int64         `json:"movedComments"`
+	MovedOffers            int64         `json:"movedOffers"`
+	MovedScreenings        int64         `json:"movedScreenings"`
+	MovedHistoryEntries    int64         `json:"movedHistoryEntries"`
+	MovedReports           int64         `json:"movedReports"`
 }
 
 type GetMoviesRequest struct {
-	Page     int    `form:"page,default=1" binding:"min=1"`
-	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
-	Title    string `form:"title"`
+	Page            int      `form:"page,default=1" binding:"min=1"`
+	PageSize        int      `form:"page_size,default=10" binding:"min=1,max=100"`
+	Title           string   `form:"title"`
+	YearFrom        int      `form:"year_from" binding:"omitempty,min=1850,max=3000"`
+	YearTo          int      `form:"year_to" binding:"omitempty,min=1850,max=3000,gtefield=YearFrom"`
+	RuntimeMin      int      `form:"runtime_min" binding:"omitempty,min=0"`
+	RuntimeMax      int      `form:"runtime_max" binding:"omitempty,min=0"`
+	Language        string   `form:"language" binding:"omitempty,len=2,alpha"`
+	Country         string   `form:"country" binding:"omitempty,iso3166_1_alpha2"`
+	Certification   string   `form:"certification" binding:"omitempty,max=10"`
+	CertRegion      string   `form:"certification_region" binding:"omitempty,iso3166_1_alpha2"`
+	MinRating       float64  `form:"min_rating" binding:"omitempty,min=1,max=10"`
+	MinRatingCount  int      `form:"min_rating_count" binding:"omitempty,min=1"`
+	Provider        string   `form:"provider"` // Comma-separated provider slugs
+	ProviderCountry string   `form:"provider_country" binding:"omitempty,iso3166_1_alpha2"`
+	OfferType       string   `form:"offer_type" binding:"omitempty,oneof=stream rent buy"`
+	Sort            string   `form:"sort" binding:"omitempty,oneof=title rating_desc rating_asc release_desc release_asc"`
+	Status          string   `form:"status" binding:"omitempty,oneof=draft in_review published archived"` // Editors see all movies in the status, others only their own
+	Lang            string   `form:"lang"`
+	Locales         []string `form:"-"` // Preferred locales from lang or Accept-Language
 }
 
 type GetMoviesResponse struct {
@@ -52,22 +120,108 @@ type GetMoviesResponse struct {
 }
 
 type MovieResponse struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Genres      []string `json:"genres"`
-	Actors      []string `json:"actors"`
-	TrailerUrl  string   `json:"trailerUrl"`
-	Poster      string   `json:"poster"`
+	ID               string                   `json:"id"`
+	Slug             string                   `json:"slug,omitempty"`
+	Title            string                   `json:"title"`
+	Description      string                   `json:"description"`
+	Tagline          string                   `json:"tagline,omitempty"`
+	Locale           string                   `json:"locale,omitempty"` // Locale of title, description and tagline
+	Genres           []string                 `json:"genres"`
+	Actors           []string                 `json:"actors"`
+	TrailerUrl       string                   `json:"trailerUrl"`
+	Trailer          *TrailerResponse         `json:"trailer,omitempty"`
+	Poster           string                   `json:"poster"`
+	Posters          map[string]ImageResponse `json:"posters,omitempty"` // Keyed by size: thumbnail, card, full
+	PosterStatus     string                   `json:"posterStatus"`
+	PosterError      string                   `json:"posterError,omitempty"`
+	ReleaseDate      string                   `json:"releaseDate,omitempty"`
+	RuntimeMinutes   int                      `json:"runtimeMinutes,omitempty"`
+	OriginalLanguage string                   `json:"originalLanguage,omitempty"`
+	Countries        []string                 `json:"countries,omitempty"`
+	Certifications   map[string]string        `json:"certifications,omitempty"`
+	ImdbID           string                   `json:"imdbId,omitempty"`
+	TmdbID           string                   `json:"tmdbId,omitempty"`
+	Status           string                   `json:"status"`
+	PublishAt        *time.Time               `json:"publishAt,omitempty"`
+	AverageRating    float64                  `json:"averageRating"`
+	RatingCount      int                      `json:"ratingCount"`
+	InWatchlist      *bool                    `json:"inWatchlist,omitempty"` // Only set for authenticated requests
+	IsFavorite       *bool                    `json:"isFavorite,omitempty"`
+}
+
+type ImageResponse struct {
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	JPEG   string `json:"jpeg,omitempty"`
+	WebP   string `json:"webp,omitempty"`
 }
 
 type MovieDetailsResponse struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Genres      []string `json:"genres"`
-	Actors      []string `json:"actors"`
-	TrailerUrl  string   `json:"trailerUrl"`
-	Poster      string   `json:"poster"`
-	UserID      string   `json:"userId"` // Include user ID in details
+	MovieResponse
+	UserID  string           `json:"userId"` // Include user ID in details
+	Credits []CreditResponse `json:"credits"`
+	// The original language and every translated locale
+	AvailableLocales []string `json:"availableLocales"`
+	// Set when the movie was requested by an earlier slug; clients should
+	// treat it as a permanent redirect
+	RedirectTo string `json:"redirectTo,omitempty"`
+}
+
+type TrailerResponse struct {
+	Provider     string `json:"provider"` // youtube or vimeo
+	VideoID      string `json:"videoId"`
+	URL          string `json:"url"`
+	EmbedURL     string `json:"embedUrl"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"`
+}
+
+type PosterStatusResponse struct {
+	Status   string                   `json:"status"` // ready, pending, processing or failed
+	Error    string                   `json:"error,omitempty"`
+	Attempts int                      `json:"attempts,omitempty"`
+	Poster   string                   `json:"poster"`
+	Posters  map[string]ImageResponse `json:"posters,omitempty"`
+}
+
+type PosterUploadURLRequest struct {
+	ContentType string `json:"contentType" binding:"required,oneof=image/jpeg image/png image/webp"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+}
+
+type PosterUploadURLResponse struct {
+	UploadKey string            `json:"uploadKey"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Fields    map[string]string `json:"fields"`    // Form fields to send before the file
+	FileField string            `json:"fileField"` // Form field that carries the file
+	ExpiresAt time.Time         `json:"expiresAt"`
+	MaxBytes  int64             `json:"maxBytes"`
+}
+
+type ConfirmPosterUploadRequest struct {
+	UploadKey string `json:"uploadKey" binding:"required"`
+	Async     bool   `json:"async"`
+}
+
+type GetRankedMoviesRequest struct {
+	Page     int      `form:"page,default=1" binding:"min=1"`
+	PageSize int      `form:"page_size,default=10" binding:"min=1,max=100"`
+	Lang     string   `form:"lang"`
+	Locales  []string `form:"-"` // Preferred locales from lang or Accept-Language
+}
+
+type GetRankedMoviesResponse struct {
+	Movies     []RankedMovieResponse `json:"movies"`
+	PageNumber int                   `json:"pageNumber"`
+	PageSize   int                   `json:"pageSize"`
+	TotalSize  int64                 `json:"totalSize"`
+}
+
+// RankedMovieResponse is a movie with the precomputed scores it is ranked by.
+type RankedMovieResponse struct {
+	MovieResponse
+	Rank          int       `json:"rank"`
+	TrendingScore float64   `json:"trendingScore"`
+	TotalViews    int64     `json:"totalViews"`
+	ComputedAt    time.Time `json:"computedAt"`
 }
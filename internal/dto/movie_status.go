@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+type ChangeMovieStatusRequest struct {
+	Status    string     `json:"status" binding:"required,oneof=draft in_review published archived"`
+	Comment   string     `json:"comment" binding:"max=1000"`
+	PublishAt *time.Time `json:"publishAt"` // Schedules publishing; only with status published
+}
+
+type ReviewDecisionRequest struct {
+	Comment   string     `json:"comment" binding:"max=1000"`
+	PublishAt *time.Time `json:"publishAt"` // Approvals only
+}
+
+type GetReviewQueueRequest struct {
+	Page     int `form:"page,default=1" binding:"min=1"`
+	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type MovieStatusResponse struct {
+	Status    string                 `json:"status"`
+	PublishAt *time.Time             `json:"publishAt,omitempty"`
+	History   []StatusChangeResponse `json:"history"` // Newest first
+}
+
+type StatusChangeResponse struct {
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	Comment    string    `json:"comment,omitempty"`
+	ChangedBy  string    `json:"changedBy"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
@@ -0,0 +1,66 @@
+package dto
+
+type CreatePersonRequest struct {
+	Name      string `json:"name" binding:"required,min=1,max=100"`
+	Bio       string `json:"bio" binding:"max=2000"`
+	BirthDate string `json:"birthDate" binding:"omitempty,datetime=2006-01-02"`
+	Photo     string `json:"photo" binding:"omitempty,url"`
+}
+
+type UpdatePersonRequest struct {
+	Name      string `json:"name" binding:"required,min=1,max=100"`
+	Bio       string `json:"bio" binding:"max=2000"`
+	BirthDate string `json:"birthDate" binding:"omitempty,datetime=2006-01-02"`
+	Photo     string `json:"photo" binding:"omitempty,url"`
+}
+
+type GetPeopleRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+	Name     string `form:"name"`
+}
+
+type GetPeopleResponse struct {
+	People     []PersonResponse `json:"people"`
+	PageNumber int              `json:"pageNumber"`
+	PageSize   int              `json:"pageSize"`
+	TotalSize  int64            `json:"totalSize"`
+}
+
+type PersonResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Bio       string `json:"bio"`
+	BirthDate string `json:"birthDate,omitempty"`
+	Photo     string `json:"photo"`
+}
+
+type PersonDetailsResponse struct {
+	PersonResponse
+	Filmography []FilmographyEntry `json:"filmography"`
+}
+
+type FilmographyEntry struct {
+	MovieID   string `json:"movieId"`
+	Title     string `json:"title"`
+	Poster    string `json:"poster"`
+	Role      string `json:"role"`
+	Character string `json:"character,omitempty"`
+}
+
+type AddCreditRequest struct {
+	PersonID  string `json:"personId" binding:"required,uuid"`
+	Role      string `json:"role" binding:"required,oneof=actor director writer"`
+	Character string `json:"character" binding:"max=100"`
+	Order     int    `json:"order" binding:"min=0"`
+}
+
+type CreditResponse struct {
+	ID        string `json:"id"`
+	PersonID  string `json:"personId"`
+	Name      string `json:"name"`
+	Photo     string `json:"photo"`
+	Role      string `json:"role"`
+	Character string `json:"character,omitempty"`
+	Order     int    `json:"order"`
+}
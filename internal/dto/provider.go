@@ -0,0 +1,91 @@
+package dto
+
+import "time"
+
+type ProviderRequest struct {
+	Slug        string `json:"slug" binding:"required,max=50"` // Lowercase letters, digits and dashes
+	Name        string `json:"name" binding:"required,max=100"`
+	LogoURL     string `json:"logoUrl" binding:"omitempty,url"`
+	HomepageURL string `json:"homepageUrl" binding:"omitempty,url"`
+}
+
+type ProviderResponse struct {
+	ID          string `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	LogoURL     string `json:"logoUrl,omitempty"`
+	HomepageURL string `json:"homepageUrl,omitempty"`
+}
+
+type CreateAvailabilityRequest struct {
+	ProviderID     string     `json:"providerId" binding:"required,uuid"`
+	Country        string     `json:"country" binding:"required,iso3166_1_alpha2"`
+	Type           string     `json:"type" binding:"required,oneof=stream rent buy"`
+	Quality        string     `json:"quality" binding:"required,oneof=sd hd uhd"`
+	Price          *float64   `json:"price" binding:"omitempty,min=0,max=99999999"`
+	Currency       string     `json:"currency" binding:"required_with=Price,omitempty,iso4217"`
+	Link           string     `json:"link" binding:"required,url"`
+	AvailableUntil *time.Time `json:"availableUntil"`
+}
+
+type UpdateAvailabilityRequest struct {
+	Price          *float64   `json:"price" binding:"omitempty,min=0,max=99999999"`
+	Currency       string     `json:"currency" binding:"required_with=Price,omitempty,iso4217"`
+	Link           string     `json:"link" binding:"required,url"`
+	AvailableUntil *time.Time `json:"availableUntil"`
+}
+
+type GetAvailabilityRequest struct {
+	Country string `form:"country" binding:"omitempty,iso3166_1_alpha2"`
+	Type    string `form:"type" binding:"omitempty,oneof=stream rent buy"`
+}
+
+type AvailabilityResponse struct {
+	ID             string           `json:"id"`
+	Provider       ProviderResponse `json:"provider"`
+	Country        string           `json:"country"`
+	Type           string           `json:"type"`
+	Quality        string           `json:"quality"`
+	Price          *float64         `json:"price,omitempty"` // Empty when included in a subscription
+	Currency       string           `json:"currency,omitempty"`
+	Link           string           `json:"link"`
+	AvailableUntil *time.Time       `json:"availableUntil,omitempty"`
+	Source         string           `json:"source"` // manual or feed
+	UpdatedAt      time.Time        `json:"updatedAt"`
+}
+
+// ProviderFeed is the JSON document imported to sync where movies can be
+// watched. It is authoritative for the providers it lists: their offers from
+// earlier feeds are replaced by the feed's offers.
+type ProviderFeed struct {
+	Providers []ProviderRequest `json:"providers"`
+	Offers    []FeedOffer       `json:"offers"`
+}
+
+// FeedOffer names its movie by movieId, imdbId or tmdbId and its provider by
+// slug.
+type FeedOffer struct {
+	MovieID        string     `json:"movieId"`
+	ImdbID         string     `json:"imdbId"`
+	TmdbID         string     `json:"tmdbId"`
+	Provider       string     `json:"provider"`
+	Country        string     `json:"country"`
+	Type           string     `json:"type"`
+	Quality        string     `json:"quality"`
+	Price          *float64   `json:"price"`
+	Currency       string     `json:"currency"`
+	Link           string     `json:"link"`
+	AvailableUntil *time.Time `json:"availableUntil"`
+}
+
+type ProviderFeedReport struct {
+	Providers       int              `json:"providers"`
+	Offers          int64            `json:"offers"`          // Offers saved
+	UnmatchedMovies int              `json:"unmatchedMovies"` // Offers for movies not in the catalog
+	Invalid         []FeedOfferIssue `json:"invalid,omitempty"`
+}
+
+type FeedOfferIssue struct {
+	Index int    `json:"index"` // Position in offers
+	Error string `json:"error"`
+}
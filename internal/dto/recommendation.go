@@ -0,0 +1,38 @@
+package dto
+
+type GetRecommendationsRequest struct {
+	Page     int      `form:"page,default=1" binding:"min=1"`
+	PageSize int      `form:"page_size,default=10" binding:"min=1,max=50"`
+	Lang     string   `form:"lang"`
+	Locales  []string `form:"-"` // Preferred locales from lang or Accept-Language
+}
+
+type GetSimilarMoviesRequest struct {
+	Limit   int      `form:"limit,default=10" binding:"min=1,max=20"`
+	Lang    string   `form:"lang"`
+	Locales []string `form:"-"`
+}
+
+type GetRecommendationsResponse struct {
+	Movies     []RecommendedMovieResponse `json:"movies"`
+	PageNumber int                        `json:"pageNumber"`
+	PageSize   int                        `json:"pageSize"`
+	TotalSize  int64                      `json:"totalSize"`
+}
+
+// RecommendedMovieResponse is a suggested movie with why it was suggested.
+type RecommendedMovieResponse struct {
+	MovieResponse
+	Score        float64           `json:"score"`
+	Reasons      []string          `json:"reasons"` // shared_genres, shared_people, similar_story, rated_alike or top_rated
+	SharedGenres []string          `json:"sharedGenres"`
+	SharedPeople []string          `json:"sharedPeople"`
+	BecauseOf    *MovieRefResponse `json:"becauseOf,omitempty"` // The movie of yours it resembles most
+	Explanation  string            `json:"explanation"`
+}
+
+type MovieRefResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+type CreateReportRequest struct {
+	TargetType string `json:"targetType" binding:"required,oneof=movie review comment"`
+	TargetID   string `json:"targetId" binding:"required,uuid"`
+	Reason     string `json:"reason" binding:"required,oneof=spam abuse harassment hate_speech sexual_content violence misinformation copyright other"`
+	Details    string `json:"details" binding:"max=1000"`
+}
+
+type GetModerationQueueRequest struct {
+	Page       int    `form:"page,default=1" binding:"min=1"`
+	PageSize   int    `form:"page_size,default=20" binding:"min=1,max=100"`
+	Status     string `form:"status,default=open" binding:"oneof=open resolved dismissed taken_down"`
+	TargetType string `form:"target_type" binding:"omitempty,oneof=movie review comment"`
+}
+
+type ModerationActionRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+type GetReportsResponse struct {
+	Reports    []ReportResponse `json:"reports"`
+	PageNumber int              `json:"pageNumber"`
+	PageSize   int              `json:"pageSize"`
+	TotalSize  int64            `json:"totalSize"`
+}
+
+type ReportResponse struct {
+	ID             string           `json:"id"`
+	TargetType     string           `json:"targetType"`
+	TargetID       string           `json:"targetId"`
+	MovieID        string           `json:"movieId"`
+	ReporterID     *string          `json:"reporterId"` // Empty for reports filed by the word filter
+	Reason         string           `json:"reason"`
+	Details        string           `json:"details"`
+	Status         string           `json:"status"`
+	ResolvedBy     *string          `json:"resolvedBy,omitempty"`
+	ResolutionNote string           `json:"resolutionNote,omitempty"`
+	ResolvedAt     *time.Time       `json:"resolvedAt,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	Target         *ReportedContent `json:"target,omitempty"`
+	OpenReports    int64            `json:"openReports,omitempty"` // Open reports about the same content
+}
+
+// ReportedContent shows moderators what was reported. Gone is set when the
+// content has been deleted since.
+type ReportedContent struct {
+	AuthorID string `json:"authorId,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Status   string `json:"status,omitempty"` // Movie status, or "hidden" for comments
+	Gone     bool   `json:"gone"`
+}
+
+type ModerationActionResponse struct {
+	Status        string `json:"status"`
+	ClosedReports int64  `json:"closedReports"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type CreateReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=10"`
+	Review string `json:"review" binding:"max=5000"`
+}
+
+type UpdateReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=10"`
+	Review string `json:"review" binding:"max=5000"`
+}
+
+type GetReviewsRequest struct {
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+	Sort     string `form:"sort,default=newest" binding:"oneof=newest oldest rating_desc rating_asc"`
+}
+
+type GetReviewsResponse struct {
+	Reviews    []ReviewResponse `json:"reviews"`
+	PageNumber int              `json:"pageNumber"`
+	PageSize   int              `json:"pageSize"`
+	TotalSize  int64            `json:"totalSize"`
+}
+
+type ReviewResponse struct {
+	ID        string    `json:"id"`
+	MovieID   string    `json:"movieId"`
+	UserID    string    `json:"userId"`
+	Rating    int       `json:"rating"`
+	Review    string    `json:"review"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type SaveMovieRequest struct {
+	MovieID string `json:"movieId" binding:"required,uuid"`
+}
+
+type UpdateWatchlistItemRequest struct {
+	Position *int  `json:"position" binding:"omitempty,min=1"`
+	Watched  *bool `json:"watched"`
+}
+
+type UpdateFavoriteRequest struct {
+	Position int `json:"position" binding:"required,min=1"`
+}
+
+type GetSavedMoviesRequest struct {
+	Page     int   `form:"page,default=1" binding:"min=1"`
+	PageSize int   `form:"page_size,default=10" binding:"min=1,max=100"`
+	Watched  *bool `form:"watched"`
+}
+
+type GetSavedMoviesResponse struct {
+	Items      []SavedMovieResponse `json:"items"`
+	PageNumber int                  `json:"pageNumber"`
+	PageSize   int                  `json:"pageSize"`
+	TotalSize  int64                `json:"totalSize"`
+}
+
+type SavedMovieResponse struct {
+	Movie     MovieResponse `json:"movie"`
+	Position  int           `json:"position"`
+	Watched   *bool         `json:"watched,omitempty"`
+	WatchedAt *time.Time    `json:"watchedAt,omitempty"`
+	Progress  *float64      `json:"progress,omitempty"` // Percent seen of an unwatched watchlist movie, from the viewing history
+	AddedAt   time.Time     `json:"addedAt"`
+}
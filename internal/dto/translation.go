@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type PutTranslationRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=100"`
+	Description string `json:"description" binding:"omitempty,min=10,max=999"`
+	Tagline     string `json:"tagline" binding:"max=200"`
+}
+
+type TranslationResponse struct {
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Tagline     string    `json:"tagline,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/payment"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BookingHandler struct {
+	BookingUsecase *usecase.BookingUsecase
+}
+
+func NewBookingHandler(bookingUsecase *usecase.BookingUsecase) *BookingHandler {
+	return &BookingHandler{BookingUsecase: bookingUsecase}
+}
+
+func (h *BookingHandler) ReplaceSeatMap(c *gin.Context) {
+	var req dto.SeatMapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	seatMap, err := h.BookingUsecase.ReplaceSeatMap(c.Param("id"), &req)
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Failed to save seat map", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Seat map saved successfully", seatMap))
+}
+
+func (h *BookingHandler) GetSeatMap(c *gin.Context) {
+	seatMap, err := h.BookingUsecase.GetSeatMap(c.Param("id"), c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Failed to fetch seats", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Seats fetched successfully", seatMap))
+}
+
+func (h *BookingHandler) HoldSeats(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.HoldSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	booking, err := h.BookingUsecase.HoldSeats(c.Param("id"), &req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Failed to hold seats", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Seats held successfully", booking))
+}
+
+func (h *BookingHandler) GetMyBookings(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.GetBookingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	bookings, err := h.BookingUsecase.GetMyBookings(&req, userID.(string))
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Failed to fetch bookings", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Bookings fetched successfully",
+		bookings.Bookings,
+		bookings.PageNumber,
+		bookings.PageSize,
+		int(bookings.TotalSize),
+	))
+}
+
+func (h *BookingHandler) GetBooking(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	booking, err := h.BookingUsecase.GetBooking(c.Param("id"), userID.(string))
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Failed to fetch booking", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Booking fetched successfully", booking))
+}
+
+func (h *BookingHandler) ConfirmBooking(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.ConfirmBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	booking, err := h.BookingUsecase.ConfirmBooking(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Failed to confirm booking", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Booking confirmed successfully", booking))
+}
+
+func (h *BookingHandler) CancelBooking(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	booking, err := h.BookingUsecase.CancelBooking(c.Param("id"), userID.(string))
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Failed to cancel booking", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Booking cancelled successfully", booking))
+}
+
+func (h *BookingHandler) VerifyTicket(c *gin.Context) {
+	var req dto.VerifyTicketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	ticket, err := h.BookingUsecase.VerifyTicket(&req)
+	if err != nil {
+		c.JSON(bookingErrorStatus(err), response.NewErrorResponse("Ticket rejected", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Ticket checked in successfully", ticket))
+}
+
+func bookingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, payment.ErrDeclined):
+		return http.StatusPaymentRequired
+	case strings.HasPrefix(err.Error(), "payment failed"):
+		return http.StatusBadGateway
+	case strings.HasSuffix(err.Error(), "not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	case strings.HasPrefix(err.Error(), "the screen"), strings.HasPrefix(err.Error(), "the booking"),
+		strings.HasPrefix(err.Error(), "the hold"), strings.HasPrefix(err.Error(), "the ticket"),
+		strings.HasPrefix(err.Error(), "some of the seats"), strings.HasPrefix(err.Error(), "you already hold"),
+		strings.HasPrefix(err.Error(), "bookings can only be cancelled"):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
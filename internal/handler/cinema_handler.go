@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/i18n"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CinemaHandler struct {
+	CinemaUsecase *usecase.CinemaUsecase
+}
+
+func NewCinemaHandler(cinemaUsecase *usecase.CinemaUsecase) *CinemaHandler {
+	return &CinemaHandler{CinemaUsecase: cinemaUsecase}
+}
+
+func (h *CinemaHandler) GetCinemas(c *gin.Context) {
+	var req dto.GetCinemasRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	cinemas, err := h.CinemaUsecase.GetCinemas(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch cinemas", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Cinemas fetched successfully",
+		cinemas.Cinemas,
+		cinemas.PageNumber,
+		cinemas.PageSize,
+		int(cinemas.TotalSize),
+	))
+}
+
+func (h *CinemaHandler) GetCinema(c *gin.Context) {
+	cinema, err := h.CinemaUsecase.GetCinema(c.Param("id"))
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to fetch cinema", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Cinema fetched successfully", cinema))
+}
+
+func (h *CinemaHandler) GetSchedule(c *gin.Context) {
+	var req dto.GetScheduleRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	req.Locales = i18n.Preferences(req.Lang, c.GetHeader("Accept-Language"))
+	schedule, err := h.CinemaUsecase.GetSchedule(c.Param("id"), &req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to fetch schedule", []string{err.Error()}))
+		return
+	}
+
+	c.Header("Vary", "Accept-Language")
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Schedule fetched successfully", schedule))
+}
+
+func (h *CinemaHandler) GetShowtimes(c *gin.Context) {
+	var req dto.GetShowtimesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	showtimes, err := h.CinemaUsecase.GetShowtimes(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to fetch showtimes", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Showtimes fetched successfully", showtimes))
+}
+
+func (h *CinemaHandler) CreateCinema(c *gin.Context) {
+	var req dto.CinemaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	cinema, err := h.CinemaUsecase.CreateCinema(&req)
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to create cinema", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Cinema created successfully", cinema))
+}
+
+func (h *CinemaHandler) UpdateCinema(c *gin.Context) {
+	var req dto.CinemaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	cinema, err := h.CinemaUsecase.UpdateCinema(c.Param("id"), &req)
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to update cinema", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Cinema updated successfully", cinema))
+}
+
+func (h *CinemaHandler) DeleteCinema(c *gin.Context) {
+	if err := h.CinemaUsecase.DeleteCinema(c.Param("id")); err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to delete cinema", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Cinema deleted successfully", nil))
+}
+
+func (h *CinemaHandler) CreateScreen(c *gin.Context) {
+	var req dto.ScreenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	screen, err := h.CinemaUsecase.CreateScreen(c.Param("id"), &req)
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to create screen", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Screen created successfully", screen))
+}
+
+func (h *CinemaHandler) UpdateScreen(c *gin.Context) {
+	var req dto.ScreenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	screen, err := h.CinemaUsecase.UpdateScreen(c.Param("id"), &req)
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to update screen", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Screen updated successfully", screen))
+}
+
+func (h *CinemaHandler) DeleteScreen(c *gin.Context) {
+	if err := h.CinemaUsecase.DeleteScreen(c.Param("id")); err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to delete screen", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Screen deleted successfully", nil))
+}
+
+func (h *CinemaHandler) CreateScreening(c *gin.Context) {
+	var req dto.CreateScreeningRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	screening, err := h.CinemaUsecase.CreateScreening(&req)
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to create screening", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Screening created successfully", screening))
+}
+
+func (h *CinemaHandler) UpdateScreening(c *gin.Context) {
+	var req dto.UpdateScreeningRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	screening, err := h.CinemaUsecase.UpdateScreening(c.Param("id"), &req)
+	if err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to update screening", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Screening updated successfully", screening))
+}
+
+func (h *CinemaHandler) DeleteScreening(c *gin.Context) {
+	if err := h.CinemaUsecase.DeleteScreening(c.Param("id")); err != nil {
+		c.JSON(cinemaErrorStatus(err), response.NewErrorResponse("Failed to delete screening", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Screening deleted successfully", nil))
+}
+
+func cinemaErrorStatus(err error) int {
+	switch {
+	case err.Error() == "cinema not found", err.Error() == "screen not found", err.Error() == "screening not found", err.Error() == "movie not found":
+		return http.StatusNotFound
+	case err.Error() == "the cinema already has a screen with this name", strings.HasPrefix(err.Error(), "screen is already in use"),
+		strings.HasPrefix(err.Error(), "screenings with bookings"), strings.HasPrefix(err.Error(), "the screening has bookings"):
+		return http.StatusConflict
+	case strings.HasPrefix(err.Error(), "invalid startsAt"), strings.HasPrefix(err.Error(), "invalid price"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
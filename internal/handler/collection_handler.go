@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CollectionHandler struct {
+	CollectionUsecase *usecase.CollectionUsecase
+}
+
+func NewCollectionHandler(collectionUsecase *usecase.CollectionUsecase) *CollectionHandler {
+	return &CollectionHandler{CollectionUsecase: collectionUsecase}
+}
+
+func (h *CollectionHandler) GetPublicCollections(c *gin.Context) {
+	var req dto.GetCollectionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	collectionsResponse, err := h.CollectionUsecase.GetPublicCollections(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch collections", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Collections fetched successfully",
+		collectionsResponse.Collections,
+		collectionsResponse.PageNumber,
+		collectionsResponse.PageSize,
+		int(collectionsResponse.TotalSize),
+	))
+}
+
+func (h *CollectionHandler) GetMyCollections(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.GetCollectionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	collectionsResponse, err := h.CollectionUsecase.GetUserCollections(userID.(string), &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch collections", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Collections fetched successfully",
+		collectionsResponse.Collections,
+		collectionsResponse.PageNumber,
+		collectionsResponse.PageSize,
+		int(collectionsResponse.TotalSize),
+	))
+}
+
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	collection, err := h.CollectionUsecase.GetCollection(c.Param("id"), c.GetString("user_id"))
+	if err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusInternalServerError), response.NewErrorResponse("Failed to fetch collection", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Collection fetched successfully", collection))
+}
+
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.CreateCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	collection, err := h.CollectionUsecase.CreateCollection(&req, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Failed to create collection", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Collection created successfully", collection))
+}
+
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	collection, err := h.CollectionUsecase.UpdateCollection(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusBadRequest), response.NewErrorResponse("Failed to update collection", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Collection updated successfully", collection))
+}
+
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	if err := h.CollectionUsecase.DeleteCollection(c.Param("id"), userID.(string)); err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusInternalServerError), response.NewErrorResponse("Failed to delete collection", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Collection deleted successfully", nil))
+}
+
+func (h *CollectionHandler) AddEntry(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.AddCollectionEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	entry, err := h.CollectionUsecase.AddEntry(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusBadRequest), response.NewErrorResponse("Failed to add movie to collection", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Movie added to collection", entry))
+}
+
+func (h *CollectionHandler) UpdateEntry(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateCollectionEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	entry, err := h.CollectionUsecase.UpdateEntry(c.Param("id"), c.Param("movieId"), &req, userID.(string))
+	if err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusBadRequest), response.NewErrorResponse("Failed to update collection entry", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Collection entry updated successfully", entry))
+}
+
+func (h *CollectionHandler) RemoveEntry(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	if err := h.CollectionUsecase.RemoveEntry(c.Param("id"), c.Param("movieId"), userID.(string)); err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusInternalServerError), response.NewErrorResponse("Failed to remove movie from collection", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Movie removed from collection", nil))
+}
+
+func (h *CollectionHandler) InviteCollaborator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.InviteCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	collaborator, err := h.CollectionUsecase.InviteCollaborator(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusBadRequest), response.NewErrorResponse("Failed to invite collaborator", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Collaborator invited successfully", collaborator))
+}
+
+func (h *CollectionHandler) AcceptInvitation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	collaborator, err := h.CollectionUsecase.AcceptInvitation(c.Param("id"), userID.(string))
+	if err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusInternalServerError), response.NewErrorResponse("Failed to accept invitation", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Invitation accepted", collaborator))
+}
+
+func (h *CollectionHandler) RemoveCollaborator(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	if err := h.CollectionUsecase.RemoveCollaborator(c.Param("id"), c.Param("userId"), userID.(string)); err != nil {
+		c.JSON(collectionErrorStatus(err, http.StatusInternalServerError), response.NewErrorResponse("Failed to remove collaborator", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Collaborator removed successfully", nil))
+}
+
+func collectionErrorStatus(err error, fallback int) int {
+	switch err.Error() {
+	case "collection not found", "movie not found", "user not found", "collaborator not found",
+		"invitation not found", "movie is not in this collection":
+		return http.StatusNotFound
+	case "movie is already in this collection", "user has already been invited":
+		return http.StatusConflict
+	}
+	if strings.HasPrefix(err.Error(), "forbidden:") {
+		return http.StatusForbidden
+	}
+	return fallback
+}
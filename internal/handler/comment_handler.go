@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CommentHandler struct {
+	CommentUsecase *usecase.CommentUsecase
+}
+
+func NewCommentHandler(commentUsecase *usecase.CommentUsecase) *CommentHandler {
+	return &CommentHandler{CommentUsecase: commentUsecase}
+}
+
+func (h *CommentHandler) GetMovieComments(c *gin.Context) {
+	var req dto.GetCommentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	comments, err := h.CommentUsecase.GetThreads(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), response.NewErrorResponse("Failed to fetch comments", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Comments fetched successfully",
+		comments.Comments,
+		comments.PageNumber,
+		comments.PageSize,
+		int(comments.TotalSize),
+	))
+}
+
+func (h *CommentHandler) GetReplies(c *gin.Context) {
+	var req dto.GetRepliesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	replies, err := h.CommentUsecase.GetReplies(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), response.NewErrorResponse("Failed to fetch replies", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Replies fetched successfully",
+		replies.Comments,
+		replies.PageNumber,
+		replies.PageSize,
+		int(replies.TotalSize),
+	))
+}
+
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	comment, err := h.CommentUsecase.CreateComment(c.Param("id"), &req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), response.NewErrorResponse("Failed to post comment", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Comment posted successfully", comment))
+}
+
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	comment, err := h.CommentUsecase.UpdateComment(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), response.NewErrorResponse("Failed to update comment", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Comment updated successfully", comment))
+}
+
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	if err := h.CommentUsecase.DeleteComment(c.Param("id"), userID.(string)); err != nil {
+		c.JSON(commentErrorStatus(err), response.NewErrorResponse("Failed to delete comment", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Comment deleted successfully", nil))
+}
+
+func (h *CommentHandler) HideComment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	// The reason is optional, so an empty body is fine
+	var req dto.HideCommentRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+			return
+		}
+	}
+
+	comment, err := h.CommentUsecase.HideComment(c.Param("id"), &req, true, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), response.NewErrorResponse("Failed to hide comment", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Comment hidden successfully", comment))
+}
+
+func (h *CommentHandler) UnhideComment(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	comment, err := h.CommentUsecase.HideComment(c.Param("id"), nil, false, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(commentErrorStatus(err), response.NewErrorResponse("Failed to unhide comment", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Comment shown again successfully", comment))
+}
+
+func commentErrorStatus(err error) int {
+	switch {
+	case err.Error() == "movie not found", err.Error() == "comment not found", err.Error() == "parent comment not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "forbidden:"), strings.HasPrefix(err.Error(), "comments can only be edited"):
+		return http.StatusForbidden
+	case err.Error() == "cannot reply to a removed comment":
+		return http.StatusConflict
+	case err.Error() == "comment cannot be empty":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
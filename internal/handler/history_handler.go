@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HistoryHandler struct {
+	HistoryUsecase *usecase.HistoryUsecase
+}
+
+func NewHistoryHandler(historyUsecase *usecase.HistoryUsecase) *HistoryHandler {
+	return &HistoryHandler{HistoryUsecase: historyUsecase}
+}
+
+func (h *HistoryHandler) RecordProgress(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.RecordProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	result, err := h.HistoryUsecase.RecordProgress(c.Param("movieId"), &req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(historyErrorStatus(err), response.NewErrorResponse("Failed to record progress", []string{err.Error()}))
+		return
+	}
+
+	message := "Progress recorded successfully"
+	if !result.Recorded {
+		message = "History is paused, progress was not recorded"
+	}
+	c.JSON(http.StatusOK, response.NewSuccessResponse(message, result))
+}
+
+func (h *HistoryHandler) GetHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.GetHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	history, err := h.HistoryUsecase.GetHistory(&req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(historyErrorStatus(err), response.NewErrorResponse("Failed to fetch history", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"History fetched successfully",
+		history.Entries,
+		history.PageNumber,
+		history.PageSize,
+		int(history.TotalSize),
+	))
+}
+
+func (h *HistoryHandler) GetContinueWatching(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.GetContinueWatchingRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	entries, err := h.HistoryUsecase.GetContinueWatching(&req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(historyErrorStatus(err), response.NewErrorResponse("Failed to fetch continue watching", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Continue watching fetched successfully", entries))
+}
+
+func (h *HistoryHandler) DeleteEntry(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	if err := h.HistoryUsecase.DeleteEntry(c.Param("movieId"), userID.(string)); err != nil {
+		c.JSON(historyErrorStatus(err), response.NewErrorResponse("Failed to remove history entry", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("History entry removed successfully", nil))
+}
+
+func (h *HistoryHandler) ClearHistory(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	result, err := h.HistoryUsecase.ClearHistory(userID.(string))
+	if err != nil {
+		c.JSON(historyErrorStatus(err), response.NewErrorResponse("Failed to clear history", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("History cleared successfully", result))
+}
+
+func (h *HistoryHandler) GetSettings(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	settings, err := h.HistoryUsecase.GetSettings(userID.(string))
+	if err != nil {
+		c.JSON(historyErrorStatus(err), response.NewErrorResponse("Failed to fetch history settings", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("History settings fetched successfully", settings))
+}
+
+func (h *HistoryHandler) UpdateSettings(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.HistorySettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	settings, err := h.HistoryUsecase.UpdateSettings(&req, userID.(string))
+	if err != nil {
+		c.JSON(historyErrorStatus(err), response.NewErrorResponse("Failed to update history settings", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("History settings updated successfully", settings))
+}
+
+func historyErrorStatus(err error) int {
+	switch {
+	case err.Error() == "movie not found", err.Error() == "movie is not in your history":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "invalid"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
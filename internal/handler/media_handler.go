@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/imaging"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	MediaUsecase *usecase.MediaUsecase
+}
+
+func NewMediaHandler(mediaUsecase *usecase.MediaUsecase) *MediaHandler {
+	return &MediaHandler{MediaUsecase: mediaUsecase}
+}
+
+func (h *MediaHandler) GetMovieMedia(c *gin.Context) {
+	var req dto.GetMediaRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	mediaResponse, err := h.MediaUsecase.GetMovieMedia(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to fetch media", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Media fetched successfully",
+		mediaResponse.Media,
+		mediaResponse.PageNumber,
+		mediaResponse.PageSize,
+		int(mediaResponse.TotalSize),
+	))
+}
+
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UploadMediaRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	image, _, err := c.Request.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Image is required", []string{err.Error()}))
+		return
+	}
+	defer image.Close()
+
+	media, err := h.MediaUsecase.UploadMedia(c.Param("id"), &req, image, userID.(string))
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "movie not found":
+			status = http.StatusNotFound
+		case err.Error() == "forbidden: you do not own this movie":
+			status = http.StatusForbidden
+		case err.Error() == "failed to upload image":
+			status = http.StatusBadGateway
+		case errors.Is(err, imaging.ErrTooLarge):
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to upload media", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Media uploaded successfully", media))
+}
+
+func (h *MediaHandler) UpdateMedia(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateMediaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	media, err := h.MediaUsecase.UpdateMedia(c.Param("id"), c.Param("mediaId"), &req, userID.(string))
+	if err != nil {
+		c.JSON(mediaErrorStatus(err, http.StatusBadRequest), response.NewErrorResponse("Failed to update media", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Media updated successfully", media))
+}
+
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	err := h.MediaUsecase.DeleteMedia(c.Param("id"), c.Param("mediaId"), userID.(string))
+	if err != nil {
+		c.JSON(mediaErrorStatus(err, http.StatusInternalServerError), response.NewErrorResponse("Failed to delete media", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Media deleted successfully", nil))
+}
+
+func mediaErrorStatus(err error, fallback int) int {
+	switch err.Error() {
+	case "movie not found", "media not found":
+		return http.StatusNotFound
+	case "forbidden: you do not own this movie":
+		return http.StatusForbidden
+	}
+	return fallback
+}
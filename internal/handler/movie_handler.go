@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"eskalate-movie-api/internal/dto"
 	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/i18n"
+	"eskalate-movie-api/pkg/imaging"
 	"eskalate-movie-api/pkg/response"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -32,11 +39,20 @@ func (h *MovieHandler) CreateMovie(c *gin.Context) {
 	var req dto.CreateMovieRequest
 	req.Title = c.PostForm("title")
 	req.Description = c.PostForm("description")
+	req.Tagline = c.PostForm("tagline")
 	req.Genres = c.PostFormArray("genres")
 	req.Actors = c.PostFormArray("actors")
 	req.TrailerUrl = c.PostForm("trailerUrl")
+	req.ReleaseDate = c.PostForm("releaseDate")
+	req.OriginalLanguage = c.PostForm("originalLanguage")
+	req.Countries = c.PostFormArray("countries")
+	req.ImdbID = c.PostForm("imdbId")
+	req.TmdbID = c.PostForm("tmdbId")
+	if certifications, ok := c.GetPostFormMap("certifications"); ok {
+		req.Certifications = certifications
+	}
 
-	poster, posterHeader, err := c.Request.FormFile("poster")
+	poster, _, err := c.Request.FormFile("poster")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Poster is required", []string{err.Error()}))
 		return
@@ -48,15 +64,74 @@ func (h *MovieHandler) CreateMovie(c *gin.Context) {
 		return
 	}
 
-	movie, err := h.MovieUsecase.CreateMovie(&req, poster, posterHeader, userID.(string))
+	movie, err := h.MovieUsecase.CreateMovie(&req, poster, userID.(string))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Failed to create movie", []string{err.Error()}))
+		var duplicate *usecase.DuplicateMovieError
+		if errors.As(err, &duplicate) {
+			c.JSON(http.StatusConflict, response.NewErrorResponseWithObject("Possible duplicate movie", duplicate.Candidates, []string{err.Error()}))
+			return
+		}
+		status := http.StatusBadRequest
+		if errors.Is(err, imaging.ErrTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to create movie", []string{err.Error()}))
 		return
 	}
 
 	c.JSON(http.StatusCreated, response.NewSuccessResponse("Movie created successfully", movie))
 }
 
+// ImportMovies creates movies in bulk from JSON.
+func (h *MovieHandler) ImportMovies(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.ImportMoviesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	imported, err := h.MovieUsecase.ImportMovies(&req, userID.(string))
+	if err != nil {
+		var conflict *usecase.ImportConflictError
+		if errors.As(err, &conflict) {
+			c.JSON(http.StatusConflict, response.NewErrorResponseWithObject("Possible duplicate movies", conflict.Conflicts, []string{err.Error()}))
+			return
+		}
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Failed to import movies", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Movies imported successfully", imported))
+}
+
+// MergeMovies folds the duplicate given in the body into the movie in the
+// path. Administrators only.
+func (h *MovieHandler) MergeMovies(c *gin.Context) {
+	var req dto.MergeMoviesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	merged, err := h.MovieUsecase.MergeMovies(c.Param("id"), &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "movie not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to merge movies", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Movies merged successfully", merged))
+}
+
 func (h *MovieHandler) UpdateMovie(c *gin.Context) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -76,6 +151,8 @@ func (h *MovieHandler) UpdateMovie(c *gin.Context) {
 		status := http.StatusBadRequest
 		if err.Error() == "forbidden: you do not own this movie" {
 			status = http.StatusForbidden
+		} else if err.Error() == "poster was changed by another request" {
+			status = http.StatusConflict
 		}
 		c.JSON(status, response.NewErrorResponse("Failed to update movie", []string{err.Error()}))
 		return
@@ -91,12 +168,18 @@ func (h *MovieHandler) GetMovies(c *gin.Context) {
 		return
 	}
 
-	moviesResponse, err := h.MovieUsecase.GetMovies(&req)
+	req.Locales = i18n.Preferences(req.Lang, c.GetHeader("Accept-Language"))
+	moviesResponse, err := h.MovieUsecase.GetMovies(&req, c.GetString("user_id"), c.GetString("role"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch movies", []string{err.Error()}))
+		status := http.StatusInternalServerError
+		if err.Error() == "sign in to list movies by status" {
+			status = http.StatusUnauthorized
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to fetch movies", []string{err.Error()}))
 		return
 	}
 
+	c.Header("Vary", "Accept-Language")
 	c.JSON(http.StatusOK, response.NewPaginatedResponse(
 		"Movies fetched successfully",
 		moviesResponse.Movies,
@@ -106,6 +189,39 @@ func (h *MovieHandler) GetMovies(c *gin.Context) {
 	))
 }
 
+func (h *MovieHandler) GetTrendingMovies(c *gin.Context) {
+	h.getRankedMovies(c, h.MovieUsecase.GetTrendingMovies, "Trending movies fetched successfully")
+}
+
+func (h *MovieHandler) GetPopularMovies(c *gin.Context) {
+	h.getRankedMovies(c, h.MovieUsecase.GetPopularMovies, "Popular movies fetched successfully")
+}
+
+func (h *MovieHandler) getRankedMovies(c *gin.Context, rank func(*dto.GetRankedMoviesRequest, string) (*dto.GetRankedMoviesResponse, error), message string) {
+	var req dto.GetRankedMoviesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	req.Locales = i18n.Preferences(req.Lang, c.GetHeader("Accept-Language"))
+	moviesResponse, err := rank(&req, c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch movies", []string{err.Error()}))
+		return
+	}
+
+	c.Header("Vary", "Accept-Language")
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		message,
+		moviesResponse.Movies,
+		moviesResponse.PageNumber,
+		moviesResponse.PageSize,
+		int(moviesResponse.TotalSize),
+	))
+}
+
+// GetMovieByID accepts a movie ID or slug.
 func (h *MovieHandler) GetMovieByID(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -113,7 +229,8 @@ func (h *MovieHandler) GetMovieByID(c *gin.Context) {
 		return
 	}
 
-	movie, err := h.MovieUsecase.GetMovieByID(id)
+	locales := i18n.Preferences(c.Query("lang"), c.GetHeader("Accept-Language"))
+	movie, err := h.MovieUsecase.GetMovieByID(id, c.GetString("user_id"), c.GetString("role"), viewerKey(c), locales)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if err.Error() == "movie not found" {
@@ -123,6 +240,13 @@ func (h *MovieHandler) GetMovieByID(c *gin.Context) {
 		return
 	}
 
+	if movie.RedirectTo != "" {
+		c.Header("Location", movie.RedirectTo)
+	}
+	c.Header("Vary", "Accept-Language")
+	if movie.Locale != "" {
+		c.Header("Content-Language", movie.Locale)
+	}
 	c.JSON(http.StatusOK, response.NewSuccessResponse("Movie details fetched successfully", movie))
 }
 
@@ -146,6 +270,8 @@ func (h *MovieHandler) DeleteMovie(c *gin.Context) {
 			status = http.StatusNotFound
 		} else if err.Error() == "forbidden: you do not own this movie" {
 			status = http.StatusForbidden
+		} else if strings.HasPrefix(err.Error(), "screenings with bookings") {
+			status = http.StatusConflict
 		}
 		c.JSON(status, response.NewErrorResponse("Failed to delete movie", []string{err.Error()}))
 		return
@@ -153,3 +279,218 @@ func (h *MovieHandler) DeleteMovie(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response.NewSuccessResponse("Movie deleted successfully", nil))
 }
+
+func (h *MovieHandler) ReplacePoster(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	poster, _, err := c.Request.FormFile("poster")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Poster is required", []string{err.Error()}))
+		return
+	}
+	defer poster.Close()
+
+	async, _ := strconv.ParseBool(c.PostForm("async"))
+	movie, err := h.MovieUsecase.ReplacePoster(c.Param("id"), poster, async, userID.(string))
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "movie not found":
+			status = http.StatusNotFound
+		case err.Error() == "forbidden: you do not own this movie":
+			status = http.StatusForbidden
+		case err.Error() == "poster was changed by another request":
+			status = http.StatusConflict
+		case err.Error() == "direct uploads are not supported by the configured storage":
+			status = http.StatusNotImplemented
+		case err.Error() == "failed to upload image":
+			status = http.StatusBadGateway
+		case errors.Is(err, imaging.ErrTooLarge):
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to replace poster", []string{err.Error()}))
+		return
+	}
+
+	if async {
+		c.JSON(http.StatusAccepted, response.NewSuccessResponse("Poster upload accepted for processing", movie))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Poster replaced successfully", movie))
+}
+
+func (h *MovieHandler) RequestPosterUpload(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.PosterUploadURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	upload, err := h.MovieUsecase.RequestPosterUpload(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err.Error() == "movie not found":
+			status = http.StatusNotFound
+		case err.Error() == "forbidden: you do not own this movie":
+			status = http.StatusForbidden
+		case err.Error() == "direct uploads are not supported by the configured storage":
+			status = http.StatusNotImplemented
+		case errors.Is(err, imaging.ErrTooLarge):
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to create poster upload", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Poster upload created successfully", upload))
+}
+
+func (h *MovieHandler) ConfirmPosterUpload(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.ConfirmPosterUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	movie, err := h.MovieUsecase.ConfirmPosterUpload(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case err.Error() == "movie not found", err.Error() == "uploaded poster not found":
+			status = http.StatusNotFound
+		case err.Error() == "forbidden: you do not own this movie":
+			status = http.StatusForbidden
+		case err.Error() == "poster was changed by another request":
+			status = http.StatusConflict
+		case err.Error() == "failed to upload image":
+			status = http.StatusBadGateway
+		case errors.Is(err, imaging.ErrTooLarge):
+			status = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to confirm poster upload", []string{err.Error()}))
+		return
+	}
+
+	if req.Async {
+		c.JSON(http.StatusAccepted, response.NewSuccessResponse("Poster upload accepted for processing", movie))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Poster replaced successfully", movie))
+}
+
+func (h *MovieHandler) GetPosterStatus(c *gin.Context) {
+	status, err := h.MovieUsecase.GetPosterStatus(c.Param("id"), c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		httpStatus := http.StatusInternalServerError
+		if err.Error() == "movie not found" {
+			httpStatus = http.StatusNotFound
+		}
+		c.JSON(httpStatus, response.NewErrorResponse("Failed to fetch poster status", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Poster status fetched successfully", status))
+}
+
+func (h *MovieHandler) RetryPosterUpload(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	status, err := h.MovieUsecase.RetryPosterUpload(c.Param("id"), userID.(string))
+	if err != nil {
+		httpStatus := http.StatusInternalServerError
+		switch err.Error() {
+		case "movie not found":
+			httpStatus = http.StatusNotFound
+		case "forbidden: you do not own this movie":
+			httpStatus = http.StatusForbidden
+		case "poster upload has not failed", "poster upload was superseded":
+			httpStatus = http.StatusConflict
+		}
+		c.JSON(httpStatus, response.NewErrorResponse("Failed to retry poster upload", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.NewSuccessResponse("Poster upload queued again", status))
+}
+
+func (h *MovieHandler) AddCredit(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	movieID := c.Param("id")
+	var req dto.AddCreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	credit, err := h.MovieUsecase.AddCredit(movieID, &req, userID.(string))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "movie not found" || err.Error() == "person not found" {
+			status = http.StatusNotFound
+		} else if err.Error() == "forbidden: you do not own this movie" {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to add credit", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Credit added successfully", credit))
+}
+
+func (h *MovieHandler) RemoveCredit(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	err := h.MovieUsecase.RemoveCredit(c.Param("id"), c.Param("creditId"), userID.(string))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie not found" || err.Error() == "credit not found" {
+			status = http.StatusNotFound
+		} else if err.Error() == "forbidden: you do not own this movie" {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to remove credit", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Credit removed successfully", nil))
+}
+
+// viewerKey tells viewers apart for view counting: signed-in users by ID,
+// anyone else by a hash of their address and user agent.
+func viewerKey(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	sum := sha256.Sum256([]byte(c.ClientIP() + "|" + c.Request.UserAgent()))
+	return "anon:" + hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *MovieHandler) GetMovieStatus(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	status, err := h.MovieUsecase.GetMovieStatus(c.Param("id"), userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(movieStatusErrorStatus(err), response.NewErrorResponse("Failed to fetch movie status", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Movie status fetched successfully", status))
+}
+
+func (h *MovieHandler) ChangeMovieStatus(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.ChangeMovieStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	status, err := h.MovieUsecase.ChangeMovieStatus(c.Param("id"), &req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(movieStatusErrorStatus(err), response.NewErrorResponse("Failed to change movie status", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Movie status changed successfully", status))
+}
+
+// GetReviewQueue lists movies waiting for review. Editors only.
+func (h *MovieHandler) GetReviewQueue(c *gin.Context) {
+	var req dto.GetReviewQueueRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	queue, err := h.MovieUsecase.GetReviewQueue(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch review queue", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Review queue fetched successfully",
+		queue.Movies,
+		queue.PageNumber,
+		queue.PageSize,
+		int(queue.TotalSize),
+	))
+}
+
+// ApproveMovie publishes a movie in review. Editors only.
+func (h *MovieHandler) ApproveMovie(c *gin.Context) {
+	var req dto.ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	status, err := h.MovieUsecase.ApproveMovie(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.JSON(movieStatusErrorStatus(err), response.NewErrorResponse("Failed to approve movie", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Movie approved successfully", status))
+}
+
+// RejectMovie returns a movie in review to its owner. Editors only.
+func (h *MovieHandler) RejectMovie(c *gin.Context) {
+	var req dto.ReviewDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	status, err := h.MovieUsecase.RejectMovie(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.JSON(movieStatusErrorStatus(err), response.NewErrorResponse("Failed to reject movie", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Movie rejected successfully", status))
+}
+
+func movieStatusErrorStatus(err error) int {
+	switch {
+	case err.Error() == "movie not found":
+		return http.StatusNotFound
+	case strings.HasPrefix(err.Error(), "forbidden:"):
+		return http.StatusForbidden
+	case err.Error() == "movie status was changed by another request", err.Error() == "movie is not in review":
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
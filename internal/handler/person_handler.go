@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PersonHandler struct {
+	PersonUsecase *usecase.PersonUsecase
+}
+
+func NewPersonHandler(personUsecase *usecase.PersonUsecase) *PersonHandler {
+	return &PersonHandler{PersonUsecase: personUsecase}
+}
+
+func (h *PersonHandler) CreatePerson(c *gin.Context) {
+	var req dto.CreatePersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	person, err := h.PersonUsecase.CreatePerson(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Failed to create person", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Person created successfully", person))
+}
+
+func (h *PersonHandler) UpdatePerson(c *gin.Context) {
+	id := c.Param("id")
+	var req dto.UpdatePersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	person, err := h.PersonUsecase.UpdatePerson(id, &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "person not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to update person", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Person updated successfully", person))
+}
+
+func (h *PersonHandler) GetPeople(c *gin.Context) {
+	var req dto.GetPeopleRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	peopleResponse, err := h.PersonUsecase.GetPeople(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch people", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"People fetched successfully",
+		peopleResponse.People,
+		peopleResponse.PageNumber,
+		peopleResponse.PageSize,
+		int(peopleResponse.TotalSize),
+	))
+}
+
+func (h *PersonHandler) GetPersonByID(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Person ID is required", []string{"invalid person id"}))
+		return
+	}
+
+	person, err := h.PersonUsecase.GetPersonByID(id, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "person not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to fetch person details", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Person details fetched successfully", person))
+}
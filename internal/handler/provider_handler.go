@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProviderHandler struct {
+	ProviderUsecase *usecase.ProviderUsecase
+}
+
+func NewProviderHandler(providerUsecase *usecase.ProviderUsecase) *ProviderHandler {
+	return &ProviderHandler{ProviderUsecase: providerUsecase}
+}
+
+func (h *ProviderHandler) GetProviders(c *gin.Context) {
+	providers, err := h.ProviderUsecase.GetProviders()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch providers", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Providers fetched successfully", providers))
+}
+
+func (h *ProviderHandler) CreateProvider(c *gin.Context) {
+	var req dto.ProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	provider, err := h.ProviderUsecase.CreateProvider(&req)
+	if err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to create provider", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Provider created successfully", provider))
+}
+
+func (h *ProviderHandler) UpdateProvider(c *gin.Context) {
+	var req dto.ProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	provider, err := h.ProviderUsecase.UpdateProvider(c.Param("id"), &req)
+	if err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to update provider", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Provider updated successfully", provider))
+}
+
+func (h *ProviderHandler) DeleteProvider(c *gin.Context) {
+	if err := h.ProviderUsecase.DeleteProvider(c.Param("id")); err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to delete provider", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Provider deleted successfully", nil))
+}
+
+// ImportFeed syncs providers and offers from a feed sent as the body.
+func (h *ProviderHandler) ImportFeed(c *gin.Context) {
+	var feed dto.ProviderFeed
+	if err := c.ShouldBindJSON(&feed); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid feed", []string{err.Error()}))
+		return
+	}
+
+	report, err := h.ProviderUsecase.ImportFeed(&feed)
+	if err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to import feed", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Feed imported successfully", report))
+}
+
+func (h *ProviderHandler) GetMovieAvailability(c *gin.Context) {
+	var req dto.GetAvailabilityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	availability, err := h.ProviderUsecase.GetMovieAvailability(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to fetch availability", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Availability fetched successfully", availability))
+}
+
+func (h *ProviderHandler) CreateAvailability(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.CreateAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	availability, err := h.ProviderUsecase.CreateAvailability(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to add availability", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Availability added successfully", availability))
+}
+
+func (h *ProviderHandler) UpdateAvailability(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	availability, err := h.ProviderUsecase.UpdateAvailability(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to update availability", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Availability updated successfully", availability))
+}
+
+func (h *ProviderHandler) DeleteAvailability(c *gin.Context) {
+	if err := h.ProviderUsecase.DeleteAvailability(c.Param("id")); err != nil {
+		c.JSON(providerErrorStatus(err), response.NewErrorResponse("Failed to delete availability", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Availability deleted successfully", nil))
+}
+
+func providerErrorStatus(err error) int {
+	switch {
+	case err.Error() == "provider not found", err.Error() == "availability not found", err.Error() == "movie not found":
+		return http.StatusNotFound
+	case err.Error() == "provider slug already exists", err.Error() == "availability already exists":
+		return http.StatusConflict
+	case err.Error() == "invalid provider slug", strings.HasPrefix(err.Error(), "invalid feed provider"):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
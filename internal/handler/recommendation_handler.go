@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/i18n"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationHandler struct {
+	RecommendationUsecase *usecase.RecommendationUsecase
+}
+
+func NewRecommendationHandler(recommendationUsecase *usecase.RecommendationUsecase) *RecommendationHandler {
+	return &RecommendationHandler{RecommendationUsecase: recommendationUsecase}
+}
+
+// GetSimilarMovies accepts a movie ID or slug.
+func (h *RecommendationHandler) GetSimilarMovies(c *gin.Context) {
+	var req dto.GetSimilarMoviesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	req.Locales = i18n.Preferences(req.Lang, c.GetHeader("Accept-Language"))
+	movies, err := h.RecommendationUsecase.GetSimilarMovies(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to fetch similar movies", []string{err.Error()}))
+		return
+	}
+
+	c.Header("Vary", "Accept-Language")
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Similar movies fetched successfully", movies))
+}
+
+func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.GetRecommendationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	req.Locales = i18n.Preferences(req.Lang, c.GetHeader("Accept-Language"))
+	recommendations, err := h.RecommendationUsecase.GetRecommendations(&req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch recommendations", []string{err.Error()}))
+		return
+	}
+
+	c.Header("Vary", "Accept-Language")
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Recommendations fetched successfully",
+		recommendations.Movies,
+		recommendations.PageNumber,
+		recommendations.PageSize,
+		int(recommendations.TotalSize),
+	))
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	ReportUsecase *usecase.ReportUsecase
+}
+
+func NewReportHandler(reportUsecase *usecase.ReportUsecase) *ReportHandler {
+	return &ReportHandler{ReportUsecase: reportUsecase}
+}
+
+func (h *ReportHandler) CreateReport(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	report, err := h.ReportUsecase.CreateReport(&req, userID.(string), c.GetString("role"))
+	if err != nil {
+		c.JSON(reportErrorStatus(err), response.NewErrorResponse("Failed to report content", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Content reported successfully", report))
+}
+
+func (h *ReportHandler) GetModerationQueue(c *gin.Context) {
+	var req dto.GetModerationQueueRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", []string{err.Error()}))
+		return
+	}
+
+	reports, err := h.ReportUsecase.GetModerationQueue(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch reports", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Reports fetched successfully",
+		reports.Reports,
+		reports.PageNumber,
+		reports.PageSize,
+		int(reports.TotalSize),
+	))
+}
+
+func (h *ReportHandler) ResolveReport(c *gin.Context) {
+	h.moderate(c, usecase.ModerationResolve, "Report resolved successfully")
+}
+
+func (h *ReportHandler) DismissReport(c *gin.Context) {
+	h.moderate(c, usecase.ModerationDismiss, "Report dismissed successfully")
+}
+
+func (h *ReportHandler) TakeDownContent(c *gin.Context) {
+	h.moderate(c, usecase.ModerationTakeDown, "Content taken down successfully")
+}
+
+func (h *ReportHandler) moderate(c *gin.Context, action, message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	// The note is optional, so an empty body is fine
+	var req dto.ModerationActionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+			return
+		}
+	}
+
+	result, err := h.ReportUsecase.ModerateReport(c.Param("id"), action, &req, userID.(string))
+	if err != nil {
+		c.JSON(reportErrorStatus(err), response.NewErrorResponse("Failed to moderate report", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(message, result))
+}
+
+func reportErrorStatus(err error) int {
+	switch err.Error() {
+	case "report not found", "movie not found", "review not found", "comment not found":
+		return http.StatusNotFound
+	case "you have already reported this content", "report is already closed", "movie status was changed by another request":
+		return http.StatusConflict
+	case "you cannot report your own content":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	ReviewUsecase *usecase.ReviewUsecase
+}
+
+func NewReviewHandler(reviewUsecase *usecase.ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{ReviewUsecase: reviewUsecase}
+}
+
+func (h *ReviewHandler) GetMovieReviews(c *gin.Context) {
+	var req dto.GetReviewsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	reviewsResponse, err := h.ReviewUsecase.GetMovieReviews(c.Param("id"), &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to fetch reviews", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		"Reviews fetched successfully",
+		reviewsResponse.Reviews,
+		reviewsResponse.PageNumber,
+		reviewsResponse.PageSize,
+		int(reviewsResponse.TotalSize),
+	))
+}
+
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	review, err := h.ReviewUsecase.CreateReview(c.Param("id"), &req, userID.(string))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "movie not found" {
+			status = http.StatusNotFound
+		} else if err.Error() == "you have already reviewed this movie" {
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to create review", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse("Review created successfully", review))
+}
+
+func (h *ReviewHandler) UpdateReview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	review, err := h.ReviewUsecase.UpdateReview(c.Param("id"), c.Param("reviewId"), &req, userID.(string))
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "review not found" {
+			status = http.StatusNotFound
+		} else if err.Error() == "forbidden: you do not own this review" {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to update review", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Review updated successfully", review))
+}
+
+func (h *ReviewHandler) DeleteReview(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	err := h.ReviewUsecase.DeleteReview(c.Param("id"), c.Param("reviewId"), userID.(string))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "review not found" {
+			status = http.StatusNotFound
+		} else if err.Error() == "forbidden: you do not own this review" {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to delete review", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Review deleted successfully", nil))
+}
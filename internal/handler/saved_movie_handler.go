@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedMovieHandler struct {
+	SavedMovieUsecase *usecase.SavedMovieUsecase
+}
+
+func NewSavedMovieHandler(savedMovieUsecase *usecase.SavedMovieUsecase) *SavedMovieHandler {
+	return &SavedMovieHandler{SavedMovieUsecase: savedMovieUsecase}
+}
+
+func (h *SavedMovieHandler) GetWatchlist(c *gin.Context) {
+	h.getList(c, domain.ListWatchlist, "Watchlist fetched successfully")
+}
+
+func (h *SavedMovieHandler) AddToWatchlist(c *gin.Context) {
+	h.saveMovie(c, domain.ListWatchlist, "Movie added to watchlist")
+}
+
+func (h *SavedMovieHandler) RemoveFromWatchlist(c *gin.Context) {
+	h.removeMovie(c, domain.ListWatchlist, "Movie removed from watchlist")
+}
+
+func (h *SavedMovieHandler) UpdateWatchlistItem(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateWatchlistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	item, err := h.SavedMovieUsecase.UpdateWatchlistItem(userID.(string), c.Param("movieId"), &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie is not in this list" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to update watchlist", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Watchlist updated successfully", item))
+}
+
+func (h *SavedMovieHandler) GetFavorites(c *gin.Context) {
+	h.getList(c, domain.ListFavorites, "Favorites fetched successfully")
+}
+
+func (h *SavedMovieHandler) AddToFavorites(c *gin.Context) {
+	h.saveMovie(c, domain.ListFavorites, "Movie added to favorites")
+}
+
+func (h *SavedMovieHandler) RemoveFromFavorites(c *gin.Context) {
+	h.removeMovie(c, domain.ListFavorites, "Movie removed from favorites")
+}
+
+func (h *SavedMovieHandler) MoveFavorite(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.UpdateFavoriteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	item, err := h.SavedMovieUsecase.MoveFavorite(userID.(string), c.Param("movieId"), &req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie is not in this list" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to update favorites", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Favorites updated successfully", item))
+}
+
+func (h *SavedMovieHandler) getList(c *gin.Context, list, message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.GetSavedMoviesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid pagination parameters", []string{err.Error()}))
+		return
+	}
+
+	listResponse, err := h.SavedMovieUsecase.GetList(userID.(string), list, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to fetch "+list, []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPaginatedResponse(
+		message,
+		listResponse.Items,
+		listResponse.PageNumber,
+		listResponse.PageSize,
+		int(listResponse.TotalSize),
+	))
+}
+
+func (h *SavedMovieHandler) saveMovie(c *gin.Context, list, message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.SaveMovieRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	item, err := h.SavedMovieUsecase.SaveMovie(userID.(string), list, &req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err.Error() == "movie not found" {
+			status = http.StatusNotFound
+		} else if err.Error() == "movie is already in this list" {
+			status = http.StatusConflict
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to add movie to "+list, []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse(message, item))
+}
+
+func (h *SavedMovieHandler) removeMovie(c *gin.Context, list, message string) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	err := h.SavedMovieUsecase.RemoveMovie(userID.(string), list, c.Param("movieId"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie is not in this list" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to remove movie from "+list, []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(message, nil))
+}
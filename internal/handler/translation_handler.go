@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/usecase"
+	"eskalate-movie-api/pkg/i18n"
+	"eskalate-movie-api/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TranslationHandler struct {
+	TranslationUsecase *usecase.TranslationUsecase
+}
+
+func NewTranslationHandler(translationUsecase *usecase.TranslationUsecase) *TranslationHandler {
+	return &TranslationHandler{TranslationUsecase: translationUsecase}
+}
+
+func (h *TranslationHandler) GetMovieTranslations(c *gin.Context) {
+	translations, err := h.TranslationUsecase.GetMovieTranslations(c.Param("id"), c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err.Error() == "movie not found" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, response.NewErrorResponse("Failed to fetch translations", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Translations fetched successfully", translations))
+}
+
+func (h *TranslationHandler) PutTranslation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	var req dto.PutTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation failed", []string{err.Error()}))
+		return
+	}
+
+	translation, err := h.TranslationUsecase.PutTranslation(c.Param("id"), c.Param("locale"), &req, userID.(string))
+	if err != nil {
+		c.JSON(translationErrorStatus(err), response.NewErrorResponse("Failed to save translation", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Translation saved successfully", translation))
+}
+
+func (h *TranslationHandler) DeleteTranslation(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse("Unauthorized", []string{"unauthorized"}))
+		return
+	}
+
+	if err := h.TranslationUsecase.DeleteTranslation(c.Param("id"), c.Param("locale"), userID.(string)); err != nil {
+		c.JSON(translationErrorStatus(err), response.NewErrorResponse("Failed to delete translation", []string{err.Error()}))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse("Translation deleted successfully", nil))
+}
+
+func translationErrorStatus(err error) int {
+	switch {
+	case err.Error() == "movie not found", err.Error() == "translation not found":
+		return http.StatusNotFound
+	case err.Error() == "forbidden: you do not own this movie":
+		return http.StatusForbidden
+	case errors.Is(err, i18n.ErrInvalidLocale):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
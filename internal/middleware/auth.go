@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"eskalate-movie-api/internal/domain"
 	"eskalate-movie-api/pkg/response"
 	"eskalate-movie-api/pkg/security"
 	"net/http"
@@ -37,6 +38,64 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		c.Set("user_id", claims["user_id"])
+		if role, ok := claims["role"].(string); ok {
+			c.Set("role", role)
+		}
+		c.Next()
+	}
+}
+
+// RequireRole lets only users with one of the roles through. It must run
+// after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(
+			http.StatusForbidden,
+			response.NewErrorResponse(
+				"Insufficient permissions",
+				[]string{"forbidden"},
+			),
+		)
+	}
+}
+
+// ModeratorMiddleware lets only moderators, editors and administrators
+// through.
+func ModeratorMiddleware() gin.HandlerFunc {
+	return RequireRole(domain.RoleModerator, domain.RoleEditor, domain.RoleAdmin)
+}
+
+// EditorMiddleware lets only editors and administrators through.
+func EditorMiddleware() gin.HandlerFunc {
+	return RequireRole(domain.RoleEditor, domain.RoleAdmin)
+}
+
+// AdminMiddleware lets only administrators through.
+func AdminMiddleware() gin.HandlerFunc {
+	return RequireRole(domain.RoleAdmin)
+}
+
+// OptionalAuthMiddleware sets user_id when a valid bearer token is supplied
+// but lets anonymous requests through unchanged.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if strings.HasPrefix(header, "Bearer ") {
+			claims, err := security.ParseJWT(strings.TrimPrefix(header, "Bearer "))
+			if err == nil {
+				c.Set("user_id", claims["user_id"])
+				if role, ok := claims["role"].(string); ok {
+					c.Set("role", role)
+				}
+			}
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingRepository interface {
+	ReplaceSeats(screenID uuid.UUID, seats []*domain.Seat) error
+	GetSeats(screenID uuid.UUID) ([]*domain.Seat, error)
+	GetTakenSeats(screeningID uuid.UUID) (map[uuid.UUID]bool, error)
+	Hold(booking *domain.Booking, seatIDs []uuid.UUID) error
+	FindByID(id string) (*domain.Booking, error)
+	FindByCode(code string) (*domain.Booking, error)
+	FindActiveHold(userID, screeningID uuid.UUID) (*domain.Booking, error)
+	GetUserBookings(userID uuid.UUID, page, pageSize int) ([]*domain.Booking, int64, error)
+	Confirm(booking *domain.Booking) error
+	Cancel(booking *domain.Booking, fromStatus string) error
+	MarkRefunded(booking *domain.Booking) error
+	FindPendingRefunds() ([]*domain.Booking, error)
+	CheckIn(booking *domain.Booking) error
+	ReleaseExpiredHolds() (int64, error)
+}
+
+type postgresBookingRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresBookingRepo(db *gorm.DB) BookingRepository {
+	return &postgresBookingRepo{db: db}
+}
+
+// ReplaceSeats swaps a screen's seat map. It is refused while bookings for
+// upcoming screenings on the screen hold seats, since removing the seats
+// would drop them from those bookings.
+func (r *postgresBookingRepo) ReplaceSeats(screenID uuid.UUID, seats []*domain.Seat) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var booked int64
+		err := tx.Model(&domain.BookingSeat{}).
+			Joins("JOIN screenings ON screenings.id = booking_seats.screening_id").
+			Where("screenings.screen_id = ? AND screenings.ends_at > ?", screenID, time.Now()).
+			Count(&booked).Error
+		if err != nil {
+			return err
+		}
+		if booked > 0 {
+			return errors.New("the screen has bookings for upcoming screenings")
+		}
+		if err := tx.Where("screen_id = ?", screenID).Delete(&domain.Seat{}).Error; err != nil {
+			return err
+		}
+		if len(seats) == 0 {
+			return nil
+		}
+		return tx.Omit("Screen").Create(&seats).Error
+	})
+}
+
+func (r *postgresBookingRepo) GetSeats(screenID uuid.UUID) ([]*domain.Seat, error) {
+	var seats []*domain.Seat
+	err := r.db.Where("screen_id = ?", screenID).
+		Order(`LENGTH("row"), "row", number`).
+		Find(&seats).Error
+	return seats, err
+}
+
+// GetTakenSeats returns the seats of a screening that are booked or held by
+// a hold that has not expired.
+func (r *postgresBookingRepo) GetTakenSeats(screeningID uuid.UUID) (map[uuid.UUID]bool, error) {
+	var seatIDs []uuid.UUID
+	err := r.db.Model(&domain.BookingSeat{}).
+		Joins("JOIN bookings ON bookings.id = booking_seats.booking_id").
+		Where("booking_seats.screening_id = ?", screeningID).
+		Where("bookings.status = ? OR (bookings.status = ? AND bookings.expires_at > ?)",
+			domain.BookingConfirmed, domain.BookingHeld, time.Now()).
+		Pluck("booking_seats.seat_id", &seatIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[uuid.UUID]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		taken[id] = true
+	}
+	return taken, nil
+}
+
+// Hold creates a held booking for the given seats. Expired holds on the
+// screening are released first. Claiming the seats relies on the unique
+// index on screening and seat: when another request claims one of them
+// first, even concurrently, the insert skips it and the whole hold is
+// rolled back.
+func (r *postgresBookingRepo) Hold(booking *domain.Booking, seatIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := releaseExpiredHolds(tx, &booking.ScreeningID); err != nil {
+			return err
+		}
+		if err := tx.Omit("Screening").Create(booking).Error; err != nil {
+			return err
+		}
+		claims := make([]domain.BookingSeat, len(seatIDs))
+		for i, seatID := range seatIDs {
+			claims[i] = domain.BookingSeat{BookingID: booking.ID, SeatID: seatID, ScreeningID: booking.ScreeningID}
+		}
+		result := tx.Omit("Booking", "Seat").Clauses(clause.OnConflict{DoNothing: true}).Create(&claims)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < int64(len(claims)) {
+			return errors.New("some of the seats are no longer available")
+		}
+		return nil
+	})
+}
+
+func (r *postgresBookingRepo) FindByID(id string) (*domain.Booking, error) {
+	var booking domain.Booking
+	err := r.withScreening().First(&booking, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("booking not found")
+	}
+	return &booking, err
+}
+
+func (r *postgresBookingRepo) FindByCode(code string) (*domain.Booking, error) {
+	var booking domain.Booking
+	err := r.withScreening().First(&booking, "code = ?", code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("ticket not found")
+	}
+	return &booking, err
+}
+
+// FindActiveHold finds the user's unexpired hold on a screening, if any.
+func (r *postgresBookingRepo) FindActiveHold(userID, screeningID uuid.UUID) (*domain.Booking, error) {
+	var booking domain.Booking
+	err := r.db.Where("user_id = ? AND screening_id = ? AND status = ? AND expires_at > ?",
+		userID, screeningID, domain.BookingHeld, time.Now()).
+		First(&booking).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("booking not found")
+	}
+	return &booking, err
+}
+
+// GetUserBookings lists a user's bookings, newest first.
+func (r *postgresBookingRepo) GetUserBookings(userID uuid.UUID, page, pageSize int) ([]*domain.Booking, int64, error) {
+	var bookings []*domain.Booking
+	var totalCount int64
+
+	query := r.db.Model(&domain.Booking{}).Where("user_id = ?", userID)
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Preload("Screening.Movie").Preload("Screening.Screen").Preload("Screening.Cinema").
+		Order("created_at DESC, id").Offset(offset).Limit(pageSize).Find(&bookings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return bookings, totalCount, nil
+}
+
+// Confirm turns a held booking into a confirmed one, provided the hold has
+// not expired in the meantime.
+func (r *postgresBookingRepo) Confirm(booking *domain.Booking) error {
+	result := r.db.Model(&domain.Booking{}).
+		Where("id = ? AND status = ? AND expires_at > ?", booking.ID, domain.BookingHeld, time.Now()).
+		Updates(map[string]any{
+			"status":            domain.BookingConfirmed,
+			"code":              booking.Code,
+			"payment_provider":  booking.PaymentProvider,
+			"payment_reference": booking.PaymentReference,
+			"confirmed_at":      booking.ConfirmedAt,
+			"updated_at":        booking.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("the hold has expired")
+	}
+	booking.Status = domain.BookingConfirmed
+	return nil
+}
+
+// Cancel cancels a booking that is still in the given status and not checked
+// in, and releases its seats.
+func (r *postgresBookingRepo) Cancel(booking *domain.Booking, fromStatus string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.Booking{}).
+			Where("id = ? AND status = ? AND checked_in_at IS NULL", booking.ID, fromStatus).
+			Updates(map[string]any{
+				"status":       domain.BookingCancelled,
+				"cancelled_at": booking.CancelledAt,
+				"updated_at":   booking.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("the booking has changed, please try again")
+		}
+		booking.Status = domain.BookingCancelled
+		return tx.Where("booking_id = ?", booking.ID).Delete(&domain.BookingSeat{}).Error
+	})
+}
+
+// MarkRefunded records that the payment of a cancelled booking was paid
+// back.
+func (r *postgresBookingRepo) MarkRefunded(booking *domain.Booking) error {
+	now := time.Now()
+	err := r.db.Model(&domain.Booking{}).
+		Where("id = ? AND refunded_at IS NULL", booking.ID).
+		Update("refunded_at", now).Error
+	if err != nil {
+		return err
+	}
+	booking.RefundedAt = &now
+	return nil
+}
+
+// FindPendingRefunds lists the cancelled bookings whose payment has not been
+// paid back yet.
+func (r *postgresBookingRepo) FindPendingRefunds() ([]*domain.Booking, error) {
+	var bookings []*domain.Booking
+	err := r.db.Where(pendingRefund, domain.BookingCancelled).Order("cancelled_at").Find(&bookings).Error
+	return bookings, err
+}
+
+// pendingRefund matches cancelled bookings that are still owed a refund.
+const pendingRefund = "status = ? AND payment_reference <> '' AND refunded_at IS NULL"
+
+// CheckIn records the first use of a confirmed ticket.
+func (r *postgresBookingRepo) CheckIn(booking *domain.Booking) error {
+	now := time.Now()
+	result := r.db.Model(&domain.Booking{}).
+		Where("id = ? AND status = ? AND checked_in_at IS NULL", booking.ID, domain.BookingConfirmed).
+		Update("checked_in_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("ticket has already been used")
+	}
+	booking.CheckedInAt = &now
+	return nil
+}
+
+// ReleaseExpiredHolds marks held bookings past their hold as expired and
+// frees their seats.
+func (r *postgresBookingRepo) ReleaseExpiredHolds() (int64, error) {
+	var released int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		released, err = releaseExpiredHolds(tx, nil)
+		return err
+	})
+	return released, err
+}
+
+// releaseExpiredHolds expires held bookings past their hold, optionally of
+// one screening only, and deletes their seat claims. It must run in a
+// transaction; the expired bookings stay locked until it ends.
+func releaseExpiredHolds(tx *gorm.DB, screeningID *uuid.UUID) (int64, error) {
+	now := time.Now()
+	query := tx.Model(&domain.Booking{}).Where("status = ? AND expires_at <= ?", domain.BookingHeld, now)
+	if screeningID != nil {
+		query = query.Where("screening_id = ?", *screeningID)
+	}
+	var ids []uuid.UUID
+	if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	err := tx.Model(&domain.Booking{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{"status": domain.BookingExpired, "updated_at": now}).Error
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Where("booking_id IN ?", ids).Delete(&domain.BookingSeat{}).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// countActiveBookings counts the bookings of the screenings that still
+// matter to their customer: paid tickets for screenings that have not ended,
+// seat holds that have not run out and cancellations whose refund is still
+// due. Tickets for past screenings can no longer be cancelled, so they do
+// not count.
+func countActiveBookings(tx *gorm.DB, screeningIDs []uuid.UUID) (int64, error) {
+	now := time.Now()
+	var count int64
+	err := tx.Model(&domain.Booking{}).
+		Where("screening_id IN ?", screeningIDs).
+		Where(tx.Where("status = ? AND screening_id IN (SELECT id FROM screenings WHERE ends_at > ?)", domain.BookingConfirmed, now).
+			Or("status = ? AND expires_at > ?", domain.BookingHeld, now).
+			Or(pendingRefund, domain.BookingCancelled)).
+		Count(&count).Error
+	return count, err
+}
+
+// clearScreeningBookings makes way for deleting the screenings matching the
+// condition, in the same transaction. It is refused while any of them has
+// active bookings, whose customers would lose their tickets without a
+// refund; the bookings that are over, including the tickets of screenings
+// that have ended, are deleted. The screenings stay locked
+// so that no seats can be held on them in the meantime.
+func clearScreeningBookings(tx *gorm.DB, query string, args ...any) error {
+	var ids []uuid.UUID
+	err := tx.Model(&domain.Screening{}).
+		Where(query, args...).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	active, err := countActiveBookings(tx, ids)
+	if err != nil {
+		return err
+	}
+	if active > 0 {
+		return errors.New("screenings with bookings cannot be deleted; cancel the bookings first")
+	}
+	return tx.Where("screening_id IN ?", ids).Delete(&domain.Booking{}).Error
+}
+
+func (r *postgresBookingRepo) withScreening() *gorm.DB {
+	return r.db.Preload("Screening.Movie").Preload("Screening.Screen").Preload("Screening.Cinema")
+}
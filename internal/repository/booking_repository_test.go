@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"eskalate-movie-api/internal/domain"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// testDB connects to the database in POSTGRES_TEST_DSN and returns a
+// transaction that is rolled back after the test. Tests that need it are
+// skipped when no database is configured.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp"`).Error; err != nil {
+		t.Fatal(err)
+	}
+	err = db.AutoMigrate(&domain.Movie{}, &domain.Cinema{}, &domain.Screen{}, &domain.Screening{},
+		&domain.Seat{}, &domain.Booking{}, &domain.BookingSeat{}, &domain.WatchHistory{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := db.Begin()
+	t.Cleanup(func() { tx.Rollback() })
+	return tx
+}
+
+// seedMovie creates a live movie. Array columns are written as literals.
+func seedMovie(t *testing.T, tx *gorm.DB) uuid.UUID {
+	t.Helper()
+	movieID := uuid.New()
+	err := tx.Exec(`INSERT INTO movies (id, title, description, poster, trailer, actors, genres, user_id)
+		VALUES (?, 'Heat', '', '', '', '{}', '{}', ?)`, movieID, uuid.New()).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	return movieID
+}
+
+// seedTicket creates a screening from startsAt to two hours later with one
+// confirmed booking for a single seat, and returns the screening.
+func seedTicket(t *testing.T, tx *gorm.DB, startsAt time.Time, checkedIn bool) *domain.Screening {
+	t.Helper()
+	movieID := seedMovie(t, tx)
+	cinema := &domain.Cinema{ID: uuid.New(), Name: "Odeon", City: "Berlin", Country: "DE", TimeZone: "Europe/Berlin"}
+	screen := &domain.Screen{ID: uuid.New(), CinemaID: cinema.ID, Name: "1"}
+	seat := &domain.Seat{ID: uuid.New(), ScreenID: screen.ID, Row: "A", Number: 1}
+	screening := &domain.Screening{
+		ID: uuid.New(), MovieID: movieID, ScreenID: screen.ID, CinemaID: cinema.ID,
+		StartsAt: startsAt, EndsAt: startsAt.Add(2 * time.Hour), Format: domain.Format2D, Price: 10, Currency: "EUR",
+	}
+	for _, record := range []any{cinema, screen, seat, screening} {
+		if err := tx.Create(record).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bookingID := uuid.New()
+	now := time.Now()
+	var checkedInAt *time.Time
+	if checkedIn {
+		checkedInAt = &now
+	}
+	err := tx.Exec(`INSERT INTO bookings (id, screening_id, user_id, status, seat_labels, amount, currency,
+			payment_provider, payment_reference, code, confirmed_at, checked_in_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, '{A1}', 10, 'EUR', 'fake', 'fake_1', ?, ?, ?, ?, ?)`,
+		bookingID, screening.ID, uuid.New(), domain.BookingConfirmed, uuid.New().String()[:10], now, checkedInAt, now, now).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Create(&domain.BookingSeat{BookingID: bookingID, SeatID: seat.ID, ScreeningID: screening.ID}).Error; err != nil {
+		t.Fatal(err)
+	}
+	return screening
+}
+
+func TestDeleteScreeningAfterItEnded(t *testing.T) {
+	tx := testDB(t)
+	cinemas := NewPostgresCinemaRepo(tx)
+	past := seedTicket(t, tx, time.Now().Add(-5*time.Hour), true)
+	upcoming := seedTicket(t, tx, time.Now().Add(24*time.Hour), false)
+
+	if err := cinemas.DeleteScreening(upcoming.ID.String()); err == nil || err.Error() != "screenings with bookings cannot be deleted; cancel the bookings first" {
+		t.Fatalf("deleting a screening with a sold ticket: got %v", err)
+	}
+	if err := cinemas.DeleteScreening(past.ID.String()); err != nil {
+		t.Fatalf("deleting a past screening with a used ticket: %v", err)
+	}
+
+	var left int64
+	if err := tx.Model(&domain.Booking{}).Where("screening_id = ?", past.ID).Count(&left).Error; err != nil {
+		t.Fatal(err)
+	}
+	if left != 0 {
+		t.Fatalf("%d bookings of the deleted screening are left", left)
+	}
+	if _, err := cinemas.FindScreeningByID(past.ID.String()); err == nil || err.Error() != "screening not found" {
+		t.Fatalf("finding the deleted screening: got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// localDate is a screening's start date in its cinema's time zone.
+const localDate = "(screenings.starts_at AT TIME ZONE \"Cinema\".time_zone)::date"
+
+type CinemaFilter struct {
+	City    string
+	Country string
+}
+
+// ScreeningFilter narrows screenings to a day in each cinema's time zone, in
+// the form 2006-01-02. Without a date, screenings later today are listed.
+type ScreeningFilter struct {
+	MovieID  *uuid.UUID
+	CinemaID *uuid.UUID
+	City     string
+	Date     string
+}
+
+type CinemaRepository interface {
+	CreateCinema(cinema *domain.Cinema) error
+	UpdateCinema(cinema *domain.Cinema) error
+	DeleteCinema(id string) error
+	FindCinemaByID(id string) (*domain.Cinema, error)
+	GetCinemas(filter CinemaFilter, page, pageSize int) ([]*domain.Cinema, int64, error)
+	CreateScreen(screen *domain.Screen) error
+	UpdateScreen(screen *domain.Screen) error
+	DeleteScreen(id string) error
+	FindScreenByID(id string) (*domain.Screen, error)
+	GetScreens(cinemaID uuid.UUID) ([]*domain.Screen, error)
+	CreateScreening(screening *domain.Screening) error
+	UpdateScreening(screening *domain.Screening) error
+	DeleteScreening(id string) error
+	FindScreeningByID(id string) (*domain.Screening, error)
+	FindOverlappingScreening(screenID uuid.UUID, startsAt, endsAt time.Time, excludeID *uuid.UUID) (*domain.Screening, error)
+	GetScreenings(filter ScreeningFilter) ([]*domain.Screening, error)
+}
+
+type postgresCinemaRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresCinemaRepo(db *gorm.DB) CinemaRepository {
+	return &postgresCinemaRepo{db: db}
+}
+
+func (r *postgresCinemaRepo) CreateCinema(cinema *domain.Cinema) error {
+	return r.db.Create(cinema).Error
+}
+
+func (r *postgresCinemaRepo) UpdateCinema(cinema *domain.Cinema) error {
+	return r.db.Model(cinema).
+		Select("name", "city", "country", "address", "time_zone", "latitude", "longitude", "website", "updated_at").
+		Updates(cinema).Error
+}
+
+// DeleteCinema removes the cinema with its screens and screenings, unless
+// any of them has active bookings.
+func (r *postgresCinemaRepo) DeleteCinema(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearScreeningBookings(tx, "cinema_id = ?", id); err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Cinema{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("cinema not found")
+		}
+		return nil
+	})
+}
+
+func (r *postgresCinemaRepo) FindCinemaByID(id string) (*domain.Cinema, error) {
+	var cinema domain.Cinema
+	if err := r.db.First(&cinema, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("cinema not found")
+		}
+		return nil, err
+	}
+	return &cinema, nil
+}
+
+func (r *postgresCinemaRepo) GetCinemas(filter CinemaFilter, page, pageSize int) ([]*domain.Cinema, int64, error) {
+	query := r.db.Model(&domain.Cinema{})
+	if filter.City != "" {
+		query = query.Where("LOWER(city) = LOWER(?)", filter.City)
+	}
+	if filter.Country != "" {
+		query = query.Where("country = ?", filter.Country)
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var cinemas []*domain.Cinema
+	err := query.Order("city, name").Offset((page - 1) * pageSize).Limit(pageSize).Find(&cinemas).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return cinemas, total, nil
+}
+
+func (r *postgresCinemaRepo) CreateScreen(screen *domain.Screen) error {
+	result := r.db.Omit("Cinema").Clauses(clause.OnConflict{DoNothing: true}).Create(screen)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("the cinema already has a screen with this name")
+	}
+	return nil
+}
+
+func (r *postgresCinemaRepo) UpdateScreen(screen *domain.Screen) error {
+	return r.db.Model(screen).Select("name").Updates(screen).Error
+}
+
+// DeleteScreen removes the screen with its screenings, unless any of them
+// has active bookings.
+func (r *postgresCinemaRepo) DeleteScreen(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearScreeningBookings(tx, "screen_id = ?", id); err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Screen{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("screen not found")
+		}
+		return nil
+	})
+}
+
+func (r *postgresCinemaRepo) FindScreenByID(id string) (*domain.Screen, error) {
+	var screen domain.Screen
+	if err := r.db.Preload("Cinema").First(&screen, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("screen not found")
+		}
+		return nil, err
+	}
+	return &screen, nil
+}
+
+func (r *postgresCinemaRepo) GetScreens(cinemaID uuid.UUID) ([]*domain.Screen, error) {
+	var screens []*domain.Screen
+	err := r.db.Where("cinema_id = ?", cinemaID).Order("name").Find(&screens).Error
+	return screens, err
+}
+
+func (r *postgresCinemaRepo) CreateScreening(screening *domain.Screening) error {
+	return r.db.Omit("Movie", "Screen", "Cinema").Create(screening).Error
+}
+
+// UpdateScreening saves a screening. Its times and price cannot change
+// while it has active bookings, whose tickets and payments were made for the
+// old ones.
+func (r *postgresCinemaRepo) UpdateScreening(screening *domain.Screening) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var current domain.Screening
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&current, "id = ?", screening.ID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("screening not found")
+		}
+		if err != nil {
+			return err
+		}
+		if !current.StartsAt.Equal(screening.StartsAt) || !current.EndsAt.Equal(screening.EndsAt) ||
+			current.Price != screening.Price || current.Currency != screening.Currency {
+			active, err := countActiveBookings(tx, []uuid.UUID{screening.ID})
+			if err != nil {
+				return err
+			}
+			if active > 0 {
+				return errors.New("the screening has bookings, so its time and price cannot change")
+			}
+		}
+		return tx.Model(screening).
+			Select("starts_at", "ends_at", "language", "subtitles", "format", "price", "currency", "updated_at").
+			Updates(screening).Error
+	})
+}
+
+// DeleteScreening removes a screening, unless it has active bookings.
+func (r *postgresCinemaRepo) DeleteScreening(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearScreeningBookings(tx, "id = ?", id); err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Screening{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("screening not found")
+		}
+		return nil
+	})
+}
+
+func (r *postgresCinemaRepo) FindScreeningByID(id string) (*domain.Screening, error) {
+	var screening domain.Screening
+	err := r.db.Joins("Screen").Joins("Cinema").First(&screening, "screenings.id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("screening not found")
+		}
+		return nil, err
+	}
+	return &screening, nil
+}
+
+// FindOverlappingScreening returns a screening on the screen that overlaps
+// the given times, other than the excluded one.
+func (r *postgresCinemaRepo) FindOverlappingScreening(screenID uuid.UUID, startsAt, endsAt time.Time, excludeID *uuid.UUID) (*domain.Screening, error) {
+	query := r.db.Where("screen_id = ? AND starts_at < ? AND ends_at > ?", screenID, endsAt, startsAt)
+	if excludeID != nil {
+		query = query.Where("id <> ?", *excludeID)
+	}
+	var screening domain.Screening
+	if err := query.Order("starts_at").First(&screening).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &screening, nil
+}
+
+// GetScreenings lists the screenings of live movies by start time, with
+// their movie, screen and cinema.
+func (r *postgresCinemaRepo) GetScreenings(filter ScreeningFilter) ([]*domain.Screening, error) {
+	query := r.db.Joins("Movie").Joins("Screen").Joins("Cinema").
+		Where("\"Movie\".status = ? AND (\"Movie\".publish_at IS NULL OR \"Movie\".publish_at <= NOW())", domain.MoviePublished)
+	if filter.MovieID != nil {
+		query = query.Where("screenings.movie_id = ?", *filter.MovieID)
+	}
+	if filter.CinemaID != nil {
+		query = query.Where("screenings.cinema_id = ?", *filter.CinemaID)
+	}
+	if filter.City != "" {
+		query = query.Where("LOWER(\"Cinema\".city) = LOWER(?)", filter.City)
+	}
+	if filter.Date != "" {
+		query = query.Where(localDate+" = ?", filter.Date)
+	} else {
+		query = query.Where(localDate + " = (NOW() AT TIME ZONE \"Cinema\".time_zone)::date AND screenings.starts_at >= NOW()")
+	}
+
+	var screenings []*domain.Screening
+	err := query.Order("screenings.starts_at, \"Cinema\".name, \"Screen\".name").Find(&screenings).Error
+	return screenings, err
+}
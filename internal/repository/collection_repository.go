@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type CollectionRepository interface {
+	Create(collection *domain.Collection) error
+	FindByID(id string) (*domain.Collection, error)
+	Update(collection *domain.Collection) error
+	Delete(id string) error
+	GetPublic(page, pageSize int, name string) ([]*domain.Collection, int64, error)
+	GetForUser(userID string, page, pageSize int) ([]*domain.Collection, int64, error)
+
+	AddEntry(entry *domain.CollectionEntry) error
+	FindEntry(collectionID, movieID string) (*domain.CollectionEntry, error)
+	UpdateEntry(entry *domain.CollectionEntry) error
+	MoveEntry(entry *domain.CollectionEntry, position int) error
+	RemoveEntry(entry *domain.CollectionEntry) error
+	GetEntries(collectionID string) ([]*domain.CollectionEntry, error)
+
+	AddCollaborator(collaborator *domain.CollectionCollaborator) error
+	FindCollaborator(collectionID, userID string) (*domain.CollectionCollaborator, error)
+	UpdateCollaborator(collaborator *domain.CollectionCollaborator) error
+	RemoveCollaborator(collaborator *domain.CollectionCollaborator) error
+	GetCollaborators(collectionID string) ([]*domain.CollectionCollaborator, error)
+}
+
+type postgresCollectionRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresCollectionRepo(db *gorm.DB) CollectionRepository {
+	return &postgresCollectionRepo{db: db}
+}
+
+func (r *postgresCollectionRepo) Create(collection *domain.Collection) error {
+	return r.db.Create(collection).Error
+}
+
+func (r *postgresCollectionRepo) FindByID(id string) (*domain.Collection, error) {
+	var collection domain.Collection
+	err := r.db.First(&collection, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("collection not found")
+	}
+	return &collection, err
+}
+
+func (r *postgresCollectionRepo) Update(collection *domain.Collection) error {
+	return r.db.Save(collection).Error
+}
+
+func (r *postgresCollectionRepo) Delete(id string) error {
+	result := r.db.Delete(&domain.Collection{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("collection not found")
+	}
+	return nil
+}
+
+func (r *postgresCollectionRepo) GetPublic(page, pageSize int, name string) ([]*domain.Collection, int64, error) {
+	query := r.db.Model(&domain.Collection{}).Where("visibility = ?", domain.VisibilityPublic)
+	if name != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+name+"%")
+	}
+	return paginateCollections(query.Order("updated_at DESC"), page, pageSize)
+}
+
+// GetForUser returns the collections a user owns or has accepted an
+// invitation to.
+func (r *postgresCollectionRepo) GetForUser(userID string, page, pageSize int) ([]*domain.Collection, int64, error) {
+	query := r.db.Model(&domain.Collection{}).
+		Where("owner_id = ? OR id IN (SELECT collection_id FROM collection_collaborators WHERE user_id = ? AND status = ?)",
+			userID, userID, domain.InvitationAccepted)
+	return paginateCollections(query.Order("updated_at DESC"), page, pageSize)
+}
+
+// AddEntry appends the entry to the end of the collection.
+func (r *postgresCollectionRepo) AddEntry(entry *domain.CollectionEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&domain.CollectionEntry{}).
+			Where("collection_id = ?", entry.CollectionID).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		entry.Position = last + 1
+		return tx.Omit("Movie").Create(entry).Error
+	})
+}
+
+func (r *postgresCollectionRepo) FindEntry(collectionID, movieID string) (*domain.CollectionEntry, error) {
+	var entry domain.CollectionEntry
+	err := r.db.Preload("Movie").
+		Where("collection_id = ? AND movie_id = ?", collectionID, movieID).
+		First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("movie is not in this collection")
+	}
+	return &entry, err
+}
+
+func (r *postgresCollectionRepo) UpdateEntry(entry *domain.CollectionEntry) error {
+	return r.db.Omit("Movie").Save(entry).Error
+}
+
+// MoveEntry places the entry at the given 1-based position and shifts the
+// entries in between so positions stay contiguous.
+func (r *postgresCollectionRepo) MoveEntry(entry *domain.CollectionEntry, position int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&domain.CollectionEntry{}).
+			Where("collection_id = ?", entry.CollectionID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if position > int(count) {
+			position = int(count)
+		}
+		if position == entry.Position {
+			return nil
+		}
+
+		err = shiftPositions(tx, &domain.CollectionEntry{}, entry.Position, position,
+			"collection_id = ?", entry.CollectionID)
+		if err != nil {
+			return err
+		}
+
+		entry.Position = position
+		return tx.Model(entry).Update("position", position).Error
+	})
+}
+
+func (r *postgresCollectionRepo) RemoveEntry(entry *domain.CollectionEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.CollectionEntry{}, "id = ?", entry.ID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.CollectionEntry{}).
+			Where("collection_id = ? AND position > ?", entry.CollectionID, entry.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+func (r *postgresCollectionRepo) GetEntries(collectionID string) ([]*domain.CollectionEntry, error) {
+	var entries []*domain.CollectionEntry
+	err := r.db.Preload("Movie").
+		Where("collection_id = ?", collectionID).
+		Order("position").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *postgresCollectionRepo) AddCollaborator(collaborator *domain.CollectionCollaborator) error {
+	return r.db.Create(collaborator).Error
+}
+
+func (r *postgresCollectionRepo) FindCollaborator(collectionID, userID string) (*domain.CollectionCollaborator, error) {
+	var collaborator domain.CollectionCollaborator
+	err := r.db.Where("collection_id = ? AND user_id = ?", collectionID, userID).First(&collaborator).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("collaborator not found")
+	}
+	return &collaborator, err
+}
+
+func (r *postgresCollectionRepo) UpdateCollaborator(collaborator *domain.CollectionCollaborator) error {
+	return r.db.Save(collaborator).Error
+}
+
+func (r *postgresCollectionRepo) RemoveCollaborator(collaborator *domain.CollectionCollaborator) error {
+	return r.db.Delete(&domain.CollectionCollaborator{}, "id = ?", collaborator.ID).Error
+}
+
+func (r *postgresCollectionRepo) GetCollaborators(collectionID string) ([]*domain.CollectionCollaborator, error) {
+	var collaborators []*domain.CollectionCollaborator
+	err := r.db.Where("collection_id = ?", collectionID).Order("created_at").Find(&collaborators).Error
+	return collaborators, err
+}
+
+func paginateCollections(query *gorm.DB, page, pageSize int) ([]*domain.Collection, int64, error) {
+	var collections []*domain.Collection
+	var totalCount int64
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Offset(offset).Limit(pageSize).Find(&collections).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return collections, totalCount, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	Create(comment *domain.Comment) error
+	FindByID(id string) (*domain.Comment, error)
+	Update(comment *domain.Comment) error
+	Delete(comment *domain.Comment) error
+	GetThreads(movieID string, page, pageSize int, sort string) ([]*domain.Comment, int64, error)
+	GetReplies(parentID string, page, pageSize int) ([]*domain.Comment, int64, error)
+	SetMentions(commentID uuid.UUID, mentions []domain.CommentMention) error
+	GetMentions(commentIDs []uuid.UUID) (map[uuid.UUID][]domain.CommentMention, error)
+}
+
+type postgresCommentRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresCommentRepo(db *gorm.DB) CommentRepository {
+	return &postgresCommentRepo{db: db}
+}
+
+// Create adds a comment and counts it as a reply of its parent.
+func (r *postgresCommentRepo) Create(comment *domain.Comment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Movie", "Parent").Create(comment).Error; err != nil {
+			return err
+		}
+		if comment.ParentID == nil {
+			return nil
+		}
+		return tx.Model(&domain.Comment{}).Where("id = ?", *comment.ParentID).
+			UpdateColumn("reply_count", gorm.Expr("reply_count + 1")).Error
+	})
+}
+
+func (r *postgresCommentRepo) FindByID(id string) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := r.db.First(&comment, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("comment not found")
+	}
+	return &comment, err
+}
+
+// Update saves the editable fields of a comment. The reply count is left to
+// Create and Delete.
+func (r *postgresCommentRepo) Update(comment *domain.Comment) error {
+	return r.db.Model(comment).Select("Body", "Hidden", "HiddenBy", "HiddenReason", "Deleted", "EditedAt").Updates(comment).Error
+}
+
+// Delete removes a comment without replies and uncounts it from its parent.
+// Parents that were deleted while they still had replies go with their last
+// reply.
+func (r *postgresCommentRepo) Delete(comment *domain.Comment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Comment{}, "id = ? AND reply_count = 0", comment.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("comment not found")
+		}
+
+		parentID := comment.ParentID
+		for parentID != nil {
+			var parent domain.Comment
+			err := tx.Raw(`
+				UPDATE comments SET reply_count = reply_count - 1 WHERE id = ?
+				RETURNING id, parent_id, deleted, reply_count`, *parentID).Scan(&parent).Error
+			if err != nil {
+				return err
+			}
+			if !parent.Deleted || parent.ReplyCount > 0 {
+				return nil
+			}
+			if err := tx.Delete(&domain.Comment{}, "id = ?", parent.ID).Error; err != nil {
+				return err
+			}
+			parentID = parent.ParentID
+		}
+		return nil
+	})
+}
+
+// GetThreads pages through the top-level comments of a movie.
+func (r *postgresCommentRepo) GetThreads(movieID string, page, pageSize int, sort string) ([]*domain.Comment, int64, error) {
+	var comments []*domain.Comment
+	var totalCount int64
+
+	query := r.db.Model(&domain.Comment{}).Where("movie_id = ? AND parent_id IS NULL", movieID)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "created_at DESC, id"
+	switch sort {
+	case "oldest":
+		order = "created_at ASC, id"
+	case "most_replies":
+		order = "reply_count DESC, created_at DESC, id"
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order(order).Offset(offset).Limit(pageSize).Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	return comments, totalCount, nil
+}
+
+// GetReplies pages through the direct replies to a comment, oldest first so
+// a conversation reads in order.
+func (r *postgresCommentRepo) GetReplies(parentID string, page, pageSize int) ([]*domain.Comment, int64, error) {
+	var comments []*domain.Comment
+	var totalCount int64
+
+	query := r.db.Model(&domain.Comment{}).Where("parent_id = ?", parentID)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at ASC, id").Offset(offset).Limit(pageSize).Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	return comments, totalCount, nil
+}
+
+// SetMentions replaces the users mentioned in a comment.
+func (r *postgresCommentRepo) SetMentions(commentID uuid.UUID, mentions []domain.CommentMention) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.CommentMention{}, "comment_id = ?", commentID).Error; err != nil {
+			return err
+		}
+		if len(mentions) == 0 {
+			return nil
+		}
+		return tx.Omit("Comment").Create(&mentions).Error
+	})
+}
+
+func (r *postgresCommentRepo) GetMentions(commentIDs []uuid.UUID) (map[uuid.UUID][]domain.CommentMention, error) {
+	mentions := make(map[uuid.UUID][]domain.CommentMention)
+	if len(commentIDs) == 0 {
+		return mentions, nil
+	}
+	var rows []domain.CommentMention
+	if err := r.db.Where("comment_id IN ?", commentIDs).Order("username").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		mentions[row.CommentID] = append(mentions[row.CommentID], row)
+	}
+	return mentions, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HistoryFilter struct {
+	Completed  *bool
+	InProgress bool // Started but not finished
+	AllMovies  bool // Also list movies that are not live and not the user's own, for editors
+}
+
+type HistoryRepository interface {
+	Find(userID, movieID uuid.UUID) (*domain.WatchHistory, error)
+	Save(entry *domain.WatchHistory) error
+	GetHistory(userID uuid.UUID, filter HistoryFilter, page, pageSize int) ([]*domain.WatchHistory, int64, error)
+	GetProgress(userID uuid.UUID, movieIDs []uuid.UUID) (map[uuid.UUID]*domain.WatchHistory, error)
+	Delete(userID, movieID uuid.UUID) error
+	Clear(userID uuid.UUID) (int64, error)
+	GetSettings(userID uuid.UUID) (*domain.HistorySettings, error)
+	SaveSettings(settings *domain.HistorySettings) error
+	Prune(userID *uuid.UUID) (int64, error)
+}
+
+type postgresHistoryRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresHistoryRepo(db *gorm.DB) HistoryRepository {
+	return &postgresHistoryRepo{db: db}
+}
+
+func (r *postgresHistoryRepo) Find(userID, movieID uuid.UUID) (*domain.WatchHistory, error) {
+	var entry domain.WatchHistory
+	err := r.db.Where("user_id = ? AND movie_id = ?", userID, movieID).First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("movie is not in your history")
+	}
+	return &entry, err
+}
+
+// Save records an entry, replacing the user's earlier entry for the movie,
+// and reads back the stored row so a replaced entry keeps its ID.
+func (r *postgresHistoryRepo) Save(entry *domain.WatchHistory) error {
+	return r.db.Omit("Movie").Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "movie_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"progress", "position_seconds", "completed", "times_completed", "completed_at", "started_at", "last_watched_at",
+		}),
+	}, clause.Returning{}).Create(entry).Error
+}
+
+// GetHistory lists a user's history, most recently watched first. Unless
+// filter.AllMovies is set, only movies the user may see are listed: live
+// ones and their own.
+func (r *postgresHistoryRepo) GetHistory(userID uuid.UUID, filter HistoryFilter, page, pageSize int) ([]*domain.WatchHistory, int64, error) {
+	var entries []*domain.WatchHistory
+	var totalCount int64
+
+	query := r.db.Model(&domain.WatchHistory{}).Where("user_id = ?", userID)
+	if !filter.AllMovies {
+		query = query.Where(`movie_id IN (SELECT id FROM movies
+			WHERE (status = ? AND (publish_at IS NULL OR publish_at <= NOW())) OR user_id = ?)`,
+			domain.MoviePublished, userID)
+	}
+	if filter.Completed != nil {
+		query = query.Where("completed = ?", *filter.Completed)
+	}
+	if filter.InProgress {
+		query = query.Where("completed = ? AND progress > 0", false)
+	}
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Preload("Movie").Order("last_watched_at DESC, id").Offset(offset).Limit(pageSize).Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, totalCount, nil
+}
+
+// GetProgress returns the user's entries for the given movies that have one.
+func (r *postgresHistoryRepo) GetProgress(userID uuid.UUID, movieIDs []uuid.UUID) (map[uuid.UUID]*domain.WatchHistory, error) {
+	progress := make(map[uuid.UUID]*domain.WatchHistory)
+	if len(movieIDs) == 0 {
+		return progress, nil
+	}
+	var entries []*domain.WatchHistory
+	if err := r.db.Where("user_id = ? AND movie_id IN ?", userID, movieIDs).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		progress[entry.MovieID] = entry
+	}
+	return progress, nil
+}
+
+func (r *postgresHistoryRepo) Delete(userID, movieID uuid.UUID) error {
+	result := r.db.Where("user_id = ? AND movie_id = ?", userID, movieID).Delete(&domain.WatchHistory{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("movie is not in your history")
+	}
+	return nil
+}
+
+// Clear deletes the user's whole history and reports how many entries there
+// were.
+func (r *postgresHistoryRepo) Clear(userID uuid.UUID) (int64, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&domain.WatchHistory{})
+	return result.RowsAffected, result.Error
+}
+
+// GetSettings returns the user's settings, or the defaults when they never
+// changed them.
+func (r *postgresHistoryRepo) GetSettings(userID uuid.UUID) (*domain.HistorySettings, error) {
+	var settings domain.HistorySettings
+	err := r.db.First(&settings, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &domain.HistorySettings{UserID: userID}, nil
+	}
+	return &settings, err
+}
+
+func (r *postgresHistoryRepo) SaveSettings(settings *domain.HistorySettings) error {
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(settings).Error
+}
+
+// Prune deletes the entries that outlived their user's retention period,
+// for one user or for everyone.
+func (r *postgresHistoryRepo) Prune(userID *uuid.UUID) (int64, error) {
+	query := r.db.Where(`user_id IN (SELECT user_id FROM history_settings WHERE retention_days > 0
+		AND watch_histories.last_watched_at < NOW() - retention_days * INTERVAL '1 day')`)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+	result := query.Delete(&domain.WatchHistory{})
+	return result.RowsAffected, result.Error
+}
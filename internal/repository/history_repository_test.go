@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"eskalate-movie-api/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSaveHistoryReturnsTheStoredEntry(t *testing.T) {
+	tx := testDB(t)
+	history := NewPostgresHistoryRepo(tx)
+	userID, movieID := uuid.New(), seedMovie(t, tx)
+
+	first := &domain.WatchHistory{UserID: userID, MovieID: movieID, Progress: 20, StartedAt: time.Now(), LastWatchedAt: time.Now()}
+	if err := history.Save(first); err != nil {
+		t.Fatal(err)
+	}
+	// A second entry for the same movie replaces the first one
+	second := &domain.WatchHistory{UserID: userID, MovieID: movieID, Progress: 40, StartedAt: time.Now(), LastWatchedAt: time.Now()}
+	if err := history.Save(second); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := history.Find(userID, movieID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID == uuid.Nil || second.ID != first.ID || stored.ID != first.ID {
+		t.Fatalf("got IDs %s and %s, stored %s", first.ID, second.ID, stored.ID)
+	}
+	if stored.Progress != 40 {
+		t.Fatalf("stored progress %v, want 40", stored.Progress)
+	}
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type MediaRepository interface {
+	Create(media *domain.MovieMedia) error
+	FindByID(id string) (*domain.MovieMedia, error)
+	Update(media *domain.MovieMedia) error
+	Move(media *domain.MovieMedia, position int) error
+	Delete(media *domain.MovieMedia) error
+	GetMovieMedia(movieID string, page, pageSize int, kind, language string) ([]*domain.MovieMedia, int64, error)
+	GetAllMovieMedia(movieID string) ([]*domain.MovieMedia, error)
+	GetImageReferences() ([]*domain.MovieMedia, error)
+}
+
+type postgresMediaRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresMediaRepo(db *gorm.DB) MediaRepository {
+	return &postgresMediaRepo{db: db}
+}
+
+// Create appends the image to the end of its kind. The first image of a kind
+// becomes primary.
+func (r *postgresMediaRepo) Create(media *domain.MovieMedia) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&domain.MovieMedia{}).
+			Where("movie_id = ? AND kind = ?", media.MovieID, media.Kind).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		media.Position = last + 1
+		if media.Position == 1 {
+			media.IsPrimary = true
+		}
+		if media.IsPrimary {
+			if err := clearPrimaryMedia(tx, media); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Movie").Create(media).Error
+	})
+}
+
+func (r *postgresMediaRepo) FindByID(id string) (*domain.MovieMedia, error) {
+	var media domain.MovieMedia
+	err := r.db.First(&media, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("media not found")
+	}
+	return &media, err
+}
+
+func (r *postgresMediaRepo) Update(media *domain.MovieMedia) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if media.IsPrimary {
+			if err := clearPrimaryMedia(tx, media); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Movie").Save(media).Error
+	})
+}
+
+// Move places the image at the given 1-based position within its kind.
+func (r *postgresMediaRepo) Move(media *domain.MovieMedia, position int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&domain.MovieMedia{}).
+			Where("movie_id = ? AND kind = ?", media.MovieID, media.Kind).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if position > int(count) {
+			position = int(count)
+		}
+
+		if position == media.Position {
+			return nil
+		}
+		err = shiftPositions(tx, &domain.MovieMedia{}, media.Position, position,
+			"movie_id = ? AND kind = ?", media.MovieID, media.Kind)
+		if err != nil {
+			return err
+		}
+
+		media.Position = position
+		return tx.Model(media).Update("position", position).Error
+	})
+}
+
+func (r *postgresMediaRepo) Delete(media *domain.MovieMedia) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.MovieMedia{}, "id = ?", media.ID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.MovieMedia{}).
+			Where("movie_id = ? AND kind = ? AND position > ?", media.MovieID, media.Kind, media.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+// GetMovieMedia lists a movie's gallery by kind and position. A language
+// filter keeps images without text, which suit every language.
+func (r *postgresMediaRepo) GetMovieMedia(movieID string, page, pageSize int, kind, language string) ([]*domain.MovieMedia, int64, error) {
+	var media []*domain.MovieMedia
+	var totalCount int64
+
+	query := r.db.Model(&domain.MovieMedia{}).Where("movie_id = ?", movieID)
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if language != "" {
+		query = query.Where("language = ? OR language = ''", language)
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("kind").Order("position").Offset(offset).Limit(pageSize).Find(&media).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return media, totalCount, nil
+}
+
+func (r *postgresMediaRepo) GetAllMovieMedia(movieID string) ([]*domain.MovieMedia, error) {
+	var media []*domain.MovieMedia
+	err := r.db.Where("movie_id = ?", movieID).Find(&media).Error
+	return media, err
+}
+
+// GetImageReferences loads only the stored image columns of every media item.
+func (r *postgresMediaRepo) GetImageReferences() ([]*domain.MovieMedia, error) {
+	var media []*domain.MovieMedia
+	err := r.db.Select("id", "url", "key", "variants").Find(&media).Error
+	return media, err
+}
+
+func clearPrimaryMedia(tx *gorm.DB, media *domain.MovieMedia) error {
+	return tx.Model(&domain.MovieMedia{}).
+		Where("movie_id = ? AND kind = ? AND id <> ?", media.MovieID, media.Kind, media.ID).
+		Update("is_primary", false).Error
+}
@@ -2,17 +2,78 @@ package repository
 
 import (
 	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/pkg/titles"
 
 	"errors"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// MovieFilter narrows down the movies returned by GetMovies. Zero values
+// disable the corresponding condition.
+type MovieFilter struct {
+	Title               string
+	YearFrom            int
+	YearTo              int
+	RuntimeMin          int
+	RuntimeMax          int
+	Language            string
+	Country             string
+	Certification       string
+	CertificationRegion string
+	MinRating           float64
+	MinRatingCount      int
+	Providers           []string // Slugs; movies on any of them match
+	ProviderCountry     string
+	OfferType           string
+	Sort                string
+	Status              string // Empty lists published movies that are live
+	OwnerID             string
+}
+
+// DuplicateMatch is an existing movie that may be the same film as a new one.
+type DuplicateMatch struct {
+	Movie      *domain.Movie
+	Similarity float64 // Trigram similarity of the normalized titles, from 0 to 1
+}
+
+// MergeResult counts what a merge moved over to the surviving movie.
+type MergeResult struct {
+	Reviews           int64
+	ListEntries       int64
+	CollectionEntries int64
+	Media             int64
+	Credits           int64
+	Comments          int64
+	Offers            int64
+	Screenings        int64
+	HistoryEntries    int64
+	Reports           int64
+}
+
 type MovieRepository interface {
 	Create(movie *domain.Movie) error
+	CreateMany(movies []*domain.Movie) error
 	FindByID(id string) (*domain.Movie, error)
+	FindBySlug(slug string) (*domain.Movie, error)
+	AssignSlug(movie *domain.Movie) error
+	AssignMissingSlugs() error
 	Update(movie *domain.Movie) error
-	GetMovies(page, pageSize int, title string) ([]*domain.Movie, int64, error)
+	ReplacePoster(movie *domain.Movie, oldPoster string) error
+	StartPosterUpload(movie *domain.Movie) error
+	ClaimPosterUpload(movie *domain.Movie) (bool, error)
+	SavePosterUpload(movie *domain.Movie, uploadKey string) error
+	FindPendingPosterUploads() ([]*domain.Movie, error)
+	GetPosterReferences() ([]*domain.Movie, error)
+	GetMovies(page, pageSize int, filter MovieFilter) ([]*domain.Movie, int64, error)
+	SetStatus(movie *domain.Movie, fromStatus string, change *domain.MovieStatusChange) error
+	GetStatusHistory(movieID uuid.UUID) ([]*domain.MovieStatusChange, error)
+	GetReviewQueue(page, pageSize int) ([]*domain.Movie, int64, error)
+	FindDuplicates(normalizedTitle string, year int, imdbID, tmdbID string, minSimilarity float64, limit int) ([]DuplicateMatch, error)
+	PrepareDuplicateDetection() error
+	Merge(survivor, duplicate *domain.Movie) (*MergeResult, error)
 	Delete(id string) error
 }
 
@@ -28,6 +89,12 @@ func (r *postgresMovieRepo) Create(movie *domain.Movie) error {
 	return r.db.Create(movie).Error
 }
 
+// CreateMany inserts the movies in a single statement, so either all or none
+// of them are created.
+func (r *postgresMovieRepo) CreateMany(movies []*domain.Movie) error {
+	return r.db.Create(&movies).Error
+}
+
 func (r *postgresMovieRepo) FindByID(id string) (*domain.Movie, error) {
 	var movie domain.Movie
 	err := r.db.First(&movie, "id = ?", id).Error
@@ -37,20 +104,288 @@ func (r *postgresMovieRepo) FindByID(id string) (*domain.Movie, error) {
 	return &movie, err
 }
 
+// FindBySlug finds a movie by its current or an earlier slug.
+func (r *postgresMovieRepo) FindBySlug(slug string) (*domain.Movie, error) {
+	var movie domain.Movie
+	err := r.db.Where("id = (SELECT movie_id FROM movie_slugs WHERE slug = ?)", slug).First(&movie).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("movie not found")
+	}
+	return &movie, err
+}
+
+// AssignSlug gives the movie a slug for its current title and release year,
+// unless its slug already matches them. A slug the movie had before is
+// reused; otherwise the first free one of base, base-2, base-3... is taken.
+func (r *postgresMovieRepo) AssignSlug(movie *domain.Movie) error {
+	year := 0
+	if movie.ReleaseDate != nil {
+		year = movie.ReleaseDate.Year()
+	}
+	base := titles.Slug(movie.Title, year)
+	if movie.Slug != "" && titles.HasSlugBase(movie.Slug, base) {
+		return nil
+	}
+
+	// Another movie may claim the same slug at the same time; try again with
+	// the next free one
+	for attempt := 0; attempt < 3; attempt++ {
+		var slug string
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var used []domain.MovieSlug
+			err := tx.Where("slug = ? OR slug LIKE ?", base, base+"-%").Find(&used).Error
+			if err != nil {
+				return err
+			}
+			taken := make([]string, 0, len(used))
+			for _, previous := range used {
+				if previous.MovieID == movie.ID && titles.HasSlugBase(previous.Slug, base) {
+					slug = previous.Slug
+					break
+				}
+				taken = append(taken, previous.Slug)
+			}
+
+			if slug == "" {
+				candidate := titles.FreeSlug(base, taken)
+				created := tx.Clauses(clause.OnConflict{DoNothing: true}).
+					Omit("Movie").
+					Create(&domain.MovieSlug{Slug: candidate, MovieID: movie.ID})
+				if created.Error != nil || created.RowsAffected == 0 {
+					return created.Error
+				}
+				slug = candidate
+			}
+			return tx.Model(&domain.Movie{}).Where("id = ?", movie.ID).Update("slug", slug).Error
+		})
+		if err != nil {
+			return err
+		}
+		if slug != "" {
+			movie.Slug = slug
+			return nil
+		}
+	}
+	return errors.New("failed to assign a slug")
+}
+
+// AssignMissingSlugs gives slugs to movies created before slugs existed.
+func (r *postgresMovieRepo) AssignMissingSlugs() error {
+	var movies []*domain.Movie
+	return r.db.Select("id", "title", "release_date", "slug").
+		Where("slug IS NULL OR slug = ''").
+		FindInBatches(&movies, 500, func(tx *gorm.DB, batch int) error {
+			for _, movie := range movies {
+				if err := r.AssignSlug(movie); err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// posterColumns are written only by the poster methods below.
+var posterColumns = []string{"Poster", "PosterKey", "PosterVariants", "PosterStatus", "PosterError", "PosterAttempts", "PosterUploadKey"}
+
+// statusColumns are written only by SetStatus.
+var statusColumns = []string{"Status", "PublishAt", "SubmittedAt"}
+
+// ratingColumns are written only by the review repository when it refreshes
+// a movie's rating.
+var ratingColumns = []string{"AverageRating", "RatingCount"}
+
+var guardedColumns = append(append(append([]string{}, posterColumns...), statusColumns...), ratingColumns...)
+
+// Update saves everything but the poster, status and rating. Only the poster
+// methods, SetStatus and the rating refresh write those, so that a concurrent
+// edit cannot bring back a replaced poster, undo a review decision or restore
+// a stale rating.
 func (r *postgresMovieRepo) Update(movie *domain.Movie) error {
-	return r.db.Save(movie).Error
+	return r.db.Omit(guardedColumns...).Save(movie).Error
+}
+
+// SetStatus moves the movie to its new status provided it is still in
+// fromStatus, and records the change.
+func (r *postgresMovieRepo) SetStatus(movie *domain.Movie, fromStatus string, change *domain.MovieStatusChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(movie).
+			Where("status = ?", fromStatus).
+			Select(statusColumns).
+			Updates(movie)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("movie status was changed by another request")
+		}
+		return tx.Omit("Movie").Create(change).Error
+	})
+}
+
+func (r *postgresMovieRepo) GetStatusHistory(movieID uuid.UUID) ([]*domain.MovieStatusChange, error) {
+	var changes []*domain.MovieStatusChange
+	err := r.db.Where("movie_id = ?", movieID).Order("created_at DESC").Find(&changes).Error
+	return changes, err
 }
 
-func (r *postgresMovieRepo) GetMovies(page, pageSize int, title string) ([]*domain.Movie, int64, error) {
+// GetReviewQueue lists movies waiting for review, longest waiting first.
+func (r *postgresMovieRepo) GetReviewQueue(page, pageSize int) ([]*domain.Movie, int64, error) {
+	var movies []*domain.Movie
+	var totalCount int64
+	query := r.db.Model(&domain.Movie{}).Where("status = ?", domain.MovieInReview)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+	err := query.Order("submitted_at ASC NULLS FIRST").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&movies).Error
+	return movies, totalCount, err
+}
+
+// GetPosterReferences loads only the poster columns of every movie.
+func (r *postgresMovieRepo) GetPosterReferences() ([]*domain.Movie, error) {
+	var movies []*domain.Movie
+	err := r.db.Select("id", "poster", "poster_key", "poster_variants", "poster_upload_key").Find(&movies).Error
+	return movies, err
+}
+
+// ReplacePoster swaps the poster columns in one statement, provided the
+// poster is still oldPoster. Of two concurrent replacements only one wins.
+func (r *postgresMovieRepo) ReplacePoster(movie *domain.Movie, oldPoster string) error {
+	result := r.db.Model(movie).
+		Where("poster = ?", oldPoster).
+		Select(posterColumns).
+		Updates(movie)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("poster was changed by another request")
+	}
+	return nil
+}
+
+// StartPosterUpload records a newly staged upload, superseding any upload
+// that is still pending.
+func (r *postgresMovieRepo) StartPosterUpload(movie *domain.Movie) error {
+	return r.db.Model(movie).
+		Select("PosterStatus", "PosterError", "PosterAttempts", "PosterUploadKey").
+		Updates(movie).Error
+}
+
+// ClaimPosterUpload moves the movie's pending upload to processing. It
+// reports false when the upload was superseded or another worker claimed it.
+func (r *postgresMovieRepo) ClaimPosterUpload(movie *domain.Movie) (bool, error) {
+	result := r.db.Model(&domain.Movie{}).
+		Where("id = ? AND poster_upload_key = ? AND poster_status = ?", movie.ID, movie.PosterUploadKey, domain.PosterPending).
+		Update("poster_status", domain.PosterProcessing)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	movie.PosterStatus = domain.PosterProcessing
+	return true, nil
+}
+
+// SavePosterUpload writes the poster columns provided uploadKey is still the
+// movie's current upload.
+func (r *postgresMovieRepo) SavePosterUpload(movie *domain.Movie, uploadKey string) error {
+	result := r.db.Model(movie).
+		Where("poster_upload_key = ?", uploadKey).
+		Select(posterColumns).
+		Updates(movie)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("poster upload was superseded")
+	}
+	return nil
+}
+
+func (r *postgresMovieRepo) FindPendingPosterUploads() ([]*domain.Movie, error) {
+	var movies []*domain.Movie
+	err := r.db.Where("poster_status IN ?", []string{domain.PosterPending, domain.PosterProcessing}).Find(&movies).Error
+	return movies, err
+}
+
+func (r *postgresMovieRepo) GetMovies(page, pageSize int, filter MovieFilter) ([]*domain.Movie, int64, error) {
 	var movies []*domain.Movie
 	var totalCount int64
 
 	// Initialize the query builder
 	query := r.db.Model(&domain.Movie{})
 
-	// Add title search condition if title is not empty
-	if title != "" {
-		query = query.Where("LOWER(title) LIKE LOWER(?)", "%"+title+"%")
+	// Add title search condition if title is not empty; translated titles
+	// match as well
+	if filter.Title != "" {
+		pattern := "%" + filter.Title + "%"
+		query = query.Where("(LOWER(title) LIKE LOWER(?) OR EXISTS (SELECT 1 FROM movie_translations t WHERE t.movie_id = movies.id AND LOWER(t.title) LIKE LOWER(?)))", pattern, pattern)
+	}
+
+	// Unless a status is asked for, only list what the public may see
+	if filter.Status == "" {
+		query = query.Where("status = ? AND (publish_at IS NULL OR publish_at <= NOW())", domain.MoviePublished)
+	} else {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.OwnerID != "" {
+		query = query.Where("user_id = ?", filter.OwnerID)
+	}
+
+	// Add metadata filters
+	if filter.YearFrom > 0 {
+		query = query.Where("EXTRACT(YEAR FROM release_date) >= ?", filter.YearFrom)
+	}
+	if filter.YearTo > 0 {
+		query = query.Where("EXTRACT(YEAR FROM release_date) <= ?", filter.YearTo)
+	}
+	if filter.RuntimeMin > 0 {
+		query = query.Where("runtime_minutes >= ?", filter.RuntimeMin)
+	}
+	if filter.RuntimeMax > 0 {
+		query = query.Where("runtime_minutes <= ?", filter.RuntimeMax)
+	}
+	if filter.Language != "" {
+		query = query.Where("original_language = LOWER(?)", filter.Language)
+	}
+	if filter.Country != "" {
+		query = query.Where("? = ANY(countries)", filter.Country)
+	}
+	if filter.Certification != "" {
+		if filter.CertificationRegion != "" {
+			query = query.Where("certifications ->> ? = ?", filter.CertificationRegion, filter.Certification)
+		} else {
+			query = query.Where("EXISTS (SELECT 1 FROM jsonb_each_text(certifications) WHERE value = ?)", filter.Certification)
+		}
+	}
+
+	if filter.MinRating > 0 {
+		query = query.Where("average_rating >= ?", filter.MinRating)
+	}
+	if filter.MinRatingCount > 0 {
+		query = query.Where("rating_count >= ?", filter.MinRatingCount)
+	}
+
+	// Add where-to-watch filters; expired offers do not count
+	if len(filter.Providers) > 0 || filter.ProviderCountry != "" || filter.OfferType != "" {
+		offers := r.db.Table("movie_availabilities a").
+			Select("1").
+			Joins("JOIN providers p ON p.id = a.provider_id").
+			Where("a.movie_id = movies.id AND (a.available_until IS NULL OR a.available_until > NOW())")
+		if len(filter.Providers) > 0 {
+			offers = offers.Where("p.slug IN ?", filter.Providers)
+		}
+		if filter.ProviderCountry != "" {
+			offers = offers.Where("a.country = ?", filter.ProviderCountry)
+		}
+		if filter.OfferType != "" {
+			offers = offers.Where("a.type = ?", filter.OfferType)
+		}
+		query = query.Where("EXISTS (?)", offers)
 	}
 
 	// Get total count with search condition
@@ -59,6 +394,19 @@ func (r *postgresMovieRepo) GetMovies(page, pageSize int, title string) ([]*doma
 	}
 
 	// Get paginated movies with search condition
+	switch filter.Sort {
+	case "title":
+		query = query.Order("title")
+	case "rating_desc":
+		query = query.Order("average_rating DESC, rating_count DESC")
+	case "rating_asc":
+		query = query.Order("average_rating ASC, rating_count DESC")
+	case "release_desc":
+		query = query.Order("release_date DESC NULLS LAST")
+	case "release_asc":
+		query = query.Order("release_date ASC NULLS LAST")
+	}
+
 	offset := (page - 1) * pageSize
 	err := query.Offset(offset).Limit(pageSize).Find(&movies).Error
 	if err != nil {
@@ -68,13 +416,363 @@ func (r *postgresMovieRepo) GetMovies(page, pageSize int, title string) ([]*doma
 	return movies, totalCount, nil
 }
 
+// Delete removes a movie with everything attached to it, unless its
+// screenings have active bookings.
 func (r *postgresMovieRepo) Delete(id string) error {
-	result := r.db.Delete(&domain.Movie{}, "id = ?", id)
-	if result.Error != nil {
-		return result.Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearScreeningBookings(tx, "movie_id = ?", id); err != nil {
+			return err
+		}
+		result := tx.Delete(&domain.Movie{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("movie not found")
+		}
+		return nil
+	})
+}
+
+// FindDuplicates looks for movies with the same external ID or a same or
+// similar normalized title. Title matches must be released within a year of
+// year when both years are known. The best title matches come first.
+func (r *postgresMovieRepo) FindDuplicates(normalizedTitle string, year int, imdbID, tmdbID string, minSimilarity float64, limit int) ([]DuplicateMatch, error) {
+	matches := r.db.Where("1 = 0")
+	if normalizedTitle != "" {
+		titleMatch := r.db.Where("normalized_title = ?", normalizedTitle).
+			Or("similarity(normalized_title, ?) >= ?", normalizedTitle, minSimilarity)
+		if year > 0 {
+			titleMatch = r.db.Where(titleMatch).
+				Where("(release_date IS NULL OR ABS(EXTRACT(YEAR FROM release_date) - ?) <= 1)", year)
+		}
+		matches = matches.Or(titleMatch)
 	}
-	if result.RowsAffected == 0 {
-		return errors.New("movie not found")
+	if imdbID != "" {
+		matches = matches.Or("imdb_id = ?", imdbID)
 	}
-	return nil
+	if tmdbID != "" {
+		matches = matches.Or("tmdb_id = ?", tmdbID)
+	}
+
+	var rows []struct {
+		ID         uuid.UUID
+		Similarity float64
+	}
+	err := r.db.Model(&domain.Movie{}).
+		Select("id, similarity(normalized_title, ?) AS similarity", normalizedTitle).
+		Where(matches).
+		Order("similarity DESC, id").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var movies []*domain.Movie
+	if err := r.db.Where("id IN ?", ids).Find(&movies).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*domain.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	duplicates := make([]DuplicateMatch, 0, len(rows))
+	for _, row := range rows {
+		if movie, ok := byID[row.ID]; ok {
+			duplicates = append(duplicates, DuplicateMatch{Movie: movie, Similarity: row.Similarity})
+		}
+	}
+	return duplicates, nil
+}
+
+// PrepareDuplicateDetection enables trigram matching of normalized titles and
+// normalizes the titles of movies created before they were stored.
+func (r *postgresMovieRepo) PrepareDuplicateDetection() error {
+	if err := r.db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return err
+	}
+	if err := r.db.Exec("CREATE INDEX IF NOT EXISTS idx_movies_normalized_title_trgm ON movies USING gin (normalized_title gin_trgm_ops)").Error; err != nil {
+		return err
+	}
+
+	var movies []*domain.Movie
+	return r.db.Select("id", "title").
+		Where("normalized_title IS NULL OR normalized_title = ''").
+		FindInBatches(&movies, 500, func(tx *gorm.DB, batch int) error {
+			for _, movie := range movies {
+				err := r.db.Model(movie).Update("normalized_title", titles.Normalize(movie.Title)).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// Merge folds duplicate into survivor and deletes it. Reviews, list and
+// collection entries, viewing history, media, comments, offers, screenings
+// with their bookings, translations, old slugs and reports move to the
+// survivor, as does the cast and crew it lacks. Where a user or collection
+// already has the survivor, the duplicate's entry is dropped, and positions
+// are closed up again; the same goes for an offer or translation the
+// survivor already has. Viewing history of both is combined per user.
+func (r *postgresMovieRepo) Merge(survivor, duplicate *domain.Movie) (*MergeResult, error) {
+	result := &MergeResult{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock both movies in a fixed order so concurrent merges cannot deadlock
+		err := tx.Exec("SELECT 1 FROM movies WHERE id IN ? ORDER BY id FOR UPDATE", []uuid.UUID{survivor.ID, duplicate.ID}).Error
+		if err != nil {
+			return err
+		}
+
+		// Reviews: a user who reviewed both keeps the review of the survivor
+		err = tx.Exec(`DELETE FROM reviews
+			WHERE movie_id = ? AND user_id IN (SELECT user_id FROM reviews WHERE movie_id = ?)`,
+			duplicate.ID, survivor.ID).Error
+		if err != nil {
+			return err
+		}
+		moved := tx.Model(&domain.Review{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Reviews = moved.RowsAffected
+		if err := refreshMovieRating(tx, survivor.ID); err != nil {
+			return err
+		}
+
+		// Personal lists: an entry that is dropped passes on its watched state
+		err = tx.Exec(`UPDATE saved_movies s SET watched = true, watched_at = d.watched_at
+			FROM saved_movies d
+			WHERE s.movie_id = ? AND d.movie_id = ? AND d.user_id = s.user_id AND d.list = s.list
+				AND d.watched AND NOT s.watched`,
+			survivor.ID, duplicate.ID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec(`DELETE FROM saved_movies d USING saved_movies s
+			WHERE d.movie_id = ? AND s.movie_id = ? AND s.user_id = d.user_id AND s.list = d.list`,
+			duplicate.ID, survivor.ID).Error
+		if err != nil {
+			return err
+		}
+		moved = tx.Model(&domain.SavedMovie{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.ListEntries = moved.RowsAffected
+		err = tx.Exec(`UPDATE saved_movies t SET position = r.rn
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, list ORDER BY position, created_at) AS rn
+				FROM saved_movies
+				WHERE (user_id, list) IN (SELECT user_id, list FROM saved_movies WHERE movie_id = ?)
+			) r
+			WHERE t.id = r.id AND t.position <> r.rn`,
+			survivor.ID).Error
+		if err != nil {
+			return err
+		}
+
+		// Viewing history: a user who watched both keeps one entry with the
+		// latest viewing and all completed runs
+		err = tx.Exec(`UPDATE watch_histories s SET
+				progress = CASE WHEN d.last_watched_at > s.last_watched_at THEN d.progress ELSE s.progress END,
+				position_seconds = CASE WHEN d.last_watched_at > s.last_watched_at THEN d.position_seconds ELSE s.position_seconds END,
+				completed = CASE WHEN d.last_watched_at > s.last_watched_at THEN d.completed ELSE s.completed END,
+				started_at = CASE WHEN d.last_watched_at > s.last_watched_at THEN d.started_at ELSE s.started_at END,
+				times_completed = s.times_completed + d.times_completed,
+				completed_at = GREATEST(s.completed_at, d.completed_at),
+				last_watched_at = GREATEST(s.last_watched_at, d.last_watched_at)
+			FROM watch_histories d
+			WHERE s.movie_id = ? AND d.movie_id = ? AND d.user_id = s.user_id`,
+			survivor.ID, duplicate.ID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec(`DELETE FROM watch_histories d USING watch_histories s
+			WHERE d.movie_id = ? AND s.movie_id = ? AND s.user_id = d.user_id`,
+			duplicate.ID, survivor.ID).Error
+		if err != nil {
+			return err
+		}
+		moved = tx.Model(&domain.WatchHistory{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.HistoryEntries = moved.RowsAffected
+
+		// Collections
+		err = tx.Exec(`DELETE FROM collection_entries d USING collection_entries s
+			WHERE d.movie_id = ? AND s.movie_id = ? AND s.collection_id = d.collection_id`,
+			duplicate.ID, survivor.ID).Error
+		if err != nil {
+			return err
+		}
+		moved = tx.Model(&domain.CollectionEntry{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.CollectionEntries = moved.RowsAffected
+		err = tx.Exec(`UPDATE collection_entries t SET position = r.rn
+			FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY collection_id ORDER BY position, created_at) AS rn
+				FROM collection_entries
+				WHERE collection_id IN (SELECT collection_id FROM collection_entries WHERE movie_id = ?)
+			) r
+			WHERE t.id = r.id AND t.position <> r.rn`,
+			survivor.ID).Error
+		if err != nil {
+			return err
+		}
+
+		// Media goes after the survivor's own images of the same kind, which
+		// stay primary
+		moved = tx.Exec(`UPDATE movie_media m SET
+				movie_id = ?,
+				position = m.position + (SELECT COALESCE(MAX(s.position), 0) FROM movie_media s WHERE s.movie_id = ? AND s.kind = m.kind),
+				is_primary = m.is_primary AND NOT EXISTS (SELECT 1 FROM movie_media s WHERE s.movie_id = ? AND s.kind = m.kind AND s.is_primary)
+			WHERE m.movie_id = ?`,
+			survivor.ID, survivor.ID, survivor.ID, duplicate.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Media = moved.RowsAffected
+
+		// Cast and crew the survivor lacks, after its own in each role
+		var credits []*domain.Credit
+		err = tx.Where(`movie_id = ? AND NOT EXISTS (SELECT 1 FROM credits s
+				WHERE s.movie_id = ? AND s.person_id = credits.person_id AND s.role = credits.role)`,
+			duplicate.ID, survivor.ID).
+			Order("credit_order").
+			Find(&credits).Error
+		if err != nil {
+			return err
+		}
+		if len(credits) > 0 {
+			creditIDs := make([]uuid.UUID, len(credits))
+			var actorIDs []uuid.UUID
+			for i, credit := range credits {
+				creditIDs[i] = credit.ID
+				if credit.Role == domain.CreditRoleActor {
+					actorIDs = append(actorIDs, credit.ID)
+				}
+			}
+			moved = tx.Exec(`UPDATE credits c SET
+					movie_id = ?,
+					credit_order = c.credit_order + (SELECT COALESCE(MAX(s.credit_order) + 1, 0) FROM credits s WHERE s.movie_id = ? AND s.role = c.role)
+				WHERE c.id IN ?`,
+				survivor.ID, survivor.ID, creditIDs)
+			if moved.Error != nil {
+				return moved.Error
+			}
+			result.Credits = moved.RowsAffected
+			// Keep the actor name list in step so later edits keep the credits
+			if len(actorIDs) > 0 {
+				err = tx.Exec(`UPDATE movies SET actors = actors || ARRAY(
+						SELECT p.name FROM credits c JOIN people p ON p.id = c.person_id
+						WHERE c.id IN ? ORDER BY c.credit_order)
+					WHERE id = ?`,
+					actorIDs, survivor.ID).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// Comment threads, replies and their mentions move as they are
+		moved = tx.Model(&domain.Comment{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Comments = moved.RowsAffected
+
+		// Where-to-watch offers: the survivor's own offer for the same provider,
+		// country, type and quality is kept
+		err = tx.Exec(`DELETE FROM movie_availabilities d USING movie_availabilities s
+			WHERE d.movie_id = ? AND s.movie_id = ? AND s.provider_id = d.provider_id
+				AND s.country = d.country AND s.type = d.type AND s.quality = d.quality`,
+			duplicate.ID, survivor.ID).Error
+		if err != nil {
+			return err
+		}
+		moved = tx.Model(&domain.MovieAvailability{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Offers = moved.RowsAffected
+
+		// Screenings keep their bookings and tickets
+		moved = tx.Model(&domain.Screening{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Screenings = moved.RowsAffected
+
+		// Translations the survivor lacks
+		err = tx.Exec(`UPDATE movie_translations SET movie_id = ?
+			WHERE movie_id = ? AND locale NOT IN (SELECT locale FROM movie_translations WHERE movie_id = ?)`,
+			survivor.ID, duplicate.ID, survivor.ID).Error
+		if err != nil {
+			return err
+		}
+
+		// Links to the duplicate lead to the survivor from now on
+		err = tx.Model(&domain.MovieSlug{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID).Error
+		if err != nil {
+			return err
+		}
+
+		// Reports keep the moderation history. A user who reported both movies
+		// keeps the report about the survivor; reports about reviews that were
+		// dropped above stay, like those about deleted reviews.
+		err = tx.Exec(`DELETE FROM reports
+			WHERE target_type = ? AND target_id = ? AND reporter_id IN (
+				SELECT reporter_id FROM reports WHERE target_type = ? AND target_id = ?)`,
+			domain.ReportTargetMovie, duplicate.ID, domain.ReportTargetMovie, survivor.ID).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&domain.Report{}).
+			Where("target_type = ? AND target_id = ?", domain.ReportTargetMovie, duplicate.ID).
+			Update("target_id", survivor.ID).Error
+		if err != nil {
+			return err
+		}
+		moved = tx.Model(&domain.Report{}).Where("movie_id = ?", duplicate.ID).Update("movie_id", survivor.ID)
+		if moved.Error != nil {
+			return moved.Error
+		}
+		result.Reports = moved.RowsAffected
+
+		// Keep external IDs the survivor lacks so later imports still match
+		if survivor.ImdbID == "" && duplicate.ImdbID != "" {
+			if err := tx.Model(survivor).Update("imdb_id", duplicate.ImdbID).Error; err != nil {
+				return err
+			}
+		}
+		if survivor.TmdbID == "" && duplicate.TmdbID != "" {
+			if err := tx.Model(survivor).Update("tmdb_id", duplicate.TmdbID).Error; err != nil {
+				return err
+			}
+		}
+
+		deleted := tx.Delete(&domain.Movie{}, "id = ?", duplicate.ID)
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		if deleted.RowsAffected == 0 {
+			return errors.New("movie not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
 }
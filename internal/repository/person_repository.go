@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonRepository interface {
+	Create(person *domain.Person) error
+	FindByID(id string) (*domain.Person, error)
+	Update(person *domain.Person) error
+	GetPeople(page, pageSize int, name string) ([]*domain.Person, int64, error)
+	AddCredit(credit *domain.Credit) error
+	FindCreditByID(id string) (*domain.Credit, error)
+	DeleteCredit(id string) error
+	GetMovieCredits(movieID string) ([]*domain.Credit, error)
+	GetFilmography(personID string) ([]*domain.Credit, error)
+	SyncActorCredits(movieID uuid.UUID, actors []string) error
+	ImportActorCredits() error
+}
+
+type postgresPersonRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresPersonRepo(db *gorm.DB) PersonRepository {
+	return &postgresPersonRepo{db: db}
+}
+
+func (r *postgresPersonRepo) Create(person *domain.Person) error {
+	return r.db.Create(person).Error
+}
+
+func (r *postgresPersonRepo) FindByID(id string) (*domain.Person, error) {
+	var person domain.Person
+	err := r.db.First(&person, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("person not found")
+	}
+	return &person, err
+}
+
+// Update saves a person. A new name is also written into the actor name list
+// of the movies they are credited on, so those credits keep matching it.
+func (r *postgresPersonRepo) Update(person *domain.Person) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous domain.Person
+		if err := tx.First(&previous, "id = ?", person.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(person).Error; err != nil {
+			return err
+		}
+		if previous.Name == person.Name {
+			return nil
+		}
+		return renameActor(tx, person.ID, previous.Name, person.Name)
+	})
+}
+
+func (r *postgresPersonRepo) GetPeople(page, pageSize int, name string) ([]*domain.Person, int64, error) {
+	var people []*domain.Person
+	var totalCount int64
+
+	query := r.db.Model(&domain.Person{})
+	if name != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+name+"%")
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("name").Offset(offset).Limit(pageSize).Find(&people).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return people, totalCount, nil
+}
+
+func (r *postgresPersonRepo) AddCredit(credit *domain.Credit) error {
+	return r.db.Create(credit).Error
+}
+
+func (r *postgresPersonRepo) FindCreditByID(id string) (*domain.Credit, error) {
+	var credit domain.Credit
+	err := r.db.Preload("Person").First(&credit, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("credit not found")
+	}
+	return &credit, err
+}
+
+func (r *postgresPersonRepo) DeleteCredit(id string) error {
+	result := r.db.Delete(&domain.Credit{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("credit not found")
+	}
+	return nil
+}
+
+func (r *postgresPersonRepo) GetMovieCredits(movieID string) ([]*domain.Credit, error) {
+	var credits []*domain.Credit
+	err := r.db.Preload("Person").
+		Where("movie_id = ?", movieID).
+		Order("role, credit_order").
+		Find(&credits).Error
+	return credits, err
+}
+
+func (r *postgresPersonRepo) GetFilmography(personID string) ([]*domain.Credit, error) {
+	var credits []*domain.Credit
+	err := r.db.Preload("Movie").
+		Where("person_id = ?", personID).
+		Find(&credits).Error
+	return credits, err
+}
+
+// SyncActorCredits reconciles the actor credits of a movie with its list of
+// actor names. Only the people already credited on the movie are matched by
+// name, so credits stay attached to a specific person; names nobody is
+// credited for yet are left to AddCredit, which links people by ID.
+func (r *postgresPersonRepo) SyncActorCredits(movieID uuid.UUID, actors []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		existing, err := findActorCredits(tx, movieID)
+		if err != nil {
+			return err
+		}
+
+		used := make(map[uuid.UUID]bool, len(existing))
+		for i, name := range actors {
+			for _, credit := range existing {
+				if used[credit.ID] || credit.Person == nil || credit.Person.Name != name {
+					continue
+				}
+				used[credit.ID] = true
+				if credit.Order != i {
+					if err := tx.Model(credit).Update("credit_order", i).Error; err != nil {
+						return err
+					}
+				}
+				break
+			}
+		}
+
+		for _, credit := range existing {
+			if used[credit.ID] {
+				continue
+			}
+			if err := tx.Delete(&domain.Credit{}, "id = ?", credit.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ImportActorCredits creates people and actor credits for movies that were
+// stored before people existed and only carry actor names. This one-off
+// migration is the only place people are looked up by name.
+func (r *postgresPersonRepo) ImportActorCredits() error {
+	var movies []*domain.Movie
+	err := r.db.Where("cardinality(actors) > 0").
+		Where("NOT EXISTS (SELECT 1 FROM credits WHERE credits.movie_id = movies.id AND credits.role = ?)", domain.CreditRoleActor).
+		Find(&movies).Error
+	if err != nil {
+		return err
+	}
+
+	for _, movie := range movies {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			for i, name := range movie.Actors {
+				person, err := findOrCreatePersonByName(tx, name)
+				if err != nil {
+					return err
+				}
+				credit := &domain.Credit{
+					ID:       uuid.New(),
+					MovieID:  movie.ID,
+					PersonID: person.ID,
+					Role:     domain.CreditRoleActor,
+					Order:    i,
+				}
+				if err := tx.Create(credit).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameActor replaces the old name once for every actor credit the person
+// has on a movie, leaving other actors who share the name alone.
+func renameActor(tx *gorm.DB, personID uuid.UUID, oldName, newName string) error {
+	var credits []*domain.Credit
+	err := tx.Preload("Movie").
+		Where("person_id = ? AND role = ?", personID, domain.CreditRoleActor).
+		Find(&credits).Error
+	if err != nil {
+		return err
+	}
+
+	renamed := make(map[uuid.UUID]*domain.Movie)
+	for _, credit := range credits {
+		movie := credit.Movie
+		if movie == nil {
+			continue
+		}
+		if previous, ok := renamed[movie.ID]; ok {
+			movie = previous
+		}
+		for i, name := range movie.Actors {
+			if name == oldName {
+				movie.Actors[i] = newName
+				renamed[movie.ID] = movie
+				break
+			}
+		}
+	}
+	for _, movie := range renamed {
+		if err := tx.Model(movie).Update("actors", movie.Actors).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findActorCredits(tx *gorm.DB, movieID uuid.UUID) ([]*domain.Credit, error) {
+	var credits []*domain.Credit
+	err := tx.Preload("Person").
+		Where("movie_id = ? AND role = ?", movieID, domain.CreditRoleActor).
+		Order("credit_order").
+		Find(&credits).Error
+	return credits, err
+}
+
+func findOrCreatePersonByName(tx *gorm.DB, name string) (*domain.Person, error) {
+	var person domain.Person
+	err := tx.Where("name = ?", name).Order("created_at").First(&person).Error
+	if err == nil {
+		return &person, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	person = domain.Person{ID: uuid.New(), Name: name}
+	if err := tx.Create(&person).Error; err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
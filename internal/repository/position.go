@@ -0,0 +1,19 @@
+package repository
+
+import "gorm.io/gorm"
+
+// shiftPositions makes room for moving an ordered entry from one 1-based
+// position to another by shifting the entries in between, which are selected
+// from model with the given condition.
+func shiftPositions(tx *gorm.DB, model interface{}, from, to int, query string, args ...interface{}) error {
+	scope := tx.Model(model).Where(query, args...)
+	switch {
+	case to < from:
+		return scope.Where("position >= ? AND position < ?", to, from).
+			Update("position", gorm.Expr("position + 1")).Error
+	case to > from:
+		return scope.Where("position > ? AND position <= ?", from, to).
+			Update("position", gorm.Expr("position - 1")).Error
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AvailabilityFilter narrows a movie's offers. Empty fields match anything.
+type AvailabilityFilter struct {
+	Country string
+	Type    string
+}
+
+type ProviderRepository interface {
+	CreateProvider(provider *domain.Provider) error
+	UpdateProvider(provider *domain.Provider) error
+	DeleteProvider(id string) error
+	FindProviderByID(id string) (*domain.Provider, error)
+	FindProviderBySlug(slug string) (*domain.Provider, error)
+	GetProviders() ([]*domain.Provider, error)
+	CreateAvailability(availability *domain.MovieAvailability) error
+	UpdateAvailability(availability *domain.MovieAvailability) error
+	DeleteAvailability(id string) error
+	FindAvailabilityByID(id string) (*domain.MovieAvailability, error)
+	GetMovieAvailability(movieID uuid.UUID, filter AvailabilityFilter) ([]*domain.MovieAvailability, error)
+	SyncFeed(providers []*domain.Provider, availabilities map[string][]*domain.MovieAvailability) (int64, error)
+	ResolveMovies(ids, imdbIDs, tmdbIDs []string) (map[string]uuid.UUID, error)
+}
+
+type postgresProviderRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresProviderRepo(db *gorm.DB) ProviderRepository {
+	return &postgresProviderRepo{db: db}
+}
+
+func (r *postgresProviderRepo) CreateProvider(provider *domain.Provider) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(provider)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("provider slug already exists")
+	}
+	return nil
+}
+
+func (r *postgresProviderRepo) UpdateProvider(provider *domain.Provider) error {
+	return r.db.Model(provider).
+		Select("slug", "name", "logo_url", "homepage_url", "updated_at").
+		Updates(provider).Error
+}
+
+// DeleteProvider removes the provider with all its offers.
+func (r *postgresProviderRepo) DeleteProvider(id string) error {
+	result := r.db.Delete(&domain.Provider{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("provider not found")
+	}
+	return nil
+}
+
+func (r *postgresProviderRepo) FindProviderByID(id string) (*domain.Provider, error) {
+	var provider domain.Provider
+	if err := r.db.First(&provider, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("provider not found")
+		}
+		return nil, err
+	}
+	return &provider, nil
+}
+
+func (r *postgresProviderRepo) FindProviderBySlug(slug string) (*domain.Provider, error) {
+	var provider domain.Provider
+	if err := r.db.First(&provider, "slug = ?", slug).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("provider not found")
+		}
+		return nil, err
+	}
+	return &provider, nil
+}
+
+func (r *postgresProviderRepo) GetProviders() ([]*domain.Provider, error) {
+	var providers []*domain.Provider
+	err := r.db.Order("name").Find(&providers).Error
+	return providers, err
+}
+
+// CreateAvailability adds an offer unless the movie already has one from the
+// same provider in the same country, type and quality.
+func (r *postgresProviderRepo) CreateAvailability(availability *domain.MovieAvailability) error {
+	result := r.db.Omit("Movie", "Provider").Clauses(clause.OnConflict{DoNothing: true}).Create(availability)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("availability already exists")
+	}
+	return nil
+}
+
+func (r *postgresProviderRepo) UpdateAvailability(availability *domain.MovieAvailability) error {
+	return r.db.Model(availability).
+		Select("price", "currency", "link", "available_until", "source", "updated_by", "updated_at").
+		Updates(availability).Error
+}
+
+func (r *postgresProviderRepo) DeleteAvailability(id string) error {
+	result := r.db.Delete(&domain.MovieAvailability{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("availability not found")
+	}
+	return nil
+}
+
+func (r *postgresProviderRepo) FindAvailabilityByID(id string) (*domain.MovieAvailability, error) {
+	var availability domain.MovieAvailability
+	if err := r.db.Preload("Provider").First(&availability, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("availability not found")
+		}
+		return nil, err
+	}
+	return &availability, nil
+}
+
+// GetMovieAvailability lists the offers of a movie that have not expired,
+// by country, type, provider and price.
+func (r *postgresProviderRepo) GetMovieAvailability(movieID uuid.UUID, filter AvailabilityFilter) ([]*domain.MovieAvailability, error) {
+	query := r.db.Joins("Provider").
+		Where("movie_availabilities.movie_id = ?", movieID).
+		Where("movie_availabilities.available_until IS NULL OR movie_availabilities.available_until > ?", time.Now())
+	if filter.Country != "" {
+		query = query.Where("movie_availabilities.country = ?", filter.Country)
+	}
+	if filter.Type != "" {
+		query = query.Where("movie_availabilities.type = ?", filter.Type)
+	}
+
+	var availabilities []*domain.MovieAvailability
+	err := query.
+		Order("movie_availabilities.country, movie_availabilities.type, \"Provider\".name, movie_availabilities.price NULLS FIRST").
+		Find(&availabilities).Error
+	return availabilities, err
+}
+
+// SyncFeed creates or updates the feed's providers by slug and replaces the
+// offers earlier feeds added for them with the given ones, keyed by provider
+// slug. Offers entered by editors are kept unless the feed has the same
+// offer. It returns how many offers were saved.
+func (r *postgresProviderRepo) SyncFeed(providers []*domain.Provider, availabilities map[string][]*domain.MovieAvailability) (int64, error) {
+	var saved int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		providerIDs := make(map[string]uuid.UUID, len(providers))
+		for _, provider := range providers {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "slug"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "logo_url", "homepage_url", "updated_at"}),
+			}).Create(provider).Error
+			if err != nil {
+				return err
+			}
+			// The conflicting row keeps its ID, so look it up
+			var stored domain.Provider
+			if err := tx.Select("id").First(&stored, "slug = ?", provider.Slug).Error; err != nil {
+				return err
+			}
+			providerIDs[provider.Slug] = stored.ID
+		}
+
+		ids := make([]uuid.UUID, 0, len(providerIDs))
+		for _, id := range providerIDs {
+			ids = append(ids, id)
+		}
+		if len(ids) > 0 {
+			err := tx.Where("provider_id IN ? AND source = ?", ids, domain.AvailabilityFeed).
+				Delete(&domain.MovieAvailability{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var rows []*domain.MovieAvailability
+		for slug, offers := range availabilities {
+			id, ok := providerIDs[slug]
+			if !ok {
+				return errors.New("unknown provider " + slug)
+			}
+			for _, availability := range offers {
+				availability.ProviderID = id
+				rows = append(rows, availability)
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		result := tx.Omit("Movie", "Provider").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "movie_id"}, {Name: "provider_id"}, {Name: "country"}, {Name: "type"}, {Name: "quality"}},
+				DoUpdates: clause.AssignmentColumns([]string{"price", "currency", "link", "available_until", "source", "updated_by", "updated_at"}),
+			}).
+			CreateInBatches(rows, 1000)
+		saved = result.RowsAffected
+		return result.Error
+	})
+	return saved, err
+}
+
+// ResolveMovies finds the movies with the given IDs, IMDb IDs and TMDb IDs
+// and returns them keyed by the ID, IMDb ID or TMDb ID they were found by.
+func (r *postgresProviderRepo) ResolveMovies(ids, imdbIDs, tmdbIDs []string) (map[string]uuid.UUID, error) {
+	resolved := make(map[string]uuid.UUID)
+	if len(ids) == 0 && len(imdbIDs) == 0 && len(tmdbIDs) == 0 {
+		return resolved, nil
+	}
+
+	var movies []struct {
+		ID     uuid.UUID
+		ImdbID string
+		TmdbID string
+	}
+	query := r.db.Model(&domain.Movie{}).Select("id, imdb_id, tmdb_id").Where("1 = 0")
+	if len(ids) > 0 {
+		query = query.Or("id IN ?", ids)
+	}
+	if len(imdbIDs) > 0 {
+		query = query.Or("imdb_id IN ?", imdbIDs)
+	}
+	if len(tmdbIDs) > 0 {
+		query = query.Or("tmdb_id IN ?", tmdbIDs)
+	}
+	if err := query.Scan(&movies).Error; err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		resolved[movie.ID.String()] = movie.ID
+		if movie.ImdbID != "" {
+			resolved[movie.ImdbID] = movie.ID
+		}
+		if movie.TmdbID != "" {
+			resolved[movie.TmdbID] = movie.ID
+		}
+	}
+	return resolved, nil
+}
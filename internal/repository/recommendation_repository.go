@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/pkg/recommend"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RecommendationRepository interface {
+	LoadItems() ([]recommend.Item, error)
+	LoadRatings() ([]recommend.Rating, error)
+	ReplaceSimilarities(similarities []domain.MovieSimilarity) error
+	GetSimilar(movieID uuid.UUID, limit int) ([]*domain.MovieSimilarity, error)
+	GetSimilarToAny(movieIDs []uuid.UUID) ([]*domain.MovieSimilarity, error)
+	GetUserReviews(userID string) ([]*domain.Review, error)
+	GetUserSavedMovies(userID string) ([]*domain.SavedMovie, error)
+}
+
+type postgresRecommendationRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresRecommendationRepo(db *gorm.DB) RecommendationRepository {
+	return &postgresRecommendationRepo{db: db}
+}
+
+// LoadItems loads the genres, people and texts of live movies. Movies
+// scheduled to go live are left out until the next refresh after that.
+func (r *postgresRecommendationRepo) LoadItems() ([]recommend.Item, error) {
+	var movies []struct {
+		ID          uuid.UUID
+		Genres      []string `gorm:"type:text[]"`
+		Description string
+		Tagline     string
+	}
+	err := r.db.Model(&domain.Movie{}).
+		Select("id, genres, description, tagline").
+		Where("status = ? AND (publish_at IS NULL OR publish_at <= NOW())", domain.MoviePublished).
+		Scan(&movies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var credits []struct {
+		MovieID  uuid.UUID
+		PersonID uuid.UUID
+		Name     string
+	}
+	err = r.db.Table("credits").
+		Select("credits.movie_id, credits.person_id, people.name").
+		Joins("JOIN people ON people.id = credits.person_id").
+		Joins("JOIN movies ON movies.id = credits.movie_id").
+		Where("movies.status = ? AND (movies.publish_at IS NULL OR movies.publish_at <= NOW())", domain.MoviePublished).
+		Scan(&credits).Error
+	if err != nil {
+		return nil, err
+	}
+	people := make(map[uuid.UUID][]recommend.Person)
+	for _, credit := range credits {
+		people[credit.MovieID] = append(people[credit.MovieID], recommend.Person{ID: credit.PersonID.String(), Name: credit.Name})
+	}
+
+	items := make([]recommend.Item, len(movies))
+	for i, movie := range movies {
+		items[i] = recommend.Item{
+			ID:     movie.ID.String(),
+			Genres: movie.Genres,
+			People: people[movie.ID],
+			Text:   movie.Description + "\n" + movie.Tagline,
+		}
+	}
+	return items, nil
+}
+
+func (r *postgresRecommendationRepo) LoadRatings() ([]recommend.Rating, error) {
+	var reviews []struct {
+		UserID  uuid.UUID
+		MovieID uuid.UUID
+		Rating  int
+	}
+	if err := r.db.Model(&domain.Review{}).Select("user_id, movie_id, rating").Scan(&reviews).Error; err != nil {
+		return nil, err
+	}
+	ratings := make([]recommend.Rating, len(reviews))
+	for i, review := range reviews {
+		ratings[i] = recommend.Rating{UserID: review.UserID.String(), ItemID: review.MovieID.String(), Value: float64(review.Rating)}
+	}
+	return ratings, nil
+}
+
+// ReplaceSimilarities swaps in a freshly computed set of similarities, so
+// readers see either the old set or the new one.
+func (r *postgresRecommendationRepo) ReplaceSimilarities(similarities []domain.MovieSimilarity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM movie_similarities").Error; err != nil {
+			return err
+		}
+		if len(similarities) == 0 {
+			return nil
+		}
+		return tx.Omit("Movie", "Similar").CreateInBatches(similarities, 1000).Error
+	})
+}
+
+// GetSimilar lists the live movies most similar to a movie.
+func (r *postgresRecommendationRepo) GetSimilar(movieID uuid.UUID, limit int) ([]*domain.MovieSimilarity, error) {
+	var similarities []*domain.MovieSimilarity
+	err := r.liveSimilar().
+		Where("movie_similarities.movie_id = ?", movieID).
+		Order("movie_similarities.score DESC, movie_similarities.similar_id").
+		Limit(limit).
+		Find(&similarities).Error
+	return similarities, err
+}
+
+// GetSimilarToAny lists the live movies similar to any of the given movies,
+// with both movies of each pair loaded.
+func (r *postgresRecommendationRepo) GetSimilarToAny(movieIDs []uuid.UUID) ([]*domain.MovieSimilarity, error) {
+	var similarities []*domain.MovieSimilarity
+	if len(movieIDs) == 0 {
+		return similarities, nil
+	}
+	err := r.liveSimilar().
+		Preload("Movie").
+		Where("movie_similarities.movie_id IN ?", movieIDs).
+		Find(&similarities).Error
+	return similarities, err
+}
+
+func (r *postgresRecommendationRepo) liveSimilar() *gorm.DB {
+	return r.db.Model(&domain.MovieSimilarity{}).
+		Joins("Similar").
+		Where(`"Similar".status = ? AND ("Similar".publish_at IS NULL OR "Similar".publish_at <= NOW())`, domain.MoviePublished)
+}
+
+func (r *postgresRecommendationRepo) GetUserReviews(userID string) ([]*domain.Review, error) {
+	var reviews []*domain.Review
+	err := r.db.Where("user_id = ?", userID).Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *postgresRecommendationRepo) GetUserSavedMovies(userID string) ([]*domain.SavedMovie, error) {
+	var items []*domain.SavedMovie
+	err := r.db.Where("user_id = ?", userID).Find(&items).Error
+	return items, err
+}
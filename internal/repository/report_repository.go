@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReportFilter struct {
+	Status     string
+	TargetType string
+}
+
+type ReportRepository interface {
+	Create(report *domain.Report) error
+	FindByID(id string) (*domain.Report, error)
+	FindOpenFiltered(targetType string, targetID uuid.UUID) (*domain.Report, error)
+	SetDetails(report *domain.Report) error
+	GetReports(filter ReportFilter, page, pageSize int) ([]*domain.Report, int64, error)
+	CountOpen(targetIDs []uuid.UUID) (map[uuid.UUID]int64, error)
+	Close(targetType string, targetID uuid.UUID, status string, moderatorID uuid.UUID, note string) (int64, error)
+}
+
+type postgresReportRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresReportRepo(db *gorm.DB) ReportRepository {
+	return &postgresReportRepo{db: db}
+}
+
+// Create files a report. A user can report the same content only once.
+func (r *postgresReportRepo) Create(report *domain.Report) error {
+	result := r.db.Omit("Movie").Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("you have already reported this content")
+	}
+	return nil
+}
+
+func (r *postgresReportRepo) FindByID(id string) (*domain.Report, error) {
+	var report domain.Report
+	err := r.db.First(&report, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("report not found")
+	}
+	return &report, err
+}
+
+// FindOpenFiltered finds the open report the word filter filed for the
+// content, if any.
+func (r *postgresReportRepo) FindOpenFiltered(targetType string, targetID uuid.UUID) (*domain.Report, error) {
+	var report domain.Report
+	err := r.db.Where("target_type = ? AND target_id = ? AND reason = ? AND status = ?",
+		targetType, targetID, domain.ReasonFiltered, domain.ReportOpen).
+		First(&report).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("report not found")
+	}
+	return &report, err
+}
+
+func (r *postgresReportRepo) SetDetails(report *domain.Report) error {
+	return r.db.Model(report).Update("details", report.Details).Error
+}
+
+// GetReports lists reports, oldest first so the queue is worked in order.
+func (r *postgresReportRepo) GetReports(filter ReportFilter, page, pageSize int) ([]*domain.Report, int64, error) {
+	var reports []*domain.Report
+	var totalCount int64
+
+	query := r.db.Model(&domain.Report{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at ASC, id").Offset(offset).Limit(pageSize).Find(&reports).Error; err != nil {
+		return nil, 0, err
+	}
+	return reports, totalCount, nil
+}
+
+// CountOpen counts the open reports about each of the given targets.
+func (r *postgresReportRepo) CountOpen(targetIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64)
+	if len(targetIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		TargetID uuid.UUID
+		Count    int64
+	}
+	err := r.db.Model(&domain.Report{}).
+		Select("target_id, COUNT(*) AS count").
+		Where("target_id IN ? AND status = ?", targetIDs, domain.ReportOpen).
+		Group("target_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.TargetID] = row.Count
+	}
+	return counts, nil
+}
+
+// Close settles all open reports about a piece of content at once and
+// reports how many there were.
+func (r *postgresReportRepo) Close(targetType string, targetID uuid.UUID, status string, moderatorID uuid.UUID, note string) (int64, error) {
+	result := r.db.Model(&domain.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, domain.ReportOpen).
+		Updates(map[string]any{
+			"status":          status,
+			"resolved_by":     moderatorID,
+			"resolution_note": note,
+			"resolved_at":     time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
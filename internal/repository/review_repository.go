@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReviewRepository interface {
+	Create(review *domain.Review) error
+	FindByID(id string) (*domain.Review, error)
+	FindByMovieAndUser(movieID, userID string) (*domain.Review, error)
+	Update(review *domain.Review) error
+	Delete(review *domain.Review) error
+	GetMovieReviews(movieID string, page, pageSize int, sort string) ([]*domain.Review, int64, error)
+}
+
+type postgresReviewRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresReviewRepo(db *gorm.DB) ReviewRepository {
+	return &postgresReviewRepo{db: db}
+}
+
+func (r *postgresReviewRepo) Create(review *domain.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return refreshMovieRating(tx, review.MovieID)
+	})
+}
+
+func (r *postgresReviewRepo) FindByID(id string) (*domain.Review, error) {
+	var review domain.Review
+	err := r.db.First(&review, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("review not found")
+	}
+	return &review, err
+}
+
+func (r *postgresReviewRepo) FindByMovieAndUser(movieID, userID string) (*domain.Review, error) {
+	var review domain.Review
+	err := r.db.Where("movie_id = ? AND user_id = ?", movieID, userID).First(&review).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("review not found")
+	}
+	return &review, err
+}
+
+func (r *postgresReviewRepo) Update(review *domain.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return refreshMovieRating(tx, review.MovieID)
+	})
+}
+
+func (r *postgresReviewRepo) Delete(review *domain.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&domain.Review{}, "id = ?", review.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("review not found")
+		}
+		return refreshMovieRating(tx, review.MovieID)
+	})
+}
+
+func (r *postgresReviewRepo) GetMovieReviews(movieID string, page, pageSize int, sort string) ([]*domain.Review, int64, error) {
+	var reviews []*domain.Review
+	var totalCount int64
+
+	query := r.db.Model(&domain.Review{}).Where("movie_id = ?", movieID)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "created_at DESC"
+	switch sort {
+	case "oldest":
+		order = "created_at ASC"
+	case "rating_desc":
+		order = "rating DESC, created_at DESC"
+	case "rating_asc":
+		order = "rating ASC, created_at DESC"
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order(order).Offset(offset).Limit(pageSize).Find(&reviews).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, totalCount, nil
+}
+
+// refreshMovieRating recomputes the denormalized rating aggregate of a movie
+// so listing and sorting by rating never has to touch the reviews table.
+func refreshMovieRating(tx *gorm.DB, movieID uuid.UUID) error {
+	// Lock the movie row first so concurrent reviews are aggregated in turn
+	if err := tx.Exec("SELECT 1 FROM movies WHERE id = ? FOR UPDATE", movieID).Error; err != nil {
+		return err
+	}
+	return tx.Exec(`
+		UPDATE movies SET
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE movie_id = ?),
+			average_rating = COALESCE((SELECT AVG(rating) FROM reviews WHERE movie_id = ?), 0)
+		WHERE id = ?`, movieID, movieID, movieID).Error
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SavedMovieRepository interface {
+	Add(item *domain.SavedMovie) error
+	Find(userID, movieID, list string) (*domain.SavedMovie, error)
+	Update(item *domain.SavedMovie) error
+	MarkWatched(userID, movieID uuid.UUID, watchedAt time.Time) error
+	Move(item *domain.SavedMovie, position int) error
+	Remove(item *domain.SavedMovie) error
+	GetList(userID, list string, page, pageSize int, watched *bool) ([]*domain.SavedMovie, int64, error)
+	FindSavedLists(userID string, movieIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+}
+
+type postgresSavedMovieRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresSavedMovieRepo(db *gorm.DB) SavedMovieRepository {
+	return &postgresSavedMovieRepo{db: db}
+}
+
+// Add appends the item to the end of the user's list.
+func (r *postgresSavedMovieRepo) Add(item *domain.SavedMovie) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		err := tx.Model(&domain.SavedMovie{}).
+			Where("user_id = ? AND list = ?", item.UserID, item.List).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+		item.Position = last + 1
+		return tx.Create(item).Error
+	})
+}
+
+func (r *postgresSavedMovieRepo) Find(userID, movieID, list string) (*domain.SavedMovie, error) {
+	var item domain.SavedMovie
+	err := r.db.Preload("Movie").
+		Where("user_id = ? AND movie_id = ? AND list = ?", userID, movieID, list).
+		First(&item).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("movie is not in this list")
+	}
+	return &item, err
+}
+
+func (r *postgresSavedMovieRepo) Update(item *domain.SavedMovie) error {
+	return r.db.Omit("Movie").Save(item).Error
+}
+
+// MarkWatched ticks the movie off the user's watchlist if it is on it and
+// not ticked off yet.
+func (r *postgresSavedMovieRepo) MarkWatched(userID, movieID uuid.UUID, watchedAt time.Time) error {
+	return r.db.Model(&domain.SavedMovie{}).
+		Where("user_id = ? AND movie_id = ? AND list = ? AND watched = ?", userID, movieID, domain.ListWatchlist, false).
+		Updates(map[string]any{"watched": true, "watched_at": watchedAt}).Error
+}
+
+// Move places the item at the given 1-based position and shifts the entries
+// in between so positions stay contiguous.
+func (r *postgresSavedMovieRepo) Move(item *domain.SavedMovie, position int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&domain.SavedMovie{}).
+			Where("user_id = ? AND list = ?", item.UserID, item.List).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if position > int(count) {
+			position = int(count)
+		}
+
+		if position == item.Position {
+			return nil
+		}
+		err = shiftPositions(tx, &domain.SavedMovie{}, item.Position, position,
+			"user_id = ? AND list = ?", item.UserID, item.List)
+		if err != nil {
+			return err
+		}
+
+		item.Position = position
+		return tx.Model(item).Update("position", position).Error
+	})
+}
+
+func (r *postgresSavedMovieRepo) Remove(item *domain.SavedMovie) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&domain.SavedMovie{}, "id = ?", item.ID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.SavedMovie{}).
+			Where("user_id = ? AND list = ? AND position > ?", item.UserID, item.List, item.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+func (r *postgresSavedMovieRepo) GetList(userID, list string, page, pageSize int, watched *bool) ([]*domain.SavedMovie, int64, error) {
+	var items []*domain.SavedMovie
+	var totalCount int64
+
+	query := r.db.Model(&domain.SavedMovie{}).Where("user_id = ? AND list = ?", userID, list)
+	if watched != nil {
+		query = query.Where("watched = ?", *watched)
+	}
+
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Preload("Movie").Order("position").Offset(offset).Limit(pageSize).Find(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, totalCount, nil
+}
+
+// FindSavedLists reports, for each of the given movies, which of the user's
+// lists contain it.
+func (r *postgresSavedMovieRepo) FindSavedLists(userID string, movieIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	saved := make(map[uuid.UUID][]string)
+	if len(movieIDs) == 0 {
+		return saved, nil
+	}
+
+	var items []*domain.SavedMovie
+	err := r.db.Select("movie_id", "list").
+		Where("user_id = ? AND movie_id IN ?", userID, movieIDs).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		saved[item.MovieID] = append(saved[item.MovieID], item.List)
+	}
+	return saved, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TranslationRepository interface {
+	Save(translation *domain.MovieTranslation) error
+	Delete(movieID uuid.UUID, locale string) error
+	GetMovieTranslations(movieID uuid.UUID) ([]*domain.MovieTranslation, error)
+	GetTranslations(movieIDs []uuid.UUID) (map[uuid.UUID][]*domain.MovieTranslation, error)
+}
+
+type postgresTranslationRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresTranslationRepo(db *gorm.DB) TranslationRepository {
+	return &postgresTranslationRepo{db: db}
+}
+
+// Save creates the translation or replaces the texts of an existing one for
+// the same movie and locale.
+func (r *postgresTranslationRepo) Save(translation *domain.MovieTranslation) error {
+	return r.db.Omit("Movie").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "movie_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"title", "description", "tagline", "updated_at"}),
+		}).
+		Create(translation).Error
+}
+
+func (r *postgresTranslationRepo) Delete(movieID uuid.UUID, locale string) error {
+	result := r.db.Delete(&domain.MovieTranslation{}, "movie_id = ? AND locale = ?", movieID, locale)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("translation not found")
+	}
+	return nil
+}
+
+func (r *postgresTranslationRepo) GetMovieTranslations(movieID uuid.UUID) ([]*domain.MovieTranslation, error) {
+	var translations []*domain.MovieTranslation
+	err := r.db.Where("movie_id = ?", movieID).Order("locale").Find(&translations).Error
+	return translations, err
+}
+
+// GetTranslations loads the translations of several movies at once, keyed by
+// movie.
+func (r *postgresTranslationRepo) GetTranslations(movieIDs []uuid.UUID) (map[uuid.UUID][]*domain.MovieTranslation, error) {
+	translations := make(map[uuid.UUID][]*domain.MovieTranslation)
+	if len(movieIDs) == 0 {
+		return translations, nil
+	}
+	var rows []*domain.MovieTranslation
+	if err := r.db.Where("movie_id IN ?", movieIDs).Order("locale").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		translations[row.MovieID] = append(translations[row.MovieID], row)
+	}
+	return translations, nil
+}
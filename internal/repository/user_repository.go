@@ -11,6 +11,7 @@ type UserRepository interface {
 	Create(user *domain.User) error
 	FindByEmail(email string) (*domain.User, error)
 	FindByUsername(username string) (*domain.User, error)
+	SetRole(email, role string) error
 }
 
 type postgresUserRepo struct {
@@ -42,3 +43,14 @@ func (r *postgresUserRepo) FindByUsername(username string) (*domain.User, error)
 	}
 	return &user, err
 }
+
+func (r *postgresUserRepo) SetRole(email, role string) error {
+	result := r.db.Model(&domain.User{}).Where("email = ?", email).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
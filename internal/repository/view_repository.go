@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"eskalate-movie-api/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Movie rankings.
+const (
+	RankTrending = "trending"
+	RankPopular  = "popular"
+)
+
+type ViewRepository interface {
+	AddViews(day time.Time, counts map[uuid.UUID]int64) error
+	RefreshScores(windowDays int, halfLifeDays float64) error
+	GetRanking(ranking string, page, pageSize int) ([]*domain.Movie, map[uuid.UUID]domain.MovieScore, int64, error)
+}
+
+type postgresViewRepo struct {
+	db *gorm.DB
+}
+
+func NewPostgresViewRepo(db *gorm.DB) ViewRepository {
+	return &postgresViewRepo{db: db}
+}
+
+// AddViews adds a batch of view counts to the day's totals in one statement.
+// Views of movies deleted in the meantime are dropped.
+func (r *postgresViewRepo) AddViews(day time.Time, counts map[uuid.UUID]int64) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	var existing []uuid.UUID
+	if err := r.db.Model(&domain.Movie{}).Where("id IN ?", ids).Pluck("id", &existing).Error; err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil
+	}
+
+	rows := make([]domain.MovieViewCount, len(existing))
+	for i, id := range existing {
+		rows[i] = domain.MovieViewCount{MovieID: id, Day: day, Views: counts[id]}
+	}
+	return r.db.Omit("Movie").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "movie_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]any{"views": gorm.Expr("movie_view_counts.views + EXCLUDED.views")}),
+	}).Create(&rows).Error
+}
+
+// RefreshScores recomputes every movie's score. The trending score adds up
+// the views of the last windowDays days, each day weighing half as much
+// every halfLifeDays.
+func (r *postgresViewRepo) RefreshScores(windowDays int, halfLifeDays float64) error {
+	return r.db.Exec(`
+		INSERT INTO movie_scores (movie_id, trending_score, total_views, computed_at)
+		SELECT movie_id,
+			COALESCE(SUM(views * POWER(0.5, ((NOW() AT TIME ZONE 'UTC')::date - day)::float8 / ?))
+				FILTER (WHERE day > (NOW() AT TIME ZONE 'UTC')::date - ?::int), 0),
+			SUM(views),
+			NOW()
+		FROM movie_view_counts
+		GROUP BY movie_id
+		ON CONFLICT (movie_id) DO UPDATE SET
+			trending_score = EXCLUDED.trending_score,
+			total_views = EXCLUDED.total_views,
+			computed_at = EXCLUDED.computed_at`, halfLifeDays, windowDays).Error
+}
+
+// GetRanking pages through live movies by their precomputed score, leaving
+// out movies without views.
+func (r *postgresViewRepo) GetRanking(ranking string, page, pageSize int) ([]*domain.Movie, map[uuid.UUID]domain.MovieScore, int64, error) {
+	var movies []*domain.Movie
+	var totalCount int64
+
+	column, order := "movie_scores.trending_score", "movie_scores.trending_score DESC, movies.id"
+	if ranking == RankPopular {
+		column, order = "movie_scores.total_views", "movie_scores.total_views DESC, movies.average_rating DESC, movies.id"
+	}
+	query := r.db.Model(&domain.Movie{}).
+		Joins("JOIN movie_scores ON movie_scores.movie_id = movies.id").
+		Where("movies.status = ? AND (movies.publish_at IS NULL OR movies.publish_at <= NOW())", domain.MoviePublished).
+		Where(column + " > 0")
+	if err := query.Session(&gorm.Session{}).Count(&totalCount).Error; err != nil {
+		return nil, nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Select("movies.*").Order(order).Offset(offset).Limit(pageSize).Find(&movies).Error; err != nil {
+		return nil, nil, 0, err
+	}
+
+	scores := make(map[uuid.UUID]domain.MovieScore, len(movies))
+	if len(movies) == 0 {
+		return movies, scores, totalCount, nil
+	}
+	ids := make([]uuid.UUID, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.ID
+	}
+	var rows []domain.MovieScore
+	if err := r.db.Where("movie_id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, nil, 0, err
+	}
+	for _, row := range rows {
+		scores[row.MovieID] = row
+	}
+	return movies, scores, totalCount, nil
+}
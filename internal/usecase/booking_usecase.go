@@ -0,0 +1,514 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/payment"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxSeatsPerBooking        = 10
+	paymentTimeout            = 30 * time.Second
+	ticketCodeLength          = 10
+	DefaultSeatHoldDuration   = 10 * time.Minute
+	DefaultCancellationCutoff = time.Hour
+)
+
+// Ticket codes leave out characters that are easily confused, like 0 and O.
+// Its 32 characters divide 256, so every byte maps to one without bias.
+const ticketCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Seat statuses in a screening's seat map.
+const (
+	SeatAvailable = "available"
+	SeatTaken     = "taken"
+)
+
+type BookingUsecase struct {
+	BookingRepo  repository.BookingRepository
+	CinemaRepo   repository.CinemaRepository
+	MovieRepo    repository.MovieRepository
+	Payments     payment.Provider
+	HoldDuration time.Duration // How long seats are held awaiting payment
+	CancelCutoff time.Duration // Confirmed bookings can be cancelled until this long before the start
+}
+
+func NewBookingUsecase(bookingRepo repository.BookingRepository, cinemaRepo repository.CinemaRepository, movieRepo repository.MovieRepository, payments payment.Provider, holdDuration, cancelCutoff time.Duration) *BookingUsecase {
+	return &BookingUsecase{
+		BookingRepo:  bookingRepo,
+		CinemaRepo:   cinemaRepo,
+		MovieRepo:    movieRepo,
+		Payments:     payments,
+		HoldDuration: holdDuration,
+		CancelCutoff: cancelCutoff,
+	}
+}
+
+// ReplaceSeatMap lays out a screen's seats row by row.
+func (u *BookingUsecase) ReplaceSeatMap(screenID string, req *dto.SeatMapRequest) (*dto.SeatMapResponse, error) {
+	screen, err := u.CinemaRepo.FindScreenByID(screenID)
+	if err != nil {
+		return nil, err
+	}
+
+	var seats []*domain.Seat
+	rows := make(map[string]bool)
+	for _, row := range req.Rows {
+		label := strings.ToUpper(row.Row)
+		if rows[label] {
+			return nil, errors.New("invalid seat map: row " + label + " appears twice")
+		}
+		rows[label] = true
+		accessible := make(map[int]bool)
+		for _, number := range row.Accessible {
+			if number > row.Seats {
+				return nil, fmt.Errorf("invalid seat map: row %s has no seat %d", label, number)
+			}
+			accessible[number] = true
+		}
+		for number := 1; number <= row.Seats; number++ {
+			seats = append(seats, &domain.Seat{
+				ID:         uuid.New(),
+				ScreenID:   screen.ID,
+				Row:        label,
+				Number:     number,
+				Accessible: accessible[number],
+			})
+		}
+	}
+
+	if err := u.BookingRepo.ReplaceSeats(screen.ID, seats); err != nil {
+		return nil, err
+	}
+	resp := toSeatMapResponse(screen.ID, seats, nil)
+	return &resp, nil
+}
+
+// GetSeatMap shows which seats of a screening are still available.
+func (u *BookingUsecase) GetSeatMap(screeningID, userID, role string) (*dto.SeatMapResponse, error) {
+	screening, err := u.findBookableScreening(screeningID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	seats, err := u.BookingRepo.GetSeats(screening.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+	taken, err := u.BookingRepo.GetTakenSeats(screening.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toSeatMapResponse(screening.ScreenID, seats, taken)
+	resp.ScreeningID = screening.ID.String()
+	return &resp, nil
+}
+
+// HoldSeats reserves seats of an upcoming screening for HoldDuration, during
+// which the booking can be confirmed. A user holds at most one set of seats
+// per screening at a time.
+func (u *BookingUsecase) HoldSeats(screeningID string, req *dto.HoldSeatsRequest, userID, role string) (*dto.BookingResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	screening, err := u.findBookableScreening(screeningID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if !screening.StartsAt.After(time.Now()) {
+		return nil, errors.New("the screening has already started")
+	}
+
+	wanted := make(map[uuid.UUID]bool)
+	for _, id := range req.SeatIDs {
+		wanted[uuid.MustParse(id)] = true
+	}
+	if len(wanted) > maxSeatsPerBooking {
+		return nil, fmt.Errorf("invalid seats: at most %d can be booked at once", maxSeatsPerBooking)
+	}
+
+	existing, err := u.BookingRepo.FindActiveHold(userUUID, screening.ID)
+	if ignoreNotFound(err, "booking not found") != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("you already hold seats for this screening; confirm or cancel that booking first")
+	}
+
+	// Seats are taken in seat map order so labels read naturally
+	seats, err := u.BookingRepo.GetSeats(screening.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+	var seatIDs []uuid.UUID
+	var labels []string
+	for _, seat := range seats {
+		if wanted[seat.ID] {
+			seatIDs = append(seatIDs, seat.ID)
+			labels = append(labels, seat.Label())
+		}
+	}
+	if len(seatIDs) != len(wanted) {
+		return nil, errors.New("seat not found")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(u.HoldDuration)
+	booking := &domain.Booking{
+		ID:          uuid.New(),
+		ScreeningID: screening.ID,
+		UserID:      userUUID,
+		Status:      domain.BookingHeld,
+		SeatLabels:  labels,
+		Amount:      math.Round(screening.Price*float64(len(seatIDs))*100) / 100,
+		Currency:    screening.Currency,
+		ExpiresAt:   &expiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.BookingRepo.Hold(booking, seatIDs); err != nil {
+		return nil, err
+	}
+	booking.Screening = screening
+	resp := toBookingResponse(booking)
+	return &resp, nil
+}
+
+func (u *BookingUsecase) GetBooking(id, userID string) (*dto.BookingResponse, error) {
+	booking, err := u.findOwnBooking(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toBookingResponse(booking)
+	return &resp, nil
+}
+
+func (u *BookingUsecase) GetMyBookings(req *dto.GetBookingsRequest, userID string) (*dto.GetBookingsResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	bookings, total, err := u.BookingRepo.GetUserBookings(userUUID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.BookingResponse, len(bookings))
+	for i, booking := range bookings {
+		resp[i] = toBookingResponse(booking)
+	}
+	return &dto.GetBookingsResponse{
+		Bookings:   resp,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  total,
+	}, nil
+}
+
+// ConfirmBooking pays for a held booking and issues its ticket. Confirming
+// an already confirmed booking returns it unchanged; charges use the booking
+// ID as reference so a repeated request is not charged twice. Should the
+// hold run out while the payment goes through, the payment is refunded.
+func (u *BookingUsecase) ConfirmBooking(id string, req *dto.ConfirmBookingRequest, userID string) (*dto.BookingResponse, error) {
+	booking, err := u.findOwnBooking(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case booking.Status == domain.BookingConfirmed:
+		resp := toBookingResponse(booking)
+		return &resp, nil
+	case booking.Status == domain.BookingCancelled:
+		return nil, errors.New("the booking has been cancelled")
+	case booking.Status == domain.BookingExpired, !booking.ExpiresAt.After(time.Now()):
+		return nil, errors.New("the hold has expired")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), paymentTimeout)
+	defer cancel()
+	if booking.Amount > 0 {
+		if req.PaymentToken == "" {
+			return nil, errors.New("invalid payment: a payment token is required")
+		}
+		reference, err := u.Payments.Charge(ctx, payment.Charge{
+			Reference: booking.ID.String(),
+			Amount:    booking.Amount,
+			Currency:  booking.Currency,
+			Token:     req.PaymentToken,
+		})
+		if errors.Is(err, payment.ErrDeclined) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("payment failed: %w", err)
+		}
+		booking.PaymentProvider = u.Payments.Name()
+		booking.PaymentReference = reference
+	}
+
+	code, err := newTicketCode()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	booking.Code = &code
+	booking.ConfirmedAt = &now
+	booking.UpdatedAt = now
+	if err := u.BookingRepo.Confirm(booking); err != nil {
+		// A concurrent request may have confirmed it with the same payment
+		if current, findErr := u.BookingRepo.FindByID(id); findErr == nil && current.Status == domain.BookingConfirmed {
+			resp := toBookingResponse(current)
+			return &resp, nil
+		}
+		u.refund(ctx, booking)
+		return nil, err
+	}
+	resp := toBookingResponse(booking)
+	return &resp, nil
+}
+
+// CancelBooking releases the seats of a booking. Held bookings can always be
+// cancelled; confirmed ones until CancelCutoff before the screening and
+// before check-in. A paid booking is cancelled before it is refunded, so
+// that a ticket checked in meanwhile is never refunded; a refund that fails
+// is retried by RetryRefunds.
+func (u *BookingUsecase) CancelBooking(id, userID string) (*dto.BookingResponse, error) {
+	booking, err := u.findOwnBooking(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch booking.Status {
+	case domain.BookingCancelled:
+		return nil, errors.New("the booking has already been cancelled")
+	case domain.BookingExpired:
+		return nil, errors.New("the hold has expired")
+	case domain.BookingConfirmed:
+		if booking.CheckedInAt != nil {
+			return nil, errors.New("the ticket has already been used")
+		}
+		if time.Until(booking.Screening.StartsAt) < u.CancelCutoff {
+			return nil, errors.New("bookings can only be cancelled until " + u.CancelCutoff.String() + " before the screening")
+		}
+	}
+
+	fromStatus := booking.Status
+	now := time.Now()
+	booking.CancelledAt = &now
+	booking.UpdatedAt = now
+	if err := u.BookingRepo.Cancel(booking, fromStatus); err != nil {
+		return nil, err
+	}
+	if booking.PaymentReference != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), paymentTimeout)
+		defer cancel()
+		if err := u.refundCancelled(ctx, booking); err != nil {
+			log.Printf("failed to refund cancelled booking %s, will retry: %v", booking.ID, err)
+		}
+	}
+	resp := toBookingResponse(booking)
+	return &resp, nil
+}
+
+// VerifyTicket checks a ticket code at the door and checks the ticket in.
+// Each ticket can be used once.
+func (u *BookingUsecase) VerifyTicket(req *dto.VerifyTicketRequest) (*dto.TicketResponse, error) {
+	booking, err := u.BookingRepo.FindByCode(strings.ToUpper(strings.TrimSpace(req.Code)))
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != domain.BookingConfirmed {
+		return nil, errors.New("the ticket has been cancelled")
+	}
+	if booking.CheckedInAt != nil {
+		return nil, errors.New("the ticket has already been used at " + booking.CheckedInAt.In(cinemaLocation(booking.Screening.Cinema)).Format("15:04"))
+	}
+	if booking.Screening.EndsAt.Before(time.Now()) {
+		return nil, errors.New("the screening is over")
+	}
+	if err := u.BookingRepo.CheckIn(booking); err != nil {
+		return nil, err
+	}
+	return toTicketResponse(booking), nil
+}
+
+// ReleaseExpiredHolds frees the seats of holds that ran out. Holds are also
+// released when their seats are requested, so this only keeps the table
+// tidy and statuses current.
+func (u *BookingUsecase) ReleaseExpiredHolds() (int64, error) {
+	return u.BookingRepo.ReleaseExpiredHolds()
+}
+
+// RetryRefunds pays back cancelled bookings whose refund failed before and
+// reports how many went through.
+func (u *BookingUsecase) RetryRefunds() (int64, error) {
+	bookings, err := u.BookingRepo.FindPendingRefunds()
+	if err != nil {
+		return 0, err
+	}
+	var refunded int64
+	for _, booking := range bookings {
+		ctx, cancel := context.WithTimeout(context.Background(), paymentTimeout)
+		err := u.refundCancelled(ctx, booking)
+		cancel()
+		if err != nil {
+			log.Printf("failed to refund cancelled booking %s: %v", booking.ID, err)
+			continue
+		}
+		refunded++
+	}
+	return refunded, nil
+}
+
+func (u *BookingUsecase) findBookableScreening(id, userID, role string) (*domain.Screening, error) {
+	screening, err := u.CinemaRepo.FindScreeningByID(id)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := u.MovieRepo.FindByID(screening.MovieID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("screening not found")
+	}
+	screening.Movie = movie
+	return screening, nil
+}
+
+// findOwnBooking hides other users' bookings as not found.
+func (u *BookingUsecase) findOwnBooking(id, userID string) (*domain.Booking, error) {
+	booking, err := u.BookingRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID.String() != userID {
+		return nil, errors.New("booking not found")
+	}
+	return booking, nil
+}
+
+// refundCancelled pays back a cancelled booking and records the refund.
+func (u *BookingUsecase) refundCancelled(ctx context.Context, booking *domain.Booking) error {
+	if err := u.Payments.Refund(ctx, booking.PaymentReference); err != nil {
+		return err
+	}
+	return u.BookingRepo.MarkRefunded(booking)
+}
+
+// refund pays back a booking whose confirmation failed after it was charged.
+func (u *BookingUsecase) refund(ctx context.Context, booking *domain.Booking) {
+	if booking.PaymentReference == "" {
+		return
+	}
+	if err := u.Payments.Refund(ctx, booking.PaymentReference); err != nil {
+		log.Printf("failed to refund payment %s for booking %s: %v", booking.PaymentReference, booking.ID, err)
+	}
+}
+
+func newTicketCode() (string, error) {
+	random := make([]byte, ticketCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	code := make([]byte, ticketCodeLength)
+	for i, b := range random {
+		code[i] = ticketCodeAlphabet[int(b)%len(ticketCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+func toSeatMapResponse(screenID uuid.UUID, seats []*domain.Seat, taken map[uuid.UUID]bool) dto.SeatMapResponse {
+	resp := dto.SeatMapResponse{ScreenID: screenID.String(), Rows: []dto.SeatRowResponse{}, Total: len(seats)}
+	for _, seat := range seats {
+		if len(resp.Rows) == 0 || resp.Rows[len(resp.Rows)-1].Row != seat.Row {
+			resp.Rows = append(resp.Rows, dto.SeatRowResponse{Row: seat.Row})
+		}
+		row := &resp.Rows[len(resp.Rows)-1]
+		seatResp := dto.SeatResponse{
+			ID:         seat.ID.String(),
+			Number:     seat.Number,
+			Label:      seat.Label(),
+			Accessible: seat.Accessible,
+		}
+		if taken != nil {
+			seatResp.Status = SeatAvailable
+			if taken[seat.ID] {
+				seatResp.Status = SeatTaken
+			}
+		}
+		if !taken[seat.ID] {
+			resp.Available++
+		}
+		row.Seats = append(row.Seats, seatResp)
+	}
+	return resp
+}
+
+func toBookingResponse(booking *domain.Booking) dto.BookingResponse {
+	resp := dto.BookingResponse{
+		ID:          booking.ID.String(),
+		ScreeningID: booking.ScreeningID.String(),
+		Status:      booking.Status,
+		Seats:       nonNil(booking.SeatLabels),
+		Amount:      booking.Amount,
+		Currency:    booking.Currency,
+		CreatedAt:   booking.CreatedAt,
+		ConfirmedAt: booking.ConfirmedAt,
+		CancelledAt: booking.CancelledAt,
+		RefundedAt:  booking.RefundedAt,
+	}
+	switch booking.Status {
+	case domain.BookingHeld:
+		resp.ExpiresAt = booking.ExpiresAt
+		// The hold may have run out before the sweeper got to it
+		if !booking.ExpiresAt.After(time.Now()) {
+			resp.Status = domain.BookingExpired
+		}
+	case domain.BookingConfirmed:
+		if booking.Screening != nil {
+			resp.Ticket = toTicketResponse(booking)
+		}
+	case domain.BookingCancelled:
+		resp.RefundPending = booking.PaymentReference != "" && booking.RefundedAt == nil
+	}
+	return resp
+}
+
+func toTicketResponse(booking *domain.Booking) *dto.TicketResponse {
+	screening := booking.Screening
+	startsAt := screening.StartsAt.In(cinemaLocation(screening.Cinema))
+	ticket := &dto.TicketResponse{
+		BookingID:   booking.ID.String(),
+		MovieID:     screening.MovieID.String(),
+		StartsAt:    startsAt,
+		LocalDate:   startsAt.Format(time.DateOnly),
+		LocalTime:   startsAt.Format("15:04"),
+		Format:      screening.Format,
+		Seats:       nonNil(booking.SeatLabels),
+		CheckedInAt: booking.CheckedInAt,
+	}
+	if booking.Code != nil {
+		ticket.Code = *booking.Code
+	}
+	if screening.Movie != nil {
+		ticket.MovieTitle = screening.Movie.Title
+	}
+	if screening.Cinema != nil {
+		ticket.Cinema = screening.Cinema.Name
+		ticket.CinemaAddress = screening.Cinema.Address
+	}
+	if screening.Screen != nil {
+		ticket.Screen = screening.Screen.Name
+	}
+	return ticket
+}
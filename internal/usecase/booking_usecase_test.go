@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/payment"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeBookingRepo keeps bookings in memory. Seat claims are unique per
+// screening like the database's index, and status changes are conditional
+// like the repository's updates. Methods the tests do not need panic.
+type fakeBookingRepo struct {
+	repository.BookingRepository
+
+	mu        sync.Mutex
+	seats     []*domain.Seat
+	bookings  map[uuid.UUID]*domain.Booking
+	claims    map[uuid.UUID]uuid.UUID // Seat to booking
+	screening *domain.Screening
+	// expireOnConfirm makes the hold run out while the payment goes through
+	expireOnConfirm bool
+	// beforeCancel changes the stored booking just before it is cancelled
+	beforeCancel func(stored *domain.Booking)
+}
+
+func newFakeBookingRepo(seats ...*domain.Seat) *fakeBookingRepo {
+	return &fakeBookingRepo{seats: seats, bookings: make(map[uuid.UUID]*domain.Booking), claims: make(map[uuid.UUID]uuid.UUID)}
+}
+
+func (r *fakeBookingRepo) GetSeats(screenID uuid.UUID) ([]*domain.Seat, error) {
+	return r.seats, nil
+}
+
+func (r *fakeBookingRepo) FindActiveHold(userID, screeningID uuid.UUID) (*domain.Booking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, booking := range r.bookings {
+		if booking.UserID == userID && booking.ScreeningID == screeningID && booking.Status == domain.BookingHeld {
+			copied := *booking
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("booking not found")
+}
+
+func (r *fakeBookingRepo) Hold(booking *domain.Booking, seatIDs []uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, seatID := range seatIDs {
+		if _, taken := r.claims[seatID]; taken {
+			return errors.New("some of the seats are no longer available")
+		}
+	}
+	for _, seatID := range seatIDs {
+		r.claims[seatID] = booking.ID
+	}
+	copied := *booking
+	r.bookings[booking.ID] = &copied
+	return nil
+}
+
+func (r *fakeBookingRepo) FindByID(id string) (*domain.Booking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	booking, ok := r.bookings[uuid.MustParse(id)]
+	if !ok {
+		return nil, errors.New("booking not found")
+	}
+	copied := *booking
+	copied.Screening = r.screening
+	return &copied, nil
+}
+
+func (r *fakeBookingRepo) Confirm(booking *domain.Booking) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.bookings[booking.ID]
+	if r.expireOnConfirm {
+		stored.Status = domain.BookingExpired
+	}
+	if stored.Status != domain.BookingHeld || !stored.ExpiresAt.After(time.Now()) {
+		return errors.New("the hold has expired")
+	}
+	stored.Status = domain.BookingConfirmed
+	stored.Code = booking.Code
+	stored.PaymentProvider = booking.PaymentProvider
+	stored.PaymentReference = booking.PaymentReference
+	stored.ConfirmedAt = booking.ConfirmedAt
+	booking.Status = domain.BookingConfirmed
+	return nil
+}
+
+func (r *fakeBookingRepo) Cancel(booking *domain.Booking, fromStatus string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.bookings[booking.ID]
+	if r.beforeCancel != nil {
+		r.beforeCancel(stored)
+	}
+	if stored.Status != fromStatus || stored.CheckedInAt != nil {
+		return errors.New("the booking has changed, please try again")
+	}
+	stored.Status = domain.BookingCancelled
+	stored.CancelledAt = booking.CancelledAt
+	booking.Status = domain.BookingCancelled
+	return nil
+}
+
+func (r *fakeBookingRepo) MarkRefunded(booking *domain.Booking) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.bookings[booking.ID].RefundedAt = &now
+	booking.RefundedAt = &now
+	return nil
+}
+
+type fakeCinemaRepo struct {
+	repository.CinemaRepository
+	screening *domain.Screening
+}
+
+func (r *fakeCinemaRepo) FindScreeningByID(id string) (*domain.Screening, error) {
+	copied := *r.screening
+	return &copied, nil
+}
+
+type fakeMovieRepo struct {
+	repository.MovieRepository
+	movie *domain.Movie
+}
+
+func (r *fakeMovieRepo) FindByID(id string) (*domain.Movie, error) {
+	copied := *r.movie
+	return &copied, nil
+}
+
+func newBookingFixture(seats ...*domain.Seat) (*BookingUsecase, *fakeBookingRepo, *payment.Fake, *domain.Screening) {
+	movie := &domain.Movie{ID: uuid.New(), UserID: uuid.New(), Status: domain.MoviePublished}
+	screening := &domain.Screening{
+		ID:       uuid.New(),
+		MovieID:  movie.ID,
+		ScreenID: uuid.New(),
+		StartsAt: time.Now().Add(48 * time.Hour),
+		EndsAt:   time.Now().Add(50 * time.Hour),
+		Price:    12.5,
+		Currency: "EUR",
+	}
+	bookings := newFakeBookingRepo(seats...)
+	bookings.screening = screening
+	payments := payment.NewFake()
+	bookingUsecase := NewBookingUsecase(bookings, &fakeCinemaRepo{screening: screening}, &fakeMovieRepo{movie: movie},
+		payments, DefaultSeatHoldDuration, DefaultCancellationCutoff)
+	return bookingUsecase, bookings, payments, screening
+}
+
+func newSeat(row string, number int) *domain.Seat {
+	return &domain.Seat{ID: uuid.New(), Row: row, Number: number}
+}
+
+func holdSeat(t *testing.T, u *BookingUsecase, screening *domain.Screening, seat *domain.Seat) (*dto.BookingResponse, string) {
+	t.Helper()
+	userID := uuid.New().String()
+	booking, err := u.HoldSeats(screening.ID.String(), &dto.HoldSeatsRequest{SeatIDs: []string{seat.ID.String()}}, userID, "")
+	if err != nil {
+		t.Fatalf("holding seat: %v", err)
+	}
+	return booking, userID
+}
+
+func TestHoldSeatsInParallelGivesTheSeatToOneUser(t *testing.T) {
+	seat := newSeat("A", 1)
+	u, _, _, screening := newBookingFixture(seat)
+
+	const attempts = 20
+	var wg sync.WaitGroup
+	errs := make([]error, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := &dto.HoldSeatsRequest{SeatIDs: []string{seat.ID.String()}}
+			_, errs[i] = u.HoldSeats(screening.ID.String(), req, uuid.New().String(), "")
+		}(i)
+	}
+	wg.Wait()
+
+	held := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			held++
+		case err.Error() != "some of the seats are no longer available":
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if held != 1 {
+		t.Fatalf("seat held %d times, want once", held)
+	}
+}
+
+func TestConfirmBookingRefundsWhenTheHoldExpiresDuringPayment(t *testing.T) {
+	seat := newSeat("A", 1)
+	u, bookings, payments, screening := newBookingFixture(seat)
+	booking, userID := holdSeat(t, u, screening, seat)
+	bookings.expireOnConfirm = true
+
+	_, err := u.ConfirmBooking(booking.ID, &dto.ConfirmBookingRequest{PaymentToken: "tok"}, userID)
+	if err == nil || err.Error() != "the hold has expired" {
+		t.Fatalf("got error %v, want the hold has expired", err)
+	}
+	// The fake provider numbers its charges, so the only one is fake_1
+	if !payments.Refunded("fake_1") {
+		t.Fatal("payment for the expired hold was not refunded")
+	}
+}
+
+func TestConfirmBookingIsIdempotent(t *testing.T) {
+	seat := newSeat("A", 1)
+	u, _, payments, screening := newBookingFixture(seat)
+	booking, userID := holdSeat(t, u, screening, seat)
+	req := &dto.ConfirmBookingRequest{PaymentToken: "tok"}
+
+	// Concurrent confirmations, as from a double click, then a late retry
+	var wg sync.WaitGroup
+	results := make([]*dto.BookingResponse, 5)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = u.ConfirmBooking(booking.ID, req, userID)
+		}(i)
+	}
+	wg.Wait()
+	retried, err := u.ConfirmBooking(booking.ID, req, userID)
+	results = append(results, retried)
+	errs = append(errs, err)
+
+	var code string
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("confirmation %d failed: %v", i, err)
+		}
+		if results[i].Status != domain.BookingConfirmed || results[i].Ticket == nil {
+			t.Fatalf("confirmation %d returned status %s without ticket", i, results[i].Status)
+		}
+		if code == "" {
+			code = results[i].Ticket.Code
+		} else if results[i].Ticket.Code != code {
+			t.Fatalf("confirmation %d issued ticket %s, want %s", i, results[i].Ticket.Code, code)
+		}
+	}
+	if payments.Refunded("fake_1") {
+		t.Fatal("payment of the confirmed booking was refunded")
+	}
+	// Only one charge was made, so the next one is the second
+	probe, err := payments.Charge(context.Background(), payment.Charge{Reference: "probe", Token: "tok"})
+	if err != nil || probe != "fake_2" {
+		t.Fatalf("got charge %q, %v; the booking was charged more than once", probe, err)
+	}
+}
+
+func TestCancelBookingDoesNotRefundATicketCheckedInMeanwhile(t *testing.T) {
+	seat := newSeat("A", 1)
+	u, bookings, payments, screening := newBookingFixture(seat)
+	booking, userID := holdSeat(t, u, screening, seat)
+	if _, err := u.ConfirmBooking(booking.ID, &dto.ConfirmBookingRequest{PaymentToken: "tok"}, userID); err != nil {
+		t.Fatal(err)
+	}
+	bookings.beforeCancel = func(stored *domain.Booking) {
+		now := time.Now()
+		stored.CheckedInAt = &now
+	}
+
+	if _, err := u.CancelBooking(booking.ID, userID); err == nil {
+		t.Fatal("cancelled a checked-in booking")
+	}
+	if payments.Refunded("fake_1") {
+		t.Fatal("refunded a booking that was not cancelled")
+	}
+}
+
+func TestCancelBookingRefunds(t *testing.T) {
+	seat := newSeat("A", 1)
+	u, _, payments, screening := newBookingFixture(seat)
+	booking, userID := holdSeat(t, u, screening, seat)
+	if _, err := u.ConfirmBooking(booking.ID, &dto.ConfirmBookingRequest{PaymentToken: "tok"}, userID); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelled, err := u.CancelBooking(booking.ID, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.Status != domain.BookingCancelled || cancelled.RefundedAt == nil || cancelled.RefundPending {
+		t.Fatalf("got status %s, refunded at %v, pending %v", cancelled.Status, cancelled.RefundedAt, cancelled.RefundPending)
+	}
+	if !payments.Refunded("fake_1") {
+		t.Fatal("payment was not refunded")
+	}
+}
@@ -0,0 +1,414 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"fmt"
+	"time"
+	_ "time/tzdata" // Cinema time zones must resolve on hosts without a zone database
+
+	"github.com/google/uuid"
+)
+
+// defaultScreeningLength is used for movies without a runtime.
+const defaultScreeningLength = 120 * time.Minute
+
+// Wall clock layouts accepted for screening times besides RFC 3339.
+var localTimeLayouts = []string{"2006-01-02T15:04", "2006-01-02T15:04:05"}
+
+type CinemaUsecase struct {
+	CinemaRepo   repository.CinemaRepository
+	MovieRepo    repository.MovieRepository
+	MovieUsecase *MovieUsecase // Translates and flags listed movies
+}
+
+func NewCinemaUsecase(cinemaRepo repository.CinemaRepository, movieRepo repository.MovieRepository, movieUsecase *MovieUsecase) *CinemaUsecase {
+	return &CinemaUsecase{CinemaRepo: cinemaRepo, MovieRepo: movieRepo, MovieUsecase: movieUsecase}
+}
+
+func (u *CinemaUsecase) GetCinemas(req *dto.GetCinemasRequest) (*dto.GetCinemasResponse, error) {
+	filter := repository.CinemaFilter{City: req.City, Country: req.Country}
+	cinemas, total, err := u.CinemaRepo.GetCinemas(filter, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.CinemaResponse, len(cinemas))
+	for i, cinema := range cinemas {
+		resp[i] = toCinemaResponse(cinema)
+	}
+	return &dto.GetCinemasResponse{
+		Cinemas:    resp,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  total,
+	}, nil
+}
+
+// GetCinema shows a cinema with its screens.
+func (u *CinemaUsecase) GetCinema(id string) (*dto.CinemaResponse, error) {
+	cinema, err := u.CinemaRepo.FindCinemaByID(id)
+	if err != nil {
+		return nil, err
+	}
+	screens, err := u.CinemaRepo.GetScreens(cinema.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toCinemaResponse(cinema)
+	resp.Screens = make([]dto.ScreenResponse, len(screens))
+	for i, screen := range screens {
+		resp.Screens[i] = toScreenResponse(screen)
+	}
+	return &resp, nil
+}
+
+func (u *CinemaUsecase) CreateCinema(req *dto.CinemaRequest) (*dto.CinemaResponse, error) {
+	now := time.Now()
+	cinema := &domain.Cinema{ID: uuid.New(), CreatedAt: now}
+	applyCinemaRequest(cinema, req, now)
+	if err := u.CinemaRepo.CreateCinema(cinema); err != nil {
+		return nil, err
+	}
+	resp := toCinemaResponse(cinema)
+	return &resp, nil
+}
+
+// UpdateCinema changes a cinema's details. Screenings keep their moment in
+// time when the time zone changes, so their local times shift.
+func (u *CinemaUsecase) UpdateCinema(id string, req *dto.CinemaRequest) (*dto.CinemaResponse, error) {
+	cinema, err := u.CinemaRepo.FindCinemaByID(id)
+	if err != nil {
+		return nil, err
+	}
+	applyCinemaRequest(cinema, req, time.Now())
+	if err := u.CinemaRepo.UpdateCinema(cinema); err != nil {
+		return nil, err
+	}
+	resp := toCinemaResponse(cinema)
+	return &resp, nil
+}
+
+func (u *CinemaUsecase) DeleteCinema(id string) error {
+	return u.CinemaRepo.DeleteCinema(id)
+}
+
+func (u *CinemaUsecase) CreateScreen(cinemaID string, req *dto.ScreenRequest) (*dto.ScreenResponse, error) {
+	cinema, err := u.CinemaRepo.FindCinemaByID(cinemaID)
+	if err != nil {
+		return nil, err
+	}
+	screen := &domain.Screen{ID: uuid.New(), CinemaID: cinema.ID, Name: req.Name, CreatedAt: time.Now()}
+	if err := u.CinemaRepo.CreateScreen(screen); err != nil {
+		return nil, err
+	}
+	resp := toScreenResponse(screen)
+	return &resp, nil
+}
+
+func (u *CinemaUsecase) UpdateScreen(id string, req *dto.ScreenRequest) (*dto.ScreenResponse, error) {
+	screen, err := u.CinemaRepo.FindScreenByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != screen.Name {
+		screens, err := u.CinemaRepo.GetScreens(screen.CinemaID)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range screens {
+			if other.Name == req.Name {
+				return nil, errors.New("the cinema already has a screen with this name")
+			}
+		}
+	}
+	screen.Name = req.Name
+	if err := u.CinemaRepo.UpdateScreen(screen); err != nil {
+		return nil, err
+	}
+	resp := toScreenResponse(screen)
+	return &resp, nil
+}
+
+func (u *CinemaUsecase) DeleteScreen(id string) error {
+	return u.CinemaRepo.DeleteScreen(id)
+}
+
+// CreateScreening schedules a movie on a screen. It lasts the movie's runtime
+// unless a duration is given and may not overlap another screening on the
+// same screen.
+func (u *CinemaUsecase) CreateScreening(req *dto.CreateScreeningRequest) (*dto.ScreeningResponse, error) {
+	screen, err := u.CinemaRepo.FindScreenByID(req.ScreenID)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := u.MovieRepo.FindByID(req.MovieID)
+	if err != nil {
+		return nil, err
+	}
+	loc := cinemaLocation(screen.Cinema)
+	startsAt, err := parseScreeningTime(req.StartsAt, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	screening := &domain.Screening{
+		ID:        uuid.New(),
+		MovieID:   movie.ID,
+		ScreenID:  screen.ID,
+		CinemaID:  screen.CinemaID,
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(screeningLength(movie, req.DurationMinutes)),
+		Language:  req.Language,
+		Subtitles: req.Subtitles,
+		Format:    req.Format,
+		Price:     req.Price,
+		Currency:  req.Currency,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Screen:    screen,
+	}
+	if screening.Language == "" {
+		screening.Language = movie.OriginalLanguage
+	}
+	if err := checkScreeningPrice(screening); err != nil {
+		return nil, err
+	}
+	if err := u.checkOverlap(screening, loc); err != nil {
+		return nil, err
+	}
+	if err := u.CinemaRepo.CreateScreening(screening); err != nil {
+		return nil, err
+	}
+	resp := toScreeningResponse(screening, loc)
+	return &resp, nil
+}
+
+func (u *CinemaUsecase) UpdateScreening(id string, req *dto.UpdateScreeningRequest) (*dto.ScreeningResponse, error) {
+	screening, err := u.CinemaRepo.FindScreeningByID(id)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := u.MovieRepo.FindByID(screening.MovieID.String())
+	if err != nil {
+		return nil, err
+	}
+	loc := cinemaLocation(screening.Cinema)
+	startsAt, err := parseScreeningTime(req.StartsAt, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	screening.StartsAt = startsAt
+	screening.EndsAt = startsAt.Add(screeningLength(movie, req.DurationMinutes))
+	screening.Language = req.Language
+	if screening.Language == "" {
+		screening.Language = movie.OriginalLanguage
+	}
+	screening.Subtitles = req.Subtitles
+	screening.Format = req.Format
+	screening.Price = req.Price
+	screening.Currency = req.Currency
+	screening.UpdatedAt = time.Now()
+	if err := checkScreeningPrice(screening); err != nil {
+		return nil, err
+	}
+	if err := u.checkOverlap(screening, loc); err != nil {
+		return nil, err
+	}
+	if err := u.CinemaRepo.UpdateScreening(screening); err != nil {
+		return nil, err
+	}
+	resp := toScreeningResponse(screening, loc)
+	return &resp, nil
+}
+
+func (u *CinemaUsecase) DeleteScreening(id string) error {
+	return u.CinemaRepo.DeleteScreening(id)
+}
+
+// GetShowtimes lists where and when a movie the user can see is playing on
+// a day, by cinema.
+func (u *CinemaUsecase) GetShowtimes(movieID string, req *dto.GetShowtimesRequest, userID, role string) ([]dto.CinemaShowtimesResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+
+	screenings, err := u.CinemaRepo.GetScreenings(repository.ScreeningFilter{MovieID: &movie.ID, City: req.City, Date: req.Date})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cinemas come in the order of their first screening
+	resp := []dto.CinemaShowtimesResponse{}
+	index := make(map[uuid.UUID]int)
+	for _, screening := range screenings {
+		i, ok := index[screening.CinemaID]
+		if !ok {
+			i = len(resp)
+			index[screening.CinemaID] = i
+			resp = append(resp, dto.CinemaShowtimesResponse{Cinema: toCinemaResponse(screening.Cinema)})
+		}
+		resp[i].Screenings = append(resp[i].Screenings, toScreeningResponse(screening, cinemaLocation(screening.Cinema)))
+	}
+	return resp, nil
+}
+
+// GetSchedule lists what a cinema shows on a day, today unless given, by
+// movie.
+func (u *CinemaUsecase) GetSchedule(cinemaID string, req *dto.GetScheduleRequest, userID string) (*dto.CinemaScheduleResponse, error) {
+	cinema, err := u.CinemaRepo.FindCinemaByID(cinemaID)
+	if err != nil {
+		return nil, err
+	}
+	loc := cinemaLocation(cinema)
+	date := req.Date
+	if date == "" {
+		date = time.Now().In(loc).Format(time.DateOnly)
+	}
+
+	screenings, err := u.CinemaRepo.GetScreenings(repository.ScreeningFilter{CinemaID: &cinema.ID, Date: date})
+	if err != nil {
+		return nil, err
+	}
+
+	// Movies come in the order of their first screening
+	var movies []*domain.Movie
+	byMovie := make(map[uuid.UUID][]dto.ScreeningResponse)
+	for _, screening := range screenings {
+		if _, ok := byMovie[screening.MovieID]; !ok {
+			movies = append(movies, screening.Movie)
+		}
+		byMovie[screening.MovieID] = append(byMovie[screening.MovieID], toScreeningResponse(screening, loc))
+	}
+	movieResponses, err := u.MovieUsecase.movieListResponses(movies, userID, req.Locales)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.CinemaScheduleResponse{
+		Cinema: toCinemaResponse(cinema),
+		Date:   date,
+		Movies: make([]dto.MovieScheduleResponse, len(movies)),
+	}
+	for i, movie := range movies {
+		resp.Movies[i] = dto.MovieScheduleResponse{Movie: movieResponses[i], Screenings: byMovie[movie.ID]}
+	}
+	return resp, nil
+}
+
+func (u *CinemaUsecase) checkOverlap(screening *domain.Screening, loc *time.Location) error {
+	overlapping, err := u.CinemaRepo.FindOverlappingScreening(screening.ScreenID, screening.StartsAt, screening.EndsAt, &screening.ID)
+	if err != nil {
+		return err
+	}
+	if overlapping != nil {
+		return fmt.Errorf("screen is already in use from %s to %s",
+			overlapping.StartsAt.In(loc).Format("2006-01-02 15:04"), overlapping.EndsAt.In(loc).Format("15:04"))
+	}
+	return nil
+}
+
+// checkScreeningPrice requires a currency for paid screenings.
+func checkScreeningPrice(screening *domain.Screening) error {
+	if screening.Price == 0 {
+		screening.Currency = ""
+	} else if screening.Currency == "" {
+		return errors.New("invalid price: a currency is required")
+	}
+	return nil
+}
+
+// parseScreeningTime reads a time with an offset, or a wall clock time in
+// the cinema's time zone.
+func parseScreeningTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	for _, layout := range localTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.New("invalid startsAt: use 2006-01-02T15:04 in the cinema's time zone or RFC 3339")
+}
+
+func screeningLength(movie *domain.Movie, durationMinutes int) time.Duration {
+	switch {
+	case durationMinutes > 0:
+		return time.Duration(durationMinutes) * time.Minute
+	case movie.RuntimeMinutes > 0:
+		return time.Duration(movie.RuntimeMinutes) * time.Minute
+	}
+	return defaultScreeningLength
+}
+
+// cinemaLocation resolves a cinema's time zone, which was validated when it
+// was saved.
+func cinemaLocation(cinema *domain.Cinema) *time.Location {
+	if cinema == nil {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(cinema.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func applyCinemaRequest(cinema *domain.Cinema, req *dto.CinemaRequest, now time.Time) {
+	cinema.Name = req.Name
+	cinema.City = req.City
+	cinema.Country = req.Country
+	cinema.Address = req.Address
+	cinema.TimeZone = req.TimeZone
+	cinema.Latitude = req.Latitude
+	cinema.Longitude = req.Longitude
+	cinema.Website = req.Website
+	cinema.UpdatedAt = now
+}
+
+func toCinemaResponse(cinema *domain.Cinema) dto.CinemaResponse {
+	return dto.CinemaResponse{
+		ID:        cinema.ID.String(),
+		Name:      cinema.Name,
+		City:      cinema.City,
+		Country:   cinema.Country,
+		Address:   cinema.Address,
+		TimeZone:  cinema.TimeZone,
+		Latitude:  cinema.Latitude,
+		Longitude: cinema.Longitude,
+		Website:   cinema.Website,
+	}
+}
+
+func toScreenResponse(screen *domain.Screen) dto.ScreenResponse {
+	return dto.ScreenResponse{ID: screen.ID.String(), Name: screen.Name}
+}
+
+func toScreeningResponse(screening *domain.Screening, loc *time.Location) dto.ScreeningResponse {
+	startsAt := screening.StartsAt.In(loc)
+	resp := dto.ScreeningResponse{
+		ID:        screening.ID.String(),
+		MovieID:   screening.MovieID.String(),
+		StartsAt:  startsAt,
+		EndsAt:    screening.EndsAt.In(loc),
+		LocalDate: startsAt.Format(time.DateOnly),
+		LocalTime: startsAt.Format("15:04"),
+		Language:  screening.Language,
+		Subtitles: screening.Subtitles,
+		Format:    screening.Format,
+		Price:     screening.Price,
+		Currency:  screening.Currency,
+	}
+	if screening.Screen != nil {
+		resp.Screen = toScreenResponse(screening.Screen)
+	}
+	return resp
+}
@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type CollectionUsecase struct {
+	CollectionRepo repository.CollectionRepository
+	MovieRepo      repository.MovieRepository
+	UserRepo       repository.UserRepository
+}
+
+func NewCollectionUsecase(collectionRepo repository.CollectionRepository, movieRepo repository.MovieRepository, userRepo repository.UserRepository) *CollectionUsecase {
+	return &CollectionUsecase{CollectionRepo: collectionRepo, MovieRepo: movieRepo, UserRepo: userRepo}
+}
+
+func (u *CollectionUsecase) CreateCollection(req *dto.CreateCollectionRequest, userID string) (*dto.CollectionResponse, error) {
+	visibility := req.Visibility
+	if visibility == "" {
+		visibility = domain.VisibilityPrivate
+	}
+	collection := &domain.Collection{
+		ID:          uuid.New(),
+		OwnerID:     uuid.MustParse(userID),
+		Name:        req.Name,
+		Description: req.Description,
+		Visibility:  visibility,
+	}
+	if err := u.CollectionRepo.Create(collection); err != nil {
+		return nil, err
+	}
+	resp := toCollectionResponse(collection)
+	return &resp, nil
+}
+
+func (u *CollectionUsecase) UpdateCollection(id string, req *dto.UpdateCollectionRequest, userID string) (*dto.CollectionResponse, error) {
+	collection, err := u.findOwnedCollection(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	collection.Name = req.Name
+	collection.Description = req.Description
+	collection.Visibility = req.Visibility
+	if err := u.CollectionRepo.Update(collection); err != nil {
+		return nil, err
+	}
+	resp := toCollectionResponse(collection)
+	return &resp, nil
+}
+
+func (u *CollectionUsecase) DeleteCollection(id string, userID string) error {
+	if _, err := u.findOwnedCollection(id, userID); err != nil {
+		return err
+	}
+	return u.CollectionRepo.Delete(id)
+}
+
+func (u *CollectionUsecase) GetPublicCollections(req *dto.GetCollectionsRequest) (*dto.GetCollectionsResponse, error) {
+	collections, totalCount, err := u.CollectionRepo.GetPublic(req.Page, req.PageSize, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return toCollectionsResponse(collections, totalCount, req), nil
+}
+
+func (u *CollectionUsecase) GetUserCollections(userID string, req *dto.GetCollectionsRequest) (*dto.GetCollectionsResponse, error) {
+	collections, totalCount, err := u.CollectionRepo.GetForUser(userID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return toCollectionsResponse(collections, totalCount, req), nil
+}
+
+// GetCollection returns a collection with its entries. userID is optional;
+// private collections are reported as not found to anyone but the owner and
+// accepted collaborators.
+func (u *CollectionUsecase) GetCollection(id string, userID string) (*dto.CollectionDetailsResponse, error) {
+	collection, err := u.CollectionRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	canView, canEdit := u.access(collection, userID)
+	if !canView {
+		return nil, errors.New("collection not found")
+	}
+
+	entries, err := u.CollectionRepo.GetEntries(id)
+	if err != nil {
+		return nil, err
+	}
+	entryResponses := make([]dto.CollectionEntryResponse, len(entries))
+	for i, entry := range entries {
+		entryResponses[i] = toCollectionEntryResponse(entry)
+	}
+
+	resp := &dto.CollectionDetailsResponse{
+		CollectionResponse: toCollectionResponse(collection),
+		CanEdit:            canEdit,
+		Entries:            entryResponses,
+	}
+	if collection.OwnerID.String() == userID {
+		collaborators, err := u.CollectionRepo.GetCollaborators(id)
+		if err != nil {
+			return nil, err
+		}
+		resp.Collaborators = make([]dto.CollaboratorResponse, len(collaborators))
+		for i, collaborator := range collaborators {
+			resp.Collaborators[i] = toCollaboratorResponse(collaborator)
+		}
+	}
+	return resp, nil
+}
+
+func (u *CollectionUsecase) AddEntry(id string, req *dto.AddCollectionEntryRequest, userID string) (*dto.CollectionEntryResponse, error) {
+	collection, err := u.findEditableCollection(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := u.MovieRepo.FindByID(req.MovieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, "") {
+		return nil, errors.New("movie not found")
+	}
+	if _, err := u.CollectionRepo.FindEntry(id, req.MovieID); err == nil {
+		return nil, errors.New("movie is already in this collection")
+	}
+
+	entry := &domain.CollectionEntry{
+		ID:           uuid.New(),
+		CollectionID: collection.ID,
+		MovieID:      movie.ID,
+		Note:         req.Note,
+		AddedBy:      uuid.MustParse(userID),
+	}
+	if err := u.CollectionRepo.AddEntry(entry); err != nil {
+		return nil, err
+	}
+	entry.Movie = movie
+	resp := toCollectionEntryResponse(entry)
+	return &resp, nil
+}
+
+func (u *CollectionUsecase) UpdateEntry(id, movieID string, req *dto.UpdateCollectionEntryRequest, userID string) (*dto.CollectionEntryResponse, error) {
+	if _, err := u.findEditableCollection(id, userID); err != nil {
+		return nil, err
+	}
+	entry, err := u.CollectionRepo.FindEntry(id, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Note != nil {
+		entry.Note = *req.Note
+		if err := u.CollectionRepo.UpdateEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+	if req.Position != nil {
+		if err := u.CollectionRepo.MoveEntry(entry, *req.Position); err != nil {
+			return nil, err
+		}
+	}
+	resp := toCollectionEntryResponse(entry)
+	return &resp, nil
+}
+
+func (u *CollectionUsecase) RemoveEntry(id, movieID string, userID string) error {
+	if _, err := u.findEditableCollection(id, userID); err != nil {
+		return err
+	}
+	entry, err := u.CollectionRepo.FindEntry(id, movieID)
+	if err != nil {
+		return err
+	}
+	return u.CollectionRepo.RemoveEntry(entry)
+}
+
+func (u *CollectionUsecase) InviteCollaborator(id string, req *dto.InviteCollaboratorRequest, userID string) (*dto.CollaboratorResponse, error) {
+	collection, err := u.findOwnedCollection(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	invitee, err := u.UserRepo.FindByUsername(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if invitee.ID == collection.OwnerID {
+		return nil, errors.New("you cannot invite yourself")
+	}
+	if _, err := u.CollectionRepo.FindCollaborator(id, invitee.ID.String()); err == nil {
+		return nil, errors.New("user has already been invited")
+	}
+
+	collaborator := &domain.CollectionCollaborator{
+		ID:           uuid.New(),
+		CollectionID: collection.ID,
+		UserID:       invitee.ID,
+		CanEdit:      req.CanEdit,
+		Status:       domain.InvitationPending,
+		InvitedBy:    collection.OwnerID,
+	}
+	if err := u.CollectionRepo.AddCollaborator(collaborator); err != nil {
+		return nil, err
+	}
+	resp := toCollaboratorResponse(collaborator)
+	return &resp, nil
+}
+
+func (u *CollectionUsecase) AcceptInvitation(id string, userID string) (*dto.CollaboratorResponse, error) {
+	collaborator, err := u.CollectionRepo.FindCollaborator(id, userID)
+	if err != nil {
+		return nil, errors.New("invitation not found")
+	}
+	if collaborator.Status != domain.InvitationAccepted {
+		collaborator.Status = domain.InvitationAccepted
+		if err := u.CollectionRepo.UpdateCollaborator(collaborator); err != nil {
+			return nil, err
+		}
+	}
+	resp := toCollaboratorResponse(collaborator)
+	return &resp, nil
+}
+
+// RemoveCollaborator revokes an invitation. The owner can remove anyone;
+// collaborators can only remove themselves, which also declines an invitation.
+func (u *CollectionUsecase) RemoveCollaborator(id, collaboratorID string, userID string) error {
+	collection, err := u.CollectionRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if collection.OwnerID.String() != userID && collaboratorID != userID {
+		return errors.New("forbidden: you do not own this collection")
+	}
+	collaborator, err := u.CollectionRepo.FindCollaborator(id, collaboratorID)
+	if err != nil {
+		return err
+	}
+	return u.CollectionRepo.RemoveCollaborator(collaborator)
+}
+
+func (u *CollectionUsecase) findOwnedCollection(id, userID string) (*domain.Collection, error) {
+	collection, err := u.CollectionRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.OwnerID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this collection")
+	}
+	return collection, nil
+}
+
+func (u *CollectionUsecase) findEditableCollection(id, userID string) (*domain.Collection, error) {
+	collection, err := u.CollectionRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, canEdit := u.access(collection, userID); !canEdit {
+		return nil, errors.New("forbidden: you cannot edit this collection")
+	}
+	return collection, nil
+}
+
+func (u *CollectionUsecase) access(collection *domain.Collection, userID string) (canView, canEdit bool) {
+	if userID != "" && collection.OwnerID.String() == userID {
+		return true, true
+	}
+	canView = collection.Visibility != domain.VisibilityPrivate
+	if userID == "" {
+		return canView, false
+	}
+	collaborator, err := u.CollectionRepo.FindCollaborator(collection.ID.String(), userID)
+	if err != nil || collaborator.Status != domain.InvitationAccepted {
+		return canView, false
+	}
+	return true, collaborator.CanEdit
+}
+
+func toCollectionsResponse(collections []*domain.Collection, totalCount int64, req *dto.GetCollectionsRequest) *dto.GetCollectionsResponse {
+	collectionResponses := make([]dto.CollectionResponse, len(collections))
+	for i, collection := range collections {
+		collectionResponses[i] = toCollectionResponse(collection)
+	}
+	return &dto.GetCollectionsResponse{
+		Collections: collectionResponses,
+		PageNumber:  req.Page,
+		PageSize:    req.PageSize,
+		TotalSize:   totalCount,
+	}
+}
+
+func toCollectionResponse(collection *domain.Collection) dto.CollectionResponse {
+	resp := dto.CollectionResponse{
+		ID:          collection.ID.String(),
+		OwnerID:     collection.OwnerID.String(),
+		Name:        collection.Name,
+		Description: collection.Description,
+		Visibility:  collection.Visibility,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+	}
+	if collection.Visibility != domain.VisibilityPrivate {
+		resp.ShareURL = "/collections/" + collection.ID.String()
+	}
+	return resp
+}
+
+func toCollectionEntryResponse(entry *domain.CollectionEntry) dto.CollectionEntryResponse {
+	resp := dto.CollectionEntryResponse{
+		Position: entry.Position,
+		Note:     entry.Note,
+		AddedBy:  entry.AddedBy.String(),
+		AddedAt:  entry.CreatedAt,
+	}
+	if entry.Movie != nil {
+		resp.Movie = toMovieResponse(entry.Movie)
+	}
+	return resp
+}
+
+func toCollaboratorResponse(collaborator *domain.CollectionCollaborator) dto.CollaboratorResponse {
+	return dto.CollaboratorResponse{
+		UserID:    collaborator.UserID.String(),
+		CanEdit:   collaborator.CanEdit,
+		Status:    collaborator.Status,
+		InvitedAt: collaborator.CreatedAt,
+	}
+}
@@ -0,0 +1,388 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/notify"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxCommentMentions       = 10 // Further @usernames in a comment are not resolved
+	mentionExcerptLength     = 140
+	mentionNotifyTimeout     = 10 * time.Second
+	DefaultCommentEditWindow = 15 * time.Minute
+)
+
+// mentionPattern matches @username where the @ does not continue a word, so
+// email addresses are not read as mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@.])@([A-Za-z0-9]+)`)
+
+type CommentUsecase struct {
+	CommentRepo repository.CommentRepository
+	MovieRepo   repository.MovieRepository
+	UserRepo    repository.UserRepository
+	Notifier    notify.MentionNotifier // Optional
+	EditWindow  time.Duration
+}
+
+func NewCommentUsecase(commentRepo repository.CommentRepository, movieRepo repository.MovieRepository, userRepo repository.UserRepository, notifier notify.MentionNotifier, editWindow time.Duration) *CommentUsecase {
+	return &CommentUsecase{
+		CommentRepo: commentRepo,
+		MovieRepo:   movieRepo,
+		UserRepo:    userRepo,
+		Notifier:    notifier,
+		EditWindow:  editWindow,
+	}
+}
+
+// GetThreads lists a movie's top-level comments. Replies are fetched per
+// thread with GetReplies.
+func (u *CommentUsecase) GetThreads(movieID string, req *dto.GetCommentsRequest, userID, role string) (*dto.GetCommentsResponse, error) {
+	movie, err := u.viewableMovie(movieID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	comments, totalCount, err := u.CommentRepo.GetThreads(movie.ID.String(), req.Page, req.PageSize, req.Sort)
+	if err != nil {
+		return nil, err
+	}
+	return u.commentsResponse(movie, comments, req.Page, req.PageSize, totalCount, userID, role)
+}
+
+// GetReplies lists the direct replies to a comment.
+func (u *CommentUsecase) GetReplies(commentID string, req *dto.GetRepliesRequest, userID, role string) (*dto.GetCommentsResponse, error) {
+	parent, err := u.CommentRepo.FindByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := u.viewableMovie(parent.MovieID.String(), userID, role)
+	if err != nil {
+		return nil, errors.New("comment not found")
+	}
+	comments, totalCount, err := u.CommentRepo.GetReplies(commentID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return u.commentsResponse(movie, comments, req.Page, req.PageSize, totalCount, userID, role)
+}
+
+// CreateComment posts a comment on a movie, or a reply when a parent is given.
+func (u *CommentUsecase) CreateComment(movieID string, req *dto.CreateCommentRequest, userID, role string) (*dto.CommentResponse, error) {
+	movie, err := u.viewableMovie(movieID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.New("comment cannot be empty")
+	}
+
+	comment := &domain.Comment{
+		ID:        uuid.New(),
+		MovieID:   movie.ID,
+		UserID:    uuid.MustParse(userID),
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+	if req.ParentID != "" {
+		parent, err := u.CommentRepo.FindByID(req.ParentID)
+		if err != nil || parent.MovieID != movie.ID {
+			return nil, errors.New("parent comment not found")
+		}
+		if parent.Deleted || parent.Hidden {
+			return nil, errors.New("cannot reply to a removed comment")
+		}
+		comment.ParentID = &parent.ID
+	}
+	if err := u.CommentRepo.Create(comment); err != nil {
+		return nil, err
+	}
+
+	mentions, err := u.saveMentions(comment, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp := toCommentResponse(comment, mentions, true, userID)
+	return &resp, nil
+}
+
+// UpdateComment lets the author edit a comment within the edit window.
+// Users newly mentioned by the edit are notified.
+func (u *CommentUsecase) UpdateComment(commentID string, req *dto.UpdateCommentRequest, userID string) (*dto.CommentResponse, error) {
+	comment, err := u.CommentRepo.FindByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, errors.New("comment not found")
+	}
+	if comment.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this comment")
+	}
+	if comment.Hidden {
+		return nil, errors.New("forbidden: hidden comments cannot be edited")
+	}
+	if time.Since(comment.CreatedAt) > u.EditWindow {
+		return nil, errors.New("comments can only be edited for " + u.EditWindow.String() + " after posting")
+	}
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, errors.New("comment cannot be empty")
+	}
+
+	previous, err := u.CommentRepo.GetMentions([]uuid.UUID{comment.ID})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	comment.Body = body
+	comment.EditedAt = &now
+	if err := u.CommentRepo.Update(comment); err != nil {
+		return nil, err
+	}
+
+	mentions, err := u.saveMentions(comment, previous[comment.ID])
+	if err != nil {
+		return nil, err
+	}
+	resp := toCommentResponse(comment, mentions, true, userID)
+	return &resp, nil
+}
+
+// DeleteComment removes the author's comment. A comment with replies is
+// blanked instead, so the conversation below it stays in place.
+func (u *CommentUsecase) DeleteComment(commentID string, userID string) error {
+	comment, err := u.CommentRepo.FindByID(commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Deleted {
+		return errors.New("comment not found")
+	}
+	if comment.UserID.String() != userID {
+		return errors.New("forbidden: you do not own this comment")
+	}
+
+	if comment.ReplyCount == 0 {
+		err := u.CommentRepo.Delete(comment)
+		if err == nil || err.Error() != "comment not found" {
+			return err
+		}
+		// A reply arrived in the meantime
+		if comment, err = u.CommentRepo.FindByID(commentID); err != nil {
+			return err
+		}
+	}
+
+	comment.Deleted = true
+	comment.Body = ""
+	if err := u.CommentRepo.Update(comment); err != nil {
+		return err
+	}
+	return u.CommentRepo.SetMentions(comment.ID, nil)
+}
+
+// HideComment hides a comment from other readers, or shows it again. The
+// movie's owner and moderators may do this.
+func (u *CommentUsecase) HideComment(commentID string, req *dto.HideCommentRequest, hidden bool, userID, role string) (*dto.CommentResponse, error) {
+	comment, err := u.CommentRepo.FindByID(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, errors.New("comment not found")
+	}
+	movie, err := u.MovieRepo.FindByID(comment.MovieID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !canModerateComments(movie, userID, role) {
+		return nil, errors.New("forbidden: only the movie's owner and moderators can hide comments")
+	}
+
+	comment.Hidden = hidden
+	comment.HiddenBy = nil
+	comment.HiddenReason = ""
+	if hidden {
+		moderatorID := uuid.MustParse(userID)
+		comment.HiddenBy = &moderatorID
+		if req != nil {
+			comment.HiddenReason = req.Reason
+		}
+	}
+	if err := u.CommentRepo.Update(comment); err != nil {
+		return nil, err
+	}
+
+	mentions, err := u.CommentRepo.GetMentions([]uuid.UUID{comment.ID})
+	if err != nil {
+		return nil, err
+	}
+	resp := toCommentResponse(comment, mentions[comment.ID], true, userID)
+	return &resp, nil
+}
+
+func (u *CommentUsecase) viewableMovie(movieID, userID, role string) (*domain.Movie, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+	return movie, nil
+}
+
+func (u *CommentUsecase) commentsResponse(movie *domain.Movie, comments []*domain.Comment, page, pageSize int, totalCount int64, userID, role string) (*dto.GetCommentsResponse, error) {
+	ids := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+	mentions, err := u.CommentRepo.GetMentions(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	moderator := canModerateComments(movie, userID, role)
+	responses := make([]dto.CommentResponse, len(comments))
+	for i, comment := range comments {
+		responses[i] = toCommentResponse(comment, mentions[comment.ID], moderator, userID)
+	}
+	return &dto.GetCommentsResponse{
+		Comments:   responses,
+		PageNumber: page,
+		PageSize:   pageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
+
+// saveMentions records the users a comment mentions and notifies those not
+// already mentioned before.
+func (u *CommentUsecase) saveMentions(comment *domain.Comment, previous []domain.CommentMention) ([]domain.CommentMention, error) {
+	mentions := u.resolveMentions(comment)
+	if len(mentions) == 0 && len(previous) == 0 {
+		return nil, nil
+	}
+	if err := u.CommentRepo.SetMentions(comment.ID, mentions); err != nil {
+		return nil, err
+	}
+
+	notified := make(map[uuid.UUID]bool, len(previous))
+	for _, mention := range previous {
+		notified[mention.UserID] = true
+	}
+	var fresh []domain.CommentMention
+	for _, mention := range mentions {
+		if !notified[mention.UserID] {
+			fresh = append(fresh, mention)
+		}
+	}
+	u.notifyMentions(comment, fresh)
+	return mentions, nil
+}
+
+// resolveMentions finds the existing users a comment mentions, leaving out
+// its author.
+func (u *CommentUsecase) resolveMentions(comment *domain.Comment) []domain.CommentMention {
+	seen := make(map[string]bool)
+	var mentions []domain.CommentMention
+	for _, match := range mentionPattern.FindAllStringSubmatch(comment.Body, -1) {
+		username := match[1]
+		if seen[username] {
+			continue
+		}
+		seen[username] = true
+		if len(seen) > maxCommentMentions {
+			break
+		}
+		user, err := u.UserRepo.FindByUsername(username)
+		if err != nil || user.ID == comment.UserID {
+			continue
+		}
+		mentions = append(mentions, domain.CommentMention{
+			CommentID: comment.ID,
+			UserID:    user.ID,
+			Username:  user.Username,
+		})
+	}
+	return mentions
+}
+
+// notifyMentions hands mentions to the notifier in the background, so a slow
+// channel never holds up posting.
+func (u *CommentUsecase) notifyMentions(comment *domain.Comment, mentions []domain.CommentMention) {
+	if u.Notifier == nil || len(mentions) == 0 {
+		return
+	}
+	base := notify.Mention{
+		AuthorID:  comment.UserID.String(),
+		MovieID:   comment.MovieID.String(),
+		CommentID: comment.ID.String(),
+		Excerpt:   excerpt(comment.Body, mentionExcerptLength),
+		CreatedAt: time.Now(),
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mentionNotifyTimeout)
+		defer cancel()
+		for _, mention := range mentions {
+			notification := base
+			notification.UserID = mention.UserID.String()
+			notification.Username = mention.Username
+			if err := u.Notifier.NotifyMention(ctx, notification); err != nil {
+				log.Printf("mention notification for comment %s failed: %v", comment.ID, err)
+			}
+		}
+	}()
+}
+
+// canModerateComments reports whether the user may hide comments on the
+// movie and read hidden ones.
+func canModerateComments(movie *domain.Movie, userID, role string) bool {
+	return movie.UserID.String() == userID || domain.IsModerator(role)
+}
+
+// toCommentResponse shows a comment as the viewer may see it: removed
+// comments keep their place in the thread without their content.
+func toCommentResponse(comment *domain.Comment, mentions []domain.CommentMention, moderator bool, userID string) dto.CommentResponse {
+	resp := dto.CommentResponse{
+		ID:         comment.ID.String(),
+		MovieID:    comment.MovieID.String(),
+		UserID:     comment.UserID.String(),
+		Body:       comment.Body,
+		Mentions:   []string{},
+		ReplyCount: comment.ReplyCount,
+		Hidden:     comment.Hidden,
+		Deleted:    comment.Deleted,
+		EditedAt:   comment.EditedAt,
+		CreatedAt:  comment.CreatedAt,
+	}
+	if comment.ParentID != nil {
+		parentID := comment.ParentID.String()
+		resp.ParentID = &parentID
+	}
+	for _, mention := range mentions {
+		resp.Mentions = append(resp.Mentions, mention.Username)
+	}
+
+	switch {
+	case comment.Deleted:
+		resp.UserID = ""
+		resp.Body = ""
+		resp.Mentions = []string{}
+	case comment.Hidden && !moderator && comment.UserID.String() != userID:
+		resp.Body = ""
+		resp.Mentions = []string{}
+	case comment.Hidden:
+		resp.HiddenReason = comment.HiddenReason
+	}
+	return resp
+}
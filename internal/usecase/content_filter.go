@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/wordfilter"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// What the content filter does with text containing blocked words.
+const (
+	FilterReject = "reject" // Refuse to save it
+	FilterQueue  = "queue"  // Save it and report it to moderators
+)
+
+// ErrBlockedContent is returned when text contains blocked words and the
+// filter rejects such content.
+var ErrBlockedContent = errors.New("content contains words that are not allowed")
+
+// ContentFilter screens user-written text against a word list. A nil filter
+// lets everything through.
+type ContentFilter struct {
+	Words      *wordfilter.Filter
+	Action     string
+	ReportRepo repository.ReportRepository
+}
+
+func NewContentFilter(words *wordfilter.Filter, action string, reportRepo repository.ReportRepository) *ContentFilter {
+	return &ContentFilter{Words: words, Action: action, ReportRepo: reportRepo}
+}
+
+// screen checks text before it is saved. It fails in reject mode and
+// otherwise returns the blocked words to pass to queue once saved.
+func (f *ContentFilter) screen(texts ...string) ([]string, error) {
+	if f == nil {
+		return nil, nil
+	}
+	matches := f.Words.Match(texts...)
+	if len(matches) > 0 && f.Action != FilterQueue {
+		return nil, fmt.Errorf("%w: %s", ErrBlockedContent, strings.Join(matches, ", "))
+	}
+	return matches, nil
+}
+
+// queue puts saved content with blocked words in the moderation queue. An
+// open report from an earlier save is updated rather than repeated.
+func (f *ContentFilter) queue(targetType string, targetID, movieID uuid.UUID, matches []string) error {
+	if f == nil || len(matches) == 0 {
+		return nil
+	}
+	details := "Blocked words: " + strings.Join(matches, ", ")
+	if report, err := f.ReportRepo.FindOpenFiltered(targetType, targetID); err == nil {
+		report.Details = details
+		return f.ReportRepo.SetDetails(report)
+	}
+	return f.ReportRepo.Create(&domain.Report{
+		ID:         uuid.New(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		MovieID:    movieID,
+		Reason:     domain.ReasonFiltered,
+		Details:    details,
+		Status:     domain.ReportOpen,
+		CreatedAt:  time.Now(),
+	})
+}
@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/pkg/titles"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	duplicateSimilarity    = 0.6 // Minimum trigram similarity of two normalized titles
+	maxDuplicateCandidates = 5
+)
+
+// Reasons a movie is reported as a possible duplicate.
+const (
+	duplicateExternalID   = "external_id"
+	duplicateTitleYear    = "title_year"
+	duplicateSimilarTitle = "similar_title"
+)
+
+// DuplicateMovieError is returned when a new movie looks like one that
+// already exists. Clients may retry with allowDuplicate set.
+type DuplicateMovieError struct {
+	Candidates []dto.DuplicateCandidate
+}
+
+func (e *DuplicateMovieError) Error() string {
+	return "movie may already exist; set allowDuplicate to create it anyway"
+}
+
+// ImportConflictError is returned when movies of an import look like existing
+// movies or each other. Nothing is imported.
+type ImportConflictError struct {
+	Conflicts []dto.ImportConflict
+}
+
+func (e *ImportConflictError) Error() string {
+	return "some movies may already exist; set allowDuplicates to import them anyway"
+}
+
+// findDuplicates lists existing movies the given one may duplicate. Other
+// users' drafts are not shown, so only live movies and the creator's own
+// count.
+func (u *MovieUsecase) findDuplicates(movie *domain.Movie) ([]dto.DuplicateCandidate, error) {
+	year := releaseYear(movie.ReleaseDate)
+	matches, err := u.MovieRepo.FindDuplicates(movie.NormalizedTitle, year, movie.ImdbID, movie.TmdbID, duplicateSimilarity, maxDuplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]dto.DuplicateCandidate, 0, len(matches))
+	for _, match := range matches {
+		if match.Movie.ID == movie.ID || !canView(match.Movie, movie.UserID.String(), "") {
+			continue
+		}
+		var reasons []string
+		if sameExternalID(movie, match.Movie) {
+			reasons = append(reasons, duplicateExternalID)
+		}
+		if sameYear(year, releaseYear(match.Movie.ReleaseDate)) {
+			if match.Movie.NormalizedTitle == movie.NormalizedTitle {
+				reasons = append(reasons, duplicateTitleYear)
+			} else if match.Similarity >= duplicateSimilarity {
+				reasons = append(reasons, duplicateSimilarTitle)
+			}
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		candidates = append(candidates, dto.DuplicateCandidate{
+			Movie:      toMovieResponse(match.Movie),
+			Reasons:    reasons,
+			Similarity: match.Similarity,
+		})
+	}
+	return candidates, nil
+}
+
+// ImportMovies creates many movies at once. Unless duplicates are allowed,
+// the import is rejected as a whole when any movie may already exist.
+func (u *MovieUsecase) ImportMovies(req *dto.ImportMoviesRequest, userID string) (*dto.ImportMoviesResponse, error) {
+	movies := make([]*domain.Movie, len(req.Movies))
+	blocked := make([][]string, len(req.Movies))
+	for i, item := range req.Movies {
+		releaseDate, err := parseDate(item.ReleaseDate, fmt.Sprintf("movies[%d].releaseDate", i))
+		if err != nil {
+			return nil, err
+		}
+		movie := &domain.Movie{
+			ID:               uuid.New(),
+			Title:            item.Title,
+			NormalizedTitle:  titles.Normalize(item.Title),
+			Description:      item.Description,
+			Tagline:          item.Tagline,
+			Poster:           item.Poster,
+			PosterStatus:     domain.PosterReady,
+			Genres:           item.Genres,
+			Actors:           item.Actors,
+			UserID:           uuid.MustParse(userID),
+			ReleaseDate:      releaseDate,
+			RuntimeMinutes:   item.RuntimeMinutes,
+			OriginalLanguage: item.OriginalLanguage,
+			Countries:        item.Countries,
+			Certifications:   item.Certifications,
+			ImdbID:           item.ImdbID,
+			TmdbID:           item.TmdbID,
+			Status:           domain.MovieDraft,
+		}
+		if blocked[i], err = u.ContentFilter.screen(movie.Title, movie.Description, movie.Tagline); err != nil {
+			return nil, fmt.Errorf("movies[%d]: %w", i, err)
+		}
+		if err := u.setTrailer(movie, item.TrailerUrl); err != nil {
+			return nil, fmt.Errorf("movies[%d]: %w", i, err)
+		}
+		movies[i] = movie
+	}
+
+	if !req.AllowDuplicates {
+		var conflicts []dto.ImportConflict
+		for i, movie := range movies {
+			conflict := dto.ImportConflict{Index: i, Title: movie.Title}
+			for j := 0; j < i; j++ {
+				if sameMovie(movies[j], movie) {
+					index := j
+					conflict.DuplicateOfIndex = &index
+					break
+				}
+			}
+			candidates, err := u.findDuplicates(movie)
+			if err != nil {
+				return nil, err
+			}
+			conflict.Candidates = candidates
+			if conflict.DuplicateOfIndex != nil || len(candidates) > 0 {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+		if len(conflicts) > 0 {
+			return nil, &ImportConflictError{Conflicts: conflicts}
+		}
+	}
+
+	if err := u.MovieRepo.CreateMany(movies); err != nil {
+		return nil, err
+	}
+	resp := &dto.ImportMoviesResponse{Movies: make([]dto.MovieResponse, len(movies))}
+	for i, movie := range movies {
+		if err := u.MovieRepo.AssignSlug(movie); err != nil {
+			return nil, err
+		}
+		if err := u.PersonRepo.SyncActorCredits(movie.ID, movie.Actors); err != nil {
+			return nil, err
+		}
+		if err := u.ContentFilter.queue(domain.ReportTargetMovie, movie.ID, movie.ID, blocked[i]); err != nil {
+			return nil, err
+		}
+		resp.Movies[i] = toMovieResponse(movie)
+	}
+	return resp, nil
+}
+
+// MergeMovies folds a duplicate movie into the survivor and deletes it.
+func (u *MovieUsecase) MergeMovies(survivorID string, req *dto.MergeMoviesRequest) (*dto.MergeMoviesResponse, error) {
+	if survivorID == req.DuplicateID {
+		return nil, errors.New("a movie cannot be merged into itself")
+	}
+	survivor, err := u.MovieRepo.FindByID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := u.MovieRepo.FindByID(req.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := u.MovieRepo.Merge(survivor, duplicate)
+	if err != nil {
+		return nil, err
+	}
+
+	// The duplicate's poster files are no longer needed unless the survivor
+	// was pointed at them
+	ctx, cancel := context.WithTimeout(context.Background(), posterProcessTimeout)
+	defer cancel()
+	if survivor.PosterKey == "" || (survivor.PosterKey != duplicate.PosterKey && !hasPosterVariant(duplicate.PosterVariants, survivor.PosterKey)) {
+		u.deletePoster(ctx, duplicate.ID, duplicate.PosterKey, duplicate.PosterVariants)
+	}
+	if duplicate.PosterUploadKey != "" {
+		u.ImageStore.Delete(ctx, duplicate.PosterUploadKey)
+	}
+
+	survivor, err = u.MovieRepo.FindByID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MergeMoviesResponse{
+		Movie:                  toMovieResponse(survivor),
+		MovedReviews:           result.Reviews,
+		MovedListEntries:       result.ListEntries,
+		MovedCollectionEntries: result.CollectionEntries,
+		MovedMedia:             result.Media,
+		MovedCredits:           result.Credits,
+		MovedComments:          result.Comments,
+		MovedOffers:            result.Offers,
+		MovedScreenings:        result.Screenings,
+		MovedHistoryEntries:    result.HistoryEntries,
+		MovedReports:           result.Reports,
+	}, nil
+}
+
+// sameMovie reports whether two new movies look like the same film.
+func sameMovie(a, b *domain.Movie) bool {
+	if sameExternalID(a, b) {
+		return true
+	}
+	return a.NormalizedTitle != "" && a.NormalizedTitle == b.NormalizedTitle &&
+		sameYear(releaseYear(a.ReleaseDate), releaseYear(b.ReleaseDate))
+}
+
+func sameExternalID(a, b *domain.Movie) bool {
+	return (a.ImdbID != "" && a.ImdbID == b.ImdbID) || (a.TmdbID != "" && a.TmdbID == b.TmdbID)
+}
+
+// sameYear allows a year of difference, as release dates vary by country,
+// and treats an unknown year as matching any.
+func sameYear(a, b int) bool {
+	if a == 0 || b == 0 {
+		return true
+	}
+	return a-b <= 1 && b-a <= 1
+}
+
+func releaseYear(date *time.Time) int {
+	if date == nil {
+		return 0
+	}
+	return date.Year()
+}
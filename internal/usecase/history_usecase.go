@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// completedProgress is where a movie counts as watched, since few viewers
+// sit through the end credits.
+const completedProgress = 90.0
+
+// rewatchProgress is how far back a finished movie must be started again to
+// begin a new run rather than to look something up.
+const rewatchProgress = 10.0
+
+type HistoryUsecase struct {
+	HistoryRepo    repository.HistoryRepository
+	MovieRepo      repository.MovieRepository
+	SavedMovieRepo repository.SavedMovieRepository
+}
+
+func NewHistoryUsecase(historyRepo repository.HistoryRepository, movieRepo repository.MovieRepository, savedMovieRepo repository.SavedMovieRepository) *HistoryUsecase {
+	return &HistoryUsecase{HistoryRepo: historyRepo, MovieRepo: movieRepo, SavedMovieRepo: savedMovieRepo}
+}
+
+// RecordProgress saves how far the user got in a movie. Reaching
+// completedProgress marks the movie as watched on their watchlist. Nothing
+// is saved while the user has paused their history.
+func (u *HistoryUsecase) RecordProgress(movieID string, req *dto.RecordProgressRequest, userID, role string) (*dto.RecordProgressResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+
+	var progress float64
+	position := 0
+	if req.PositionSeconds != nil {
+		position = *req.PositionSeconds
+	}
+	switch {
+	case req.Progress != nil:
+		progress = *req.Progress
+	case req.PositionSeconds == nil:
+		return nil, errors.New("invalid progress: give progress or positionSeconds")
+	case movie.RuntimeMinutes > 0:
+		progress = math.Min(100, float64(position)/float64(movie.RuntimeMinutes*60)*100)
+	default:
+		return nil, errors.New("invalid progress: the movie has no runtime, so give progress as a percentage")
+	}
+	progress = math.Round(progress*10) / 10
+
+	settings, err := u.HistoryRepo.GetSettings(userUUID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Paused {
+		return &dto.RecordProgressResponse{Recorded: false}, nil
+	}
+
+	now := time.Now()
+	entry, err := u.HistoryRepo.Find(userUUID, movie.ID)
+	if ignoreNotFound(err, "movie is not in your history") != nil {
+		return nil, err
+	}
+	if entry == nil {
+		entry = &domain.WatchHistory{UserID: userUUID, MovieID: movie.ID, StartedAt: now}
+	} else if entry.Completed && progress < rewatchProgress {
+		entry.Completed = false
+		entry.StartedAt = now
+	}
+	// A finished run stays finished when the user skips around afterwards
+	justCompleted := false
+	if !entry.Completed {
+		entry.Progress = progress
+		entry.PositionSeconds = position
+		if progress >= completedProgress {
+			justCompleted = true
+			entry.Completed = true
+			entry.TimesCompleted++
+			entry.CompletedAt = &now
+		}
+	}
+	entry.LastWatchedAt = now
+	if err := u.HistoryRepo.Save(entry); err != nil {
+		return nil, err
+	}
+	if justCompleted {
+		if err := u.SavedMovieRepo.MarkWatched(userUUID, movie.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	entry.Movie = movie
+	resp := toHistoryEntryResponse(entry)
+	return &dto.RecordProgressResponse{Recorded: true, Entry: &resp}, nil
+}
+
+// GetHistory lists what the user watched, most recent first, leaving out
+// movies they can no longer see.
+func (u *HistoryUsecase) GetHistory(req *dto.GetHistoryRequest, userID, role string) (*dto.GetHistoryResponse, error) {
+	filter := repository.HistoryFilter{Completed: req.Completed, AllMovies: domain.IsEditor(role)}
+	entries, total, err := u.getHistory(userID, filter, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.GetHistoryResponse{
+		Entries:    entries,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  total,
+	}, nil
+}
+
+// GetContinueWatching lists the movies the user started and has not
+// finished and can still see, most recent first.
+func (u *HistoryUsecase) GetContinueWatching(req *dto.GetContinueWatchingRequest, userID, role string) ([]dto.HistoryEntryResponse, error) {
+	filter := repository.HistoryFilter{InProgress: true, AllMovies: domain.IsEditor(role)}
+	entries, _, err := u.getHistory(userID, filter, 1, req.Limit)
+	return entries, err
+}
+
+func (u *HistoryUsecase) DeleteEntry(movieID, userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID")
+	}
+	movieUUID, err := uuid.Parse(movieID)
+	if err != nil {
+		return errors.New("movie is not in your history")
+	}
+	return u.HistoryRepo.Delete(userUUID, movieUUID)
+}
+
+// ClearHistory deletes the user's whole history. Watchlist entries already
+// marked as watched stay so.
+func (u *HistoryUsecase) ClearHistory(userID string) (*dto.ClearHistoryResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	deleted, err := u.HistoryRepo.Clear(userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ClearHistoryResponse{Deleted: deleted}, nil
+}
+
+func (u *HistoryUsecase) GetSettings(userID string) (*dto.HistorySettingsResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	settings, err := u.HistoryRepo.GetSettings(userUUID)
+	if err != nil {
+		return nil, err
+	}
+	return toHistorySettingsResponse(settings), nil
+}
+
+// UpdateSettings changes the user's history settings. A shorter retention
+// period applies right away.
+func (u *HistoryUsecase) UpdateSettings(req *dto.HistorySettingsRequest, userID string) (*dto.HistorySettingsResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+	settings := &domain.HistorySettings{
+		UserID:        userUUID,
+		Paused:        req.Paused,
+		RetentionDays: req.RetentionDays,
+		UpdatedAt:     time.Now(),
+	}
+	if err := u.HistoryRepo.SaveSettings(settings); err != nil {
+		return nil, err
+	}
+	if settings.RetentionDays > 0 {
+		if _, err := u.HistoryRepo.Prune(&userUUID); err != nil {
+			return nil, err
+		}
+	}
+	return toHistorySettingsResponse(settings), nil
+}
+
+// PruneHistory deletes the entries that outlived their user's retention
+// period.
+func (u *HistoryUsecase) PruneHistory() (int64, error) {
+	return u.HistoryRepo.Prune(nil)
+}
+
+func (u *HistoryUsecase) getHistory(userID string, filter repository.HistoryFilter, page, pageSize int) ([]dto.HistoryEntryResponse, int64, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, 0, errors.New("invalid user ID")
+	}
+	entries, total, err := u.HistoryRepo.GetHistory(userUUID, filter, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp := make([]dto.HistoryEntryResponse, len(entries))
+	for i, entry := range entries {
+		resp[i] = toHistoryEntryResponse(entry)
+	}
+	return resp, total, nil
+}
+
+func toHistoryEntryResponse(entry *domain.WatchHistory) dto.HistoryEntryResponse {
+	resp := dto.HistoryEntryResponse{
+		ID:              entry.ID.String(),
+		MovieID:         entry.MovieID.String(),
+		Progress:        entry.Progress,
+		PositionSeconds: entry.PositionSeconds,
+		Completed:       entry.Completed,
+		TimesCompleted:  entry.TimesCompleted,
+		CompletedAt:     entry.CompletedAt,
+		StartedAt:       entry.StartedAt,
+		LastWatchedAt:   entry.LastWatchedAt,
+	}
+	if entry.Movie != nil {
+		movie := toMovieResponse(entry.Movie)
+		resp.Movie = &movie
+	}
+	return resp
+}
+
+func toHistorySettingsResponse(settings *domain.HistorySettings) *dto.HistorySettingsResponse {
+	resp := &dto.HistorySettingsResponse{Paused: settings.Paused, RetentionDays: settings.RetentionDays}
+	if !settings.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &settings.UpdatedAt
+	}
+	return resp
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/pkg/imaging"
+	"eskalate-movie-api/pkg/storage"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// storeImage validates and processes an uploaded image and stores every
+// variant under prefix. Each upload gets its own name so a replacement never
+// overwrites the files of the image it replaces. Variants that were already
+// stored are removed again when a later one fails.
+func storeImage(ctx context.Context, store storage.ImageStore, prefix string, file io.Reader, limits imaging.Limits, sizes []imaging.Size) ([]domain.ImageVariant, error) {
+	processed, err := imaging.Process(file, limits, sizes)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadID := uuid.New().String()[:8]
+	variants := make([]domain.ImageVariant, 0, len(processed))
+	for _, image := range processed {
+		key := fmt.Sprintf("%s/%s-%s%s", prefix, uploadID, image.Name, image.Extension)
+		object, err := store.Put(ctx, key, bytes.NewReader(image.Data), int64(len(image.Data)), image.ContentType)
+		if err != nil {
+			deleteImage(ctx, store, "", variants)
+			return nil, errors.New("failed to upload image")
+		}
+		variants = append(variants, domain.ImageVariant{
+			Name:   image.Name,
+			Format: image.Format,
+			Key:    object.Key,
+			URL:    object.URL,
+			Width:  image.Width,
+			Height: image.Height,
+		})
+	}
+	return variants, nil
+}
+
+// deleteImage removes a stored image and its variants on a best-effort basis.
+// key covers images stored as a single file before variants existed.
+func deleteImage(ctx context.Context, store storage.ImageStore, key string, variants []domain.ImageVariant) {
+	for _, variant := range variants {
+		if variant.Key == key {
+			key = ""
+		}
+		store.Delete(ctx, variant.Key)
+	}
+	if key != "" {
+		store.Delete(ctx, key)
+	}
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/storage"
+	"fmt"
+	"time"
+)
+
+// ImageGCUsecase finds stored images that no movie or media item refers to,
+// such as uploads whose movie was never saved or posters left behind by an
+// update, and deletes them.
+type ImageGCUsecase struct {
+	MovieRepo  repository.MovieRepository
+	MediaRepo  repository.MediaRepository
+	ImageStore storage.ImageStore
+}
+
+func NewImageGCUsecase(movieRepo repository.MovieRepository, mediaRepo repository.MediaRepository, imageStore storage.ImageStore) *ImageGCUsecase {
+	return &ImageGCUsecase{MovieRepo: movieRepo, MediaRepo: mediaRepo, ImageStore: imageStore}
+}
+
+// CollectOrphans deletes unreferenced images older than gracePeriod, or only
+// reports them when dryRun is set. The grace period protects uploads whose
+// movie or media row is not committed yet.
+func (u *ImageGCUsecase) CollectOrphans(ctx context.Context, gracePeriod time.Duration, dryRun bool) (*dto.ImageGCReport, error) {
+	// List before loading references so that an image referenced between
+	// the two steps is never mistaken for an orphan
+	var objects []storage.ObjectInfo
+	for _, folder := range []string{posterFolder, posterUploadFolder, mediaFolder} {
+		listed, err := u.ImageStore.List(ctx, folder+"/")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", folder, err)
+		}
+		objects = append(objects, listed...)
+	}
+
+	referenced, err := u.referencedKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ImageGCReport{
+		DryRun:      dryRun,
+		GracePeriod: gracePeriod.String(),
+		Scanned:     len(objects),
+		Orphans:     []dto.OrphanedImage{},
+	}
+	cutoff := time.Now().Add(-gracePeriod)
+	for _, object := range objects {
+		switch {
+		case referenced[object.Key]:
+			report.Referenced++
+		case object.LastModified.After(cutoff):
+			report.TooRecent++
+		default:
+			report.Orphans = append(report.Orphans, dto.OrphanedImage{
+				Key:          object.Key,
+				Size:         object.Size,
+				LastModified: object.LastModified,
+			})
+			report.OrphanBytes += object.Size
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+	for _, orphan := range report.Orphans {
+		if err := u.ImageStore.Delete(ctx, orphan.Key); err != nil {
+			report.Failed = append(report.Failed, orphan.Key+": "+err.Error())
+			continue
+		}
+		report.Deleted++
+	}
+	return report, nil
+}
+
+func (u *ImageGCUsecase) referencedKeys() (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	add := func(key, url string) {
+		if key != "" {
+			referenced[key] = true
+		}
+		// Posters set through JSON updates may only be known by their URL
+		if key, ok := u.ImageStore.KeyFromURL(url); ok {
+			referenced[key] = true
+		}
+	}
+
+	movies, err := u.MovieRepo.GetPosterReferences()
+	if err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		add(movie.PosterKey, movie.Poster)
+		add(movie.PosterUploadKey, "")
+		for _, variant := range movie.PosterVariants {
+			add(variant.Key, variant.URL)
+		}
+	}
+
+	media, err := u.MediaRepo.GetImageReferences()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range media {
+		add(item.Key, item.URL)
+		for _, variant := range item.Variants {
+			add(variant.Key, variant.URL)
+		}
+	}
+	return referenced, nil
+}
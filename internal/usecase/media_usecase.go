@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/imaging"
+	"eskalate-movie-api/pkg/storage"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+const mediaFolder = "movie-media"
+
+type MediaUsecase struct {
+	MediaRepo  repository.MediaRepository
+	MovieRepo  repository.MovieRepository
+	ImageStore storage.ImageStore
+}
+
+func NewMediaUsecase(mediaRepo repository.MediaRepository, movieRepo repository.MovieRepository, imageStore storage.ImageStore) *MediaUsecase {
+	return &MediaUsecase{MediaRepo: mediaRepo, MovieRepo: movieRepo, ImageStore: imageStore}
+}
+
+func (u *MediaUsecase) UploadMedia(movieID string, req *dto.UploadMediaRequest, file io.Reader, userID string) (*dto.MediaResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+
+	ctx := context.Background()
+	variants, err := storeImage(ctx, u.ImageStore, mediaFolder+"/"+movie.ID.String(), file, imaging.MediaLimits, imaging.MediaSizes)
+	if err != nil {
+		return nil, err
+	}
+	media := &domain.MovieMedia{
+		ID:         uuid.New(),
+		MovieID:    movie.ID,
+		Kind:       req.Kind,
+		Caption:    req.Caption,
+		Language:   req.Language,
+		IsPrimary:  req.IsPrimary,
+		Variants:   variants,
+		UploadedBy: uuid.MustParse(userID),
+	}
+	for _, variant := range variants {
+		if variant.Name == "full" && variant.Format == imaging.FormatJPEG {
+			media.URL = variant.URL
+			media.Key = variant.Key
+			media.Width = variant.Width
+			media.Height = variant.Height
+		}
+	}
+	if err := u.MediaRepo.Create(media); err != nil {
+		deleteImage(ctx, u.ImageStore, "", variants)
+		return nil, err
+	}
+	resp := toMediaResponse(media)
+	return &resp, nil
+}
+
+func (u *MediaUsecase) UpdateMedia(movieID, mediaID string, req *dto.UpdateMediaRequest, userID string) (*dto.MediaResponse, error) {
+	media, err := u.findOwnedMedia(movieID, mediaID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Caption != nil {
+		media.Caption = *req.Caption
+	}
+	if req.Language != nil {
+		if len(*req.Language) == 1 {
+			return nil, errors.New("language must be a two-letter code or empty")
+		}
+		media.Language = *req.Language
+	}
+	if req.IsPrimary != nil {
+		media.IsPrimary = *req.IsPrimary
+	}
+	if err := u.MediaRepo.Update(media); err != nil {
+		return nil, err
+	}
+	if req.Position != nil {
+		if err := u.MediaRepo.Move(media, *req.Position); err != nil {
+			return nil, err
+		}
+	}
+	resp := toMediaResponse(media)
+	return &resp, nil
+}
+
+func (u *MediaUsecase) DeleteMedia(movieID, mediaID string, userID string) error {
+	media, err := u.findOwnedMedia(movieID, mediaID, userID)
+	if err != nil {
+		return err
+	}
+	if err := u.MediaRepo.Delete(media); err != nil {
+		return err
+	}
+	deleteImage(context.Background(), u.ImageStore, media.Key, media.Variants)
+	return nil
+}
+
+func (u *MediaUsecase) GetMovieMedia(movieID string, req *dto.GetMediaRequest, userID, role string) (*dto.GetMediaResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+	media, totalCount, err := u.MediaRepo.GetMovieMedia(movieID, req.Page, req.PageSize, req.Kind, req.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaResponses := make([]dto.MediaResponse, len(media))
+	for i, item := range media {
+		mediaResponses[i] = toMediaResponse(item)
+	}
+
+	return &dto.GetMediaResponse{
+		Media:      mediaResponses,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
+
+func (u *MediaUsecase) findOwnedMedia(movieID, mediaID, userID string) (*domain.MovieMedia, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+	media, err := u.MediaRepo.FindByID(mediaID)
+	if err != nil {
+		return nil, err
+	}
+	if media.MovieID != movie.ID {
+		return nil, errors.New("media not found")
+	}
+	return media, nil
+}
+
+func toMediaResponse(media *domain.MovieMedia) dto.MediaResponse {
+	return dto.MediaResponse{
+		ID:        media.ID.String(),
+		Kind:      media.Kind,
+		Caption:   media.Caption,
+		Language:  media.Language,
+		IsPrimary: media.IsPrimary,
+		Position:  media.Position,
+		URL:       media.URL,
+		Width:     media.Width,
+		Height:    media.Height,
+		Images:    toImageResponses(media.Variants),
+		CreatedAt: media.CreatedAt,
+	}
+}
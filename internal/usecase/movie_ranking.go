@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+)
+
+// GetTrendingMovies lists the movies most viewed lately, recent days
+// weighing most.
+func (u *MovieUsecase) GetTrendingMovies(req *dto.GetRankedMoviesRequest, userID string) (*dto.GetRankedMoviesResponse, error) {
+	return u.getRankedMovies(repository.RankTrending, req, userID)
+}
+
+// GetPopularMovies lists the movies most viewed of all time.
+func (u *MovieUsecase) GetPopularMovies(req *dto.GetRankedMoviesRequest, userID string) (*dto.GetRankedMoviesResponse, error) {
+	return u.getRankedMovies(repository.RankPopular, req, userID)
+}
+
+func (u *MovieUsecase) getRankedMovies(ranking string, req *dto.GetRankedMoviesRequest, userID string) (*dto.GetRankedMoviesResponse, error) {
+	movies, scores, totalCount, err := u.ViewRepo.GetRanking(ranking, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	movieResponses, err := u.movieListResponses(movies, userID, req.Locales)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]dto.RankedMovieResponse, len(movies))
+	for i, movie := range movies {
+		score := scores[movie.ID]
+		ranked[i] = dto.RankedMovieResponse{
+			MovieResponse: movieResponses[i],
+			Rank:          (req.Page-1)*req.PageSize + i + 1,
+			TrendingScore: score.TrendingScore,
+			TotalViews:    score.TotalViews,
+			ComputedAt:    score.ComputedAt,
+		}
+	}
+	return &dto.GetRankedMoviesResponse{
+		Movies:     ranked,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
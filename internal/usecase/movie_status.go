@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type statusTransition struct {
+	from, to string
+}
+
+// ownerTransitions are the status changes a movie's owner may make: submit
+// for review, withdraw from review, archive and rework.
+var ownerTransitions = map[statusTransition]bool{
+	{domain.MovieDraft, domain.MovieInReview}:     true,
+	{domain.MovieInReview, domain.MovieDraft}:     true,
+	{domain.MoviePublished, domain.MovieArchived}: true,
+	{domain.MovieArchived, domain.MovieDraft}:     true,
+}
+
+// editorTransitions are the status changes reserved for editors, on top of
+// those an owner may make on any movie: publishing and unpublishing.
+var editorTransitions = map[statusTransition]bool{
+	{domain.MovieInReview, domain.MoviePublished}: true,
+	{domain.MovieDraft, domain.MoviePublished}:    true,
+	{domain.MovieArchived, domain.MoviePublished}: true,
+	{domain.MoviePublished, domain.MovieDraft}:    true,
+}
+
+// ChangeMovieStatus moves a movie through its publishing workflow. Editors
+// sending a movie in review back to draft must say why.
+func (u *MovieUsecase) ChangeMovieStatus(movieID string, req *dto.ChangeMovieStatusRequest, userID, role string) (*dto.MovieStatusResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	isOwner := movie.UserID.String() == userID
+	isEditor := domain.IsEditor(role)
+	if !isOwner && !isEditor {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+
+	transition := statusTransition{movie.Status, req.Status}
+	if !ownerTransitions[transition] && !editorTransitions[transition] {
+		return nil, errors.New("a movie cannot go from " + movie.Status + " to " + req.Status)
+	}
+	if editorTransitions[transition] && !isEditor {
+		return nil, errors.New("forbidden: only editors can move a movie from " + movie.Status + " to " + req.Status)
+	}
+	if transition == (statusTransition{domain.MovieInReview, domain.MovieDraft}) && !isOwner && req.Comment == "" {
+		return nil, errors.New("a comment is required to reject a movie")
+	}
+
+	now := time.Now()
+	if req.PublishAt != nil {
+		if req.Status != domain.MoviePublished {
+			return nil, errors.New("publishAt only applies when publishing")
+		}
+		if !req.PublishAt.After(now) {
+			return nil, errors.New("publishAt must be in the future")
+		}
+	}
+
+	fromStatus := movie.Status
+	movie.Status = req.Status
+	movie.PublishAt = nil
+	switch req.Status {
+	case domain.MoviePublished:
+		movie.PublishAt = &now
+		if req.PublishAt != nil {
+			movie.PublishAt = req.PublishAt
+		}
+	case domain.MovieInReview:
+		movie.SubmittedAt = &now
+	}
+
+	change := &domain.MovieStatusChange{
+		ID:         uuid.New(),
+		MovieID:    movie.ID,
+		FromStatus: fromStatus,
+		ToStatus:   req.Status,
+		Comment:    req.Comment,
+		ChangedBy:  uuid.MustParse(userID),
+		CreatedAt:  now,
+	}
+	if err := u.MovieRepo.SetStatus(movie, fromStatus, change); err != nil {
+		return nil, err
+	}
+	return u.movieStatusResponse(movie)
+}
+
+// ApproveMovie publishes a movie in review, right away or at req.PublishAt.
+func (u *MovieUsecase) ApproveMovie(movieID string, req *dto.ReviewDecisionRequest, userID, role string) (*dto.MovieStatusResponse, error) {
+	if err := u.requireInReview(movieID); err != nil {
+		return nil, err
+	}
+	return u.ChangeMovieStatus(movieID, &dto.ChangeMovieStatusRequest{
+		Status:    domain.MoviePublished,
+		Comment:   req.Comment,
+		PublishAt: req.PublishAt,
+	}, userID, role)
+}
+
+// RejectMovie sends a movie in review back to its owner as a draft.
+func (u *MovieUsecase) RejectMovie(movieID string, req *dto.ReviewDecisionRequest, userID, role string) (*dto.MovieStatusResponse, error) {
+	if req.Comment == "" {
+		return nil, errors.New("a comment is required to reject a movie")
+	}
+	if req.PublishAt != nil {
+		return nil, errors.New("publishAt only applies when publishing")
+	}
+	if err := u.requireInReview(movieID); err != nil {
+		return nil, err
+	}
+	return u.ChangeMovieStatus(movieID, &dto.ChangeMovieStatusRequest{
+		Status:  domain.MovieDraft,
+		Comment: req.Comment,
+	}, userID, role)
+}
+
+func (u *MovieUsecase) requireInReview(movieID string) error {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return err
+	}
+	if movie.Status != domain.MovieInReview {
+		return errors.New("movie is not in review")
+	}
+	return nil
+}
+
+// GetMovieStatus shows the owner or an editor where a movie stands and the
+// comments it received.
+func (u *MovieUsecase) GetMovieStatus(movieID string, userID, role string) (*dto.MovieStatusResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID && !domain.IsEditor(role) {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+	return u.movieStatusResponse(movie)
+}
+
+// GetReviewQueue lists the movies waiting for an editor.
+func (u *MovieUsecase) GetReviewQueue(req *dto.GetReviewQueueRequest) (*dto.GetMoviesResponse, error) {
+	movies, totalCount, err := u.MovieRepo.GetReviewQueue(req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	movieResponses := make([]dto.MovieResponse, len(movies))
+	for i, movie := range movies {
+		movieResponses[i] = toMovieResponse(movie)
+	}
+	return &dto.GetMoviesResponse{
+		Movies:     movieResponses,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
+
+func (u *MovieUsecase) movieStatusResponse(movie *domain.Movie) (*dto.MovieStatusResponse, error) {
+	changes, err := u.MovieRepo.GetStatusHistory(movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]dto.StatusChangeResponse, len(changes))
+	for i, change := range changes {
+		history[i] = dto.StatusChangeResponse{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			Comment:    change.Comment,
+			ChangedBy:  change.ChangedBy.String(),
+			CreatedAt:  change.CreatedAt,
+		}
+	}
+	return &dto.MovieStatusResponse{
+		Status:    movie.Status,
+		PublishAt: movie.PublishAt,
+		History:   history,
+	}, nil
+}
+
+// isLive reports whether the public may see the movie.
+func isLive(movie *domain.Movie, now time.Time) bool {
+	return movie.Status == domain.MoviePublished && (movie.PublishAt == nil || !movie.PublishAt.After(now))
+}
+
+// canView reports whether the user may see the movie: anyone once it is
+// live, before that only its owner and editors.
+func canView(movie *domain.Movie, userID, role string) bool {
+	return isLive(movie, time.Now()) || movie.UserID.String() == userID || domain.IsEditor(role)
+}
@@ -1,61 +1,122 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"eskalate-movie-api/internal/domain"
 	"eskalate-movie-api/internal/dto"
 	"eskalate-movie-api/internal/repository"
-	"eskalate-movie-api/pkg/cloudinary"
-	"mime/multipart"
-	"regexp"
+	"eskalate-movie-api/pkg/storage"
+	"eskalate-movie-api/pkg/titles"
+	"eskalate-movie-api/pkg/trailer"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type MovieUsecase struct {
-	MovieRepo repository.MovieRepository
+	MovieRepo       repository.MovieRepository
+	PersonRepo      repository.PersonRepository
+	SavedMovieRepo  repository.SavedMovieRepository
+	MediaRepo       repository.MediaRepository
+	TranslationRepo repository.TranslationRepository
+	ImageStore      storage.ImageStore
+	PosterWorkers   *PosterWorkerPool
+	Trailers        trailer.Verifier // Optional; trailers are only parsed when nil
+	ContentFilter   *ContentFilter   // Optional
+	ViewRepo        repository.ViewRepository
+	Views           *ViewTracker // Optional; views are not counted when nil
 }
 
-func NewMovieUsecase(movieRepo repository.MovieRepository) *MovieUsecase {
-	return &MovieUsecase{MovieRepo: movieRepo}
+func NewMovieUsecase(movieRepo repository.MovieRepository, personRepo repository.PersonRepository, savedMovieRepo repository.SavedMovieRepository, mediaRepo repository.MediaRepository, translationRepo repository.TranslationRepository, imageStore storage.ImageStore, posterWorkers *PosterWorkerPool, trailers trailer.Verifier, contentFilter *ContentFilter, viewRepo repository.ViewRepository, views *ViewTracker) *MovieUsecase {
+	return &MovieUsecase{MovieRepo: movieRepo, PersonRepo: personRepo, SavedMovieRepo: savedMovieRepo, MediaRepo: mediaRepo, TranslationRepo: translationRepo, ImageStore: imageStore, PosterWorkers: posterWorkers, Trailers: trailers, ContentFilter: contentFilter, ViewRepo: viewRepo, Views: views}
 }
 
-func (u *MovieUsecase) CreateMovie(req *dto.CreateMovieRequest, posterFile multipart.File, posterHeader *multipart.FileHeader, userID string) (*dto.CreateMovieResponse, error) {
-	if !isValidYouTubeURL(req.TrailerUrl) {
-		return nil, errors.New("trailerUrl must be a valid YouTube URL")
-	}
-	posterURL, err := cloudinary.UploadPoster(posterFile, posterHeader)
+func (u *MovieUsecase) CreateMovie(req *dto.CreateMovieRequest, posterFile io.Reader, userID string) (*dto.CreateMovieResponse, error) {
+	releaseDate, err := parseDate(req.ReleaseDate, "releaseDate")
 	if err != nil {
-		return nil, errors.New("failed to upload poster")
+		return nil, err
 	}
 	movie := &domain.Movie{
-		ID:          uuid.New(),
-		Title:       req.Title,
-		Description: req.Description,
-		Genres:      req.Genres,
-		Actors:      req.Actors,
-		Trailer:     req.TrailerUrl,
-		Poster:      posterURL,
-		UserID:      uuid.MustParse(userID),
+		ID:               uuid.New(),
+		Title:            req.Title,
+		NormalizedTitle:  titles.Normalize(req.Title),
+		Description:      req.Description,
+		Tagline:          req.Tagline,
+		Genres:           req.Genres,
+		Actors:           req.Actors,
+		UserID:           uuid.MustParse(userID),
+		ReleaseDate:      releaseDate,
+		RuntimeMinutes:   req.RuntimeMinutes,
+		OriginalLanguage: req.OriginalLanguage,
+		Countries:        req.Countries,
+		Certifications:   req.Certifications,
+		ImdbID:           req.ImdbID,
+		TmdbID:           req.TmdbID,
+		Status:           domain.MovieDraft, // Public once an editor publishes it
 	}
-	err = u.MovieRepo.Create(movie)
+	blocked, err := u.ContentFilter.screen(movie.Title, movie.Description, movie.Tagline)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.CreateMovieResponse{
-		ID:          movie.ID.String(),
-		Title:       movie.Title,
-		Description: movie.Description,
-		Genres:      movie.Genres,
-		Actors:      movie.Actors,
-		TrailerUrl:  movie.Trailer,
-		Poster:      movie.Poster,
-	}, nil
-}
+	if !req.AllowDuplicate {
+		candidates, err := u.findDuplicates(movie)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) > 0 {
+			return nil, &DuplicateMovieError{Candidates: candidates}
+		}
+	}
+	if err := u.setTrailer(movie, req.TrailerUrl); err != nil {
+		return nil, err
+	}
 
-func isValidYouTubeURL(url string) bool {
-	ytRegex := regexp.MustCompile(`^(https?://)?(www\.)?(youtube\.com|youtu\.be)/.+$`)
-	return ytRegex.MatchString(url)
+	ctx, cancel := context.WithTimeout(context.Background(), posterProcessTimeout)
+	defer cancel()
+
+	// Asynchronous uploads only stage the original; the movie is created
+	// right away with a pending poster
+	var posterVariants []domain.ImageVariant
+	var uploadKey string
+	if req.Async {
+		uploadKey, err = u.stagePoster(ctx, movie.ID, posterFile)
+	} else {
+		posterVariants, err = u.uploadPoster(ctx, movie.ID, posterFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.Async {
+		setPosterUpload(movie, uploadKey)
+	} else {
+		setPoster(movie, posterVariants)
+	}
+	err = u.MovieRepo.Create(movie)
+	if err != nil {
+		// Do not leave the uploaded poster behind
+		u.deletePoster(ctx, movie.ID, "", posterVariants)
+		if uploadKey != "" {
+			u.ImageStore.Delete(ctx, uploadKey)
+		}
+		return nil, err
+	}
+	if req.Async {
+		u.PosterWorkers.Enqueue(movie.ID)
+	}
+	if err := u.MovieRepo.AssignSlug(movie); err != nil {
+		return nil, err
+	}
+	if err := u.PersonRepo.SyncActorCredits(movie.ID, movie.Actors); err != nil {
+		return nil, err
+	}
+	if err := u.ContentFilter.queue(domain.ReportTargetMovie, movie.ID, movie.ID, blocked); err != nil {
+		return nil, err
+	}
+	resp := toMovieResponse(movie)
+	return &resp, nil
 }
 
 func (u *MovieUsecase) UpdateMovie(id string, req *dto.UpdateMovieRequest, userID string) (*dto.UpdateMovieResponse, error) {
@@ -66,46 +127,108 @@ func (u *MovieUsecase) UpdateMovie(id string, req *dto.UpdateMovieRequest, userI
 	if movie.UserID.String() != userID {
 		return nil, errors.New("forbidden: you do not own this movie")
 	}
-	if !isValidYouTubeURL(req.TrailerUrl) {
-		return nil, errors.New("trailerUrl must be a valid YouTube URL")
+	blocked, err := u.ContentFilter.screen(req.Title, req.Description, req.Tagline)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.setTrailer(movie, req.TrailerUrl); err != nil {
+		return nil, err
+	}
+	releaseDate, err := parseDate(req.ReleaseDate, "releaseDate")
+	if err != nil {
+		return nil, err
 	}
 	movie.Title = req.Title
+	movie.NormalizedTitle = titles.Normalize(req.Title)
 	movie.Description = req.Description
+	movie.Tagline = req.Tagline
 	movie.Genres = req.Genres
 	movie.Actors = req.Actors
-	movie.Trailer = req.TrailerUrl
-	movie.Poster = req.Poster
+	oldPoster := movie.Poster
+	if req.Poster != oldPoster {
+		// New images go through PUT /movies/:id/poster; a JSON update may only
+		// point at a poster already uploaded for this movie, such as another
+		// variant
+		key, ok := u.ImageStore.KeyFromURL(req.Poster)
+		if !ok || !ownsPosterKey(movie.ID, key) {
+			return nil, errors.New("poster must be a URL of an image uploaded for this movie; upload new posters via PUT /movies/:id/poster")
+		}
+		if !hasPosterVariant(movie.PosterVariants, key) {
+			movie.PosterVariants = nil
+		}
+		movie.Poster = req.Poster
+		movie.PosterKey = key
+		clearPosterUpload(movie)
+	}
+	movie.ReleaseDate = releaseDate
+	movie.RuntimeMinutes = req.RuntimeMinutes
+	movie.OriginalLanguage = req.OriginalLanguage
+	movie.Countries = req.Countries
+	movie.Certifications = req.Certifications
+	movie.ImdbID = req.ImdbID
+	movie.TmdbID = req.TmdbID
 	if err := u.MovieRepo.Update(movie); err != nil {
 		return nil, err
 	}
-	return &dto.UpdateMovieResponse{
-		ID:          movie.ID.String(),
-		Title:       movie.Title,
-		Description: movie.Description,
-		Genres:      movie.Genres,
-		Actors:      movie.Actors,
-		TrailerUrl:  movie.Trailer,
-		Poster:      movie.Poster,
-	}, nil
+	if movie.Poster != oldPoster {
+		if err := u.MovieRepo.ReplacePoster(movie, oldPoster); err != nil {
+			return nil, err
+		}
+	}
+	// A new title or release year gets a new slug; the old one keeps working
+	if err := u.MovieRepo.AssignSlug(movie); err != nil {
+		return nil, err
+	}
+	if err := u.PersonRepo.SyncActorCredits(movie.ID, movie.Actors); err != nil {
+		return nil, err
+	}
+	if err := u.ContentFilter.queue(domain.ReportTargetMovie, movie.ID, movie.ID, blocked); err != nil {
+		return nil, err
+	}
+	resp := toMovieResponse(movie)
+	return &resp, nil
 }
 
-func (u *MovieUsecase) GetMovies(req *dto.GetMoviesRequest) (*dto.GetMoviesResponse, error) {
-	movies, totalCount, err := u.MovieRepo.GetMovies(req.Page, req.PageSize, req.Title)
+// GetMovies lists movies. userID is optional; when set, each movie is flagged
+// with whether it is on that user's watchlist or favorites. Only live movies
+// are listed unless a status is asked for.
+func (u *MovieUsecase) GetMovies(req *dto.GetMoviesRequest, userID, role string) (*dto.GetMoviesResponse, error) {
+	filter := repository.MovieFilter{
+		Title:               req.Title,
+		YearFrom:            req.YearFrom,
+		YearTo:              req.YearTo,
+		RuntimeMin:          req.RuntimeMin,
+		RuntimeMax:          req.RuntimeMax,
+		Language:            req.Language,
+		Country:             req.Country,
+		Certification:       req.Certification,
+		CertificationRegion: req.CertRegion,
+		MinRating:           req.MinRating,
+		MinRatingCount:      req.MinRatingCount,
+		ProviderCountry:     req.ProviderCountry,
+		OfferType:           req.OfferType,
+		Sort:                req.Sort,
+		Status:              req.Status,
+	}
+	for _, slug := range strings.Split(req.Provider, ",") {
+		if slug = strings.ToLower(strings.TrimSpace(slug)); slug != "" {
+			filter.Providers = append(filter.Providers, slug)
+		}
+	}
+	if req.Status != "" && !domain.IsEditor(role) {
+		if userID == "" {
+			return nil, errors.New("sign in to list movies by status")
+		}
+		filter.OwnerID = userID
+	}
+	movies, totalCount, err := u.MovieRepo.GetMovies(req.Page, req.PageSize, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	movieResponses := make([]dto.MovieResponse, len(movies))
-	for i, movie := range movies {
-		movieResponses[i] = dto.MovieResponse{
-			ID:          movie.ID.String(),
-			Title:       movie.Title,
-			Description: movie.Description,
-			Genres:      movie.Genres,
-			Actors:      movie.Actors,
-			TrailerUrl:  movie.Trailer,
-			Poster:      movie.Poster,
-		}
+	movieResponses, err := u.movieListResponses(movies, userID, req.Locales)
+	if err != nil {
+		return nil, err
 	}
 
 	return &dto.GetMoviesResponse{
@@ -116,22 +239,111 @@ func (u *MovieUsecase) GetMovies(req *dto.GetMoviesRequest) (*dto.GetMoviesRespo
 	}, nil
 }
 
-func (u *MovieUsecase) GetMovieByID(id string) (*dto.MovieDetailsResponse, error) {
-	movie, err := u.MovieRepo.FindByID(id)
+// GetMovieByID finds a movie by ID or slug. When an earlier slug is used, the
+// response points to the movie's current slug. Texts are translated into the
+// best match of the preferred locales. Views of live movies count towards
+// trending and popular, once per viewer and dedupe window.
+func (u *MovieUsecase) GetMovieByID(idOrSlug string, userID, role, viewer string, locales []string) (*dto.MovieDetailsResponse, error) {
+	var movie *domain.Movie
+	var err error
+	if _, parseErr := uuid.Parse(idOrSlug); parseErr == nil {
+		movie, err = u.MovieRepo.FindByID(idOrSlug)
+	} else {
+		movie, err = u.MovieRepo.FindBySlug(idOrSlug)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+	credits, err := u.PersonRepo.GetMovieCredits(movie.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	creditResponses := make([]dto.CreditResponse, len(credits))
+	for i, credit := range credits {
+		creditResponses[i] = toCreditResponse(credit)
+	}
 
-	return &dto.MovieDetailsResponse{
-		ID:          movie.ID.String(),
-		Title:       movie.Title,
-		Description: movie.Description,
-		Genres:      movie.Genres,
-		Actors:      movie.Actors,
-		TrailerUrl:  movie.Trailer,
-		Poster:      movie.Poster,
-		UserID:      movie.UserID.String(),
-	}, nil
+	translations, err := u.TranslationRepo.GetMovieTranslations(movie.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	movieResponses := []dto.MovieResponse{toMovieResponse(movie)}
+	localize(&movieResponses[0], movie, translations, locales)
+	if err := u.applySavedFlags(userID, movieResponses); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.MovieDetailsResponse{
+		MovieResponse:    movieResponses[0],
+		UserID:           movie.UserID.String(),
+		Credits:          creditResponses,
+		AvailableLocales: availableLocales(movie, translations),
+	}
+	if movie.Slug != "" && idOrSlug != movie.ID.String() && idOrSlug != movie.Slug {
+		resp.RedirectTo = "/movies/" + movie.Slug
+	} else if isLive(movie, time.Now()) && movie.UserID.String() != userID {
+		u.Views.Record(movie.ID, viewer)
+	}
+	return resp, nil
+}
+
+// movieListResponses turns movies into list entries, translated into the
+// preferred locales and flagged for the user.
+func (u *MovieUsecase) movieListResponses(movies []*domain.Movie, userID string, locales []string) ([]dto.MovieResponse, error) {
+	movieResponses := make([]dto.MovieResponse, len(movies))
+	for i, movie := range movies {
+		movieResponses[i] = toMovieResponse(movie)
+	}
+	if len(locales) > 0 {
+		ids := make([]uuid.UUID, len(movies))
+		for i, movie := range movies {
+			ids[i] = movie.ID
+		}
+		translations, err := u.TranslationRepo.GetTranslations(ids)
+		if err != nil {
+			return nil, err
+		}
+		for i, movie := range movies {
+			localize(&movieResponses[i], movie, translations[movie.ID], locales)
+		}
+	}
+	if err := u.applySavedFlags(userID, movieResponses); err != nil {
+		return nil, err
+	}
+	return movieResponses, nil
+}
+
+func (u *MovieUsecase) applySavedFlags(userID string, movies []dto.MovieResponse) error {
+	if userID == "" {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(movies))
+	for i, movie := range movies {
+		ids[i] = uuid.MustParse(movie.ID)
+	}
+	saved, err := u.SavedMovieRepo.FindSavedLists(userID, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range movies {
+		inWatchlist, isFavorite := false, false
+		for _, list := range saved[ids[i]] {
+			switch list {
+			case domain.ListWatchlist:
+				inWatchlist = true
+			case domain.ListFavorites:
+				isFavorite = true
+			}
+		}
+		movies[i].InWatchlist = &inWatchlist
+		movies[i].IsFavorite = &isFavorite
+	}
+	return nil
 }
 
 func (u *MovieUsecase) DeleteMovie(movieID string, userID string) error {
@@ -146,6 +358,126 @@ func (u *MovieUsecase) DeleteMovie(movieID string, userID string) error {
 		return errors.New("forbidden: you do not own this movie")
 	}
 
-	// Delete the movie
-	return u.MovieRepo.Delete(movieID)
+	media, err := u.MediaRepo.GetAllMovieMedia(movieID)
+	if err != nil {
+		return err
+	}
+
+	// Delete the movie; its media rows go with it
+	if err := u.MovieRepo.Delete(movieID); err != nil {
+		return err
+	}
+
+	// Then remove the stored images, which the database cannot cascade to
+	ctx := context.Background()
+	u.deletePoster(ctx, movie.ID, movie.PosterKey, movie.PosterVariants)
+	if movie.PosterUploadKey != "" {
+		u.ImageStore.Delete(ctx, movie.PosterUploadKey)
+	}
+	for _, item := range media {
+		deleteImage(ctx, u.ImageStore, item.Key, item.Variants)
+	}
+	return nil
+}
+
+func (u *MovieUsecase) AddCredit(movieID string, req *dto.AddCreditRequest, userID string) (*dto.CreditResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+	person, err := u.PersonRepo.FindByID(req.PersonID)
+	if err != nil {
+		return nil, err
+	}
+
+	credit := &domain.Credit{
+		ID:        uuid.New(),
+		MovieID:   movie.ID,
+		PersonID:  person.ID,
+		Role:      req.Role,
+		Character: req.Character,
+		Order:     req.Order,
+	}
+	if err := u.PersonRepo.AddCredit(credit); err != nil {
+		return nil, err
+	}
+	credit.Person = person
+
+	// Keep the legacy actor name list in step with actor credits
+	if credit.Role == domain.CreditRoleActor {
+		movie.Actors = append(movie.Actors, person.Name)
+		if err := u.MovieRepo.Update(movie); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := toCreditResponse(credit)
+	return &resp, nil
+}
+
+func (u *MovieUsecase) RemoveCredit(movieID, creditID string, userID string) error {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return err
+	}
+	if movie.UserID.String() != userID {
+		return errors.New("forbidden: you do not own this movie")
+	}
+	credit, err := u.PersonRepo.FindCreditByID(creditID)
+	if err != nil {
+		return err
+	}
+	if credit.MovieID != movie.ID {
+		return errors.New("credit not found")
+	}
+	if err := u.PersonRepo.DeleteCredit(creditID); err != nil {
+		return err
+	}
+
+	if credit.Role == domain.CreditRoleActor && credit.Person != nil {
+		for i, name := range movie.Actors {
+			if name == credit.Person.Name {
+				movie.Actors = append(movie.Actors[:i], movie.Actors[i+1:]...)
+				break
+			}
+		}
+		return u.MovieRepo.Update(movie)
+	}
+	return nil
+}
+
+func toMovieResponse(movie *domain.Movie) dto.MovieResponse {
+	resp := dto.MovieResponse{
+		ID:               movie.ID.String(),
+		Slug:             movie.Slug,
+		Title:            movie.Title,
+		Description:      movie.Description,
+		Tagline:          movie.Tagline,
+		Locale:           movie.OriginalLanguage,
+		Genres:           movie.Genres,
+		Actors:           movie.Actors,
+		TrailerUrl:       movie.Trailer,
+		Trailer:          toTrailerResponse(movie),
+		Poster:           movie.Poster,
+		Posters:          toImageResponses(movie.PosterVariants),
+		PosterStatus:     movie.PosterStatus,
+		PosterError:      movie.PosterError,
+		RuntimeMinutes:   movie.RuntimeMinutes,
+		OriginalLanguage: movie.OriginalLanguage,
+		Countries:        movie.Countries,
+		Certifications:   movie.Certifications,
+		ImdbID:           movie.ImdbID,
+		TmdbID:           movie.TmdbID,
+		Status:           movie.Status,
+		PublishAt:        movie.PublishAt,
+		AverageRating:    movie.AverageRating,
+		RatingCount:      movie.RatingCount,
+	}
+	if movie.ReleaseDate != nil {
+		resp.ReleaseDate = movie.ReleaseDate.Format(dateLayout)
+	}
+	return resp
 }
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+type PersonUsecase struct {
+	PersonRepo repository.PersonRepository
+}
+
+func NewPersonUsecase(personRepo repository.PersonRepository) *PersonUsecase {
+	return &PersonUsecase{PersonRepo: personRepo}
+}
+
+func (u *PersonUsecase) CreatePerson(req *dto.CreatePersonRequest) (*dto.PersonResponse, error) {
+	birthDate, err := parseDate(req.BirthDate, "birthDate")
+	if err != nil {
+		return nil, err
+	}
+	person := &domain.Person{
+		ID:        uuid.New(),
+		Name:      req.Name,
+		Bio:       req.Bio,
+		BirthDate: birthDate,
+		Photo:     req.Photo,
+	}
+	if err := u.PersonRepo.Create(person); err != nil {
+		return nil, err
+	}
+	resp := toPersonResponse(person)
+	return &resp, nil
+}
+
+func (u *PersonUsecase) UpdatePerson(id string, req *dto.UpdatePersonRequest) (*dto.PersonResponse, error) {
+	person, err := u.PersonRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	birthDate, err := parseDate(req.BirthDate, "birthDate")
+	if err != nil {
+		return nil, err
+	}
+	person.Name = req.Name
+	person.Bio = req.Bio
+	person.BirthDate = birthDate
+	person.Photo = req.Photo
+	if err := u.PersonRepo.Update(person); err != nil {
+		return nil, err
+	}
+	resp := toPersonResponse(person)
+	return &resp, nil
+}
+
+func (u *PersonUsecase) GetPeople(req *dto.GetPeopleRequest) (*dto.GetPeopleResponse, error) {
+	people, totalCount, err := u.PersonRepo.GetPeople(req.Page, req.PageSize, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	personResponses := make([]dto.PersonResponse, len(people))
+	for i, person := range people {
+		personResponses[i] = toPersonResponse(person)
+	}
+
+	return &dto.GetPeopleResponse{
+		People:     personResponses,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
+
+// GetPersonByID returns a person with the movies they worked on that the
+// user can see.
+func (u *PersonUsecase) GetPersonByID(id string, userID, role string) (*dto.PersonDetailsResponse, error) {
+	person, err := u.PersonRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	credits, err := u.PersonRepo.GetFilmography(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filmography := make([]dto.FilmographyEntry, 0, len(credits))
+	for _, credit := range credits {
+		if credit.Movie == nil || !canView(credit.Movie, userID, role) {
+			continue
+		}
+		filmography = append(filmography, dto.FilmographyEntry{
+			MovieID:   credit.MovieID.String(),
+			Title:     credit.Movie.Title,
+			Poster:    credit.Movie.Poster,
+			Role:      credit.Role,
+			Character: credit.Character,
+		})
+	}
+
+	return &dto.PersonDetailsResponse{
+		PersonResponse: toPersonResponse(person),
+		Filmography:    filmography,
+	}, nil
+}
+
+func parseDate(value, field string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, errors.New(field + " must be in YYYY-MM-DD format")
+	}
+	return &t, nil
+}
+
+func toPersonResponse(person *domain.Person) dto.PersonResponse {
+	resp := dto.PersonResponse{
+		ID:    person.ID.String(),
+		Name:  person.Name,
+		Bio:   person.Bio,
+		Photo: person.Photo,
+	}
+	if person.BirthDate != nil {
+		resp.BirthDate = person.BirthDate.Format(dateLayout)
+	}
+	return resp
+}
+
+func toCreditResponse(credit *domain.Credit) dto.CreditResponse {
+	resp := dto.CreditResponse{
+		ID:        credit.ID.String(),
+		PersonID:  credit.PersonID.String(),
+		Role:      credit.Role,
+		Character: credit.Character,
+		Order:     credit.Order,
+	}
+	if credit.Person != nil {
+		resp.Name = credit.Person.Name
+		resp.Photo = credit.Person.Photo
+	}
+	return resp
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/pkg/imaging"
+	"eskalate-movie-api/pkg/storage"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	posterFolder       = "movie-posters"
+	directUploadExpiry = 15 * time.Minute
+)
+
+var posterExtensions = map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "image/webp": ".webp"}
+
+// uploadPoster stores the variants of a poster under
+// movie-posters/<movie id>/.
+func (u *MovieUsecase) uploadPoster(ctx context.Context, movieID uuid.UUID, file io.Reader) ([]domain.ImageVariant, error) {
+	return storeImage(ctx, u.ImageStore, posterPath(movieID), file, imaging.PosterLimits, imaging.PosterSizes)
+}
+
+func (u *MovieUsecase) deletePoster(ctx context.Context, movieID uuid.UUID, key string, variants []domain.ImageVariant) {
+	deleteMoviePoster(ctx, u.ImageStore, movieID, key, variants)
+}
+
+func posterPath(movieID uuid.UUID) string {
+	return posterFolder + "/" + movieID.String()
+}
+
+func posterUploadPath(movieID uuid.UUID) string {
+	return posterUploadFolder + "/" + movieID.String()
+}
+
+// ownsPosterKey reports whether a storage key lies in the movie's own poster
+// folder. Movies only ever point at or delete their own posters, never
+// another upload that happens to be in the same store.
+func ownsPosterKey(movieID uuid.UUID, key string) bool {
+	return inFolder(posterPath(movieID), key)
+}
+
+// ownsUploadKey reports whether a storage key lies in the movie's own
+// staging folder, where its direct and asynchronous uploads are stored.
+func ownsUploadKey(movieID uuid.UUID, key string) bool {
+	return inFolder(posterUploadPath(movieID), key)
+}
+
+// inFolder checks the key in its clean form so that dot segments cannot
+// point a key that starts with the folder somewhere else.
+func inFolder(folder, key string) bool {
+	return strings.HasPrefix(key, folder+"/") && !strings.Contains(key, "..") && path.Clean(key) == key
+}
+
+// deleteMoviePoster deletes the files of a poster, skipping any outside the
+// movie's own poster folder.
+func deleteMoviePoster(ctx context.Context, store storage.ImageStore, movieID uuid.UUID, key string, variants []domain.ImageVariant) {
+	if !ownsPosterKey(movieID, key) {
+		key = ""
+	}
+	owned := make([]domain.ImageVariant, 0, len(variants))
+	for _, variant := range variants {
+		if ownsPosterKey(movieID, variant.Key) {
+			owned = append(owned, variant)
+		}
+	}
+	deleteImage(ctx, store, key, owned)
+}
+
+// stagePoster stores the original of an asynchronous upload under
+// poster-uploads/<movie id>/ for a worker to process. Only the size and
+// format are checked up front.
+func (u *MovieUsecase) stagePoster(ctx context.Context, movieID uuid.UUID, file io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(file, imaging.PosterLimits.MaxBytes+1))
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) > imaging.PosterLimits.MaxBytes {
+		return "", imaging.ErrTooLarge
+	}
+	contentType, ext, err := imaging.DetectFormat(data)
+	if err != nil {
+		return "", err
+	}
+
+	key := posterUploadPath(movieID) + "/" + uuid.New().String()[:8] + ext
+	if _, err := u.ImageStore.Put(ctx, key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return "", errors.New("failed to upload image")
+	}
+	return key, nil
+}
+
+// ReplacePoster swaps in a new poster. Synchronously, the poster is uploaded,
+// swapped in and the files of the previous one are deleted; the swap fails
+// when someone else replaced the poster in the meantime, in which case the
+// new upload is removed again. Asynchronously, the upload is staged and the
+// movie's poster stays in place until a worker has processed it.
+func (u *MovieUsecase) ReplacePoster(movieID string, posterFile io.Reader, async bool, userID string) (*dto.MovieResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), posterProcessTimeout)
+	defer cancel()
+
+	if async {
+		uploadKey, err := u.stagePoster(ctx, movie.ID, posterFile)
+		if err != nil {
+			return nil, err
+		}
+		setPosterUpload(movie, uploadKey)
+		if err := u.MovieRepo.StartPosterUpload(movie); err != nil {
+			u.ImageStore.Delete(ctx, uploadKey)
+			return nil, err
+		}
+		u.PosterWorkers.Enqueue(movie.ID)
+		resp := toMovieResponse(movie)
+		return &resp, nil
+	}
+
+	if err := u.swapPoster(ctx, movie, posterFile); err != nil {
+		return nil, err
+	}
+	resp := toMovieResponse(movie)
+	return &resp, nil
+}
+
+func (u *MovieUsecase) swapPoster(ctx context.Context, movie *domain.Movie, posterFile io.Reader) error {
+	oldPoster, oldKey, oldVariants := movie.Poster, movie.PosterKey, movie.PosterVariants
+	variants, err := u.uploadPoster(ctx, movie.ID, posterFile)
+	if err != nil {
+		return err
+	}
+	setPoster(movie, variants)
+	if err := u.MovieRepo.ReplacePoster(movie, oldPoster); err != nil {
+		u.deletePoster(ctx, movie.ID, "", variants)
+		return err
+	}
+	u.deletePoster(ctx, movie.ID, oldKey, oldVariants)
+	return nil
+}
+
+// RequestPosterUpload issues a presigned upload so the client can send a
+// poster straight to the object store, bypassing the API. The upload lands
+// in the staging folder and only becomes the poster once confirmed.
+func (u *MovieUsecase) RequestPosterUpload(movieID string, req *dto.PosterUploadURLRequest, userID string) (*dto.PosterUploadURLResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+	uploader, ok := u.ImageStore.(storage.DirectUploader)
+	if !ok {
+		return nil, errors.New("direct uploads are not supported by the configured storage")
+	}
+	if req.Size > imaging.PosterLimits.MaxBytes {
+		return nil, imaging.ErrTooLarge
+	}
+
+	key := posterUploadPath(movie.ID) + "/" + uuid.New().String()[:8] + posterExtensions[req.ContentType]
+	upload, err := uploader.PresignUpload(context.Background(), key, req.ContentType, imaging.PosterLimits.MaxBytes, directUploadExpiry)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PosterUploadURLResponse{
+		UploadKey: key,
+		Method:    upload.Method,
+		URL:       upload.URL,
+		Fields:    upload.Fields,
+		FileField: upload.FileField,
+		ExpiresAt: upload.ExpiresAt,
+		MaxBytes:  imaging.PosterLimits.MaxBytes,
+	}, nil
+}
+
+// ConfirmPosterUpload validates a directly uploaded poster and attaches it
+// to the movie, either right away or through the background workers. An
+// upload that is not a valid poster is deleted.
+func (u *MovieUsecase) ConfirmPosterUpload(movieID string, req *dto.ConfirmPosterUploadRequest, userID string) (*dto.MovieResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+	if _, ok := u.ImageStore.(storage.DirectUploader); !ok {
+		return nil, errors.New("direct uploads are not supported by the configured storage")
+	}
+	if !ownsUploadKey(movie.ID, req.UploadKey) {
+		return nil, errors.New("invalid upload key")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), posterProcessTimeout)
+	defer cancel()
+
+	if req.Async {
+		setPosterUpload(movie, req.UploadKey)
+		if err := u.MovieRepo.StartPosterUpload(movie); err != nil {
+			return nil, err
+		}
+		u.PosterWorkers.Enqueue(movie.ID)
+		resp := toMovieResponse(movie)
+		return &resp, nil
+	}
+
+	original, err := u.ImageStore.Get(ctx, req.UploadKey)
+	if err != nil {
+		return nil, errors.New("uploaded poster not found")
+	}
+	defer original.Close()
+	if err := u.swapPoster(ctx, movie, original); err != nil {
+		if err.Error() != "failed to upload image" {
+			u.ImageStore.Delete(ctx, req.UploadKey)
+		}
+		return nil, err
+	}
+	u.ImageStore.Delete(ctx, req.UploadKey)
+
+	resp := toMovieResponse(movie)
+	return &resp, nil
+}
+
+// RetryPosterUpload queues a failed asynchronous upload again.
+func (u *MovieUsecase) RetryPosterUpload(movieID string, userID string) (*dto.PosterStatusResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+	if movie.PosterStatus != domain.PosterFailed || movie.PosterUploadKey == "" {
+		return nil, errors.New("poster upload has not failed")
+	}
+
+	setPosterUpload(movie, movie.PosterUploadKey)
+	if err := u.MovieRepo.SavePosterUpload(movie, movie.PosterUploadKey); err != nil {
+		return nil, err
+	}
+	u.PosterWorkers.Enqueue(movie.ID)
+	return toPosterStatusResponse(movie), nil
+}
+
+func (u *MovieUsecase) GetPosterStatus(movieID string, userID, role string) (*dto.PosterStatusResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+	return toPosterStatusResponse(movie), nil
+}
+
+// setPoster points the movie at freshly uploaded variants, using the full
+// size JPEG as the main poster, and ends any asynchronous upload.
+func setPoster(movie *domain.Movie, variants []domain.ImageVariant) {
+	movie.PosterVariants = variants
+	for _, variant := range variants {
+		if variant.Name == "full" && variant.Format == imaging.FormatJPEG {
+			movie.Poster = variant.URL
+			movie.PosterKey = variant.Key
+		}
+	}
+	clearPosterUpload(movie)
+}
+
+// setPosterUpload marks a staged upload as waiting for a worker.
+func setPosterUpload(movie *domain.Movie, uploadKey string) {
+	movie.PosterStatus = domain.PosterPending
+	movie.PosterError = ""
+	movie.PosterAttempts = 0
+	movie.PosterUploadKey = uploadKey
+}
+
+func clearPosterUpload(movie *domain.Movie) {
+	movie.PosterStatus = domain.PosterReady
+	movie.PosterError = ""
+	movie.PosterAttempts = 0
+	movie.PosterUploadKey = ""
+}
+
+func toPosterStatusResponse(movie *domain.Movie) *dto.PosterStatusResponse {
+	return &dto.PosterStatusResponse{
+		Status:   movie.PosterStatus,
+		Error:    movie.PosterError,
+		Attempts: movie.PosterAttempts,
+		Poster:   movie.Poster,
+		Posters:  toImageResponses(movie.PosterVariants),
+	}
+}
+
+func hasPosterVariant(variants []domain.ImageVariant, key string) bool {
+	for _, variant := range variants {
+		if variant.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func toImageResponses(variants []domain.ImageVariant) map[string]dto.ImageResponse {
+	if len(variants) == 0 {
+		return nil
+	}
+	posters := make(map[string]dto.ImageResponse)
+	for _, variant := range variants {
+		poster := posters[variant.Name]
+		poster.Width = variant.Width
+		poster.Height = variant.Height
+		switch variant.Format {
+		case imaging.FormatJPEG:
+			poster.JPEG = variant.URL
+		case imaging.FormatWebP:
+			poster.WebP = variant.URL
+		}
+		posters[variant.Name] = poster
+	}
+	return posters
+}
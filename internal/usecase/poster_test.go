@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestOwnsUploadKey(t *testing.T) {
+	movieID, otherID := uuid.New(), uuid.New()
+	own := posterUploadPath(movieID)
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{own + "/1a2b3c4d.jpg", true},
+		{posterUploadPath(otherID) + "/1a2b3c4d.jpg", false},
+		{own + "/../../" + posterPath(otherID) + "/x-full.jpg", false},
+		{own + "/./1a2b3c4d.jpg", false},
+		{own + "//1a2b3c4d.jpg", false},
+		{own + "/", false},
+		{own, false},
+		{posterPath(movieID) + "/x-full.jpg", false},
+	}
+	for _, tt := range tests {
+		if got := ownsUploadKey(movieID, tt.key); got != tt.want {
+			t.Errorf("ownsUploadKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
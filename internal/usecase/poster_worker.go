@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/imaging"
+	"eskalate-movie-api/pkg/storage"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	posterUploadFolder   = "poster-uploads"
+	posterMaxAttempts    = 3
+	posterProcessTimeout = 2 * time.Minute
+	posterRetryBackoff   = 10 * time.Second
+)
+
+// PosterWorkerPool processes staged poster uploads in the background. Jobs
+// carry only a movie ID; the movie row holds the state of its upload, so
+// uploads left pending by a restart are picked up again by Start.
+type PosterWorkerPool struct {
+	MovieRepo  repository.MovieRepository
+	ImageStore storage.ImageStore
+	workers    int
+	queue      chan uuid.UUID
+}
+
+func NewPosterWorkerPool(movieRepo repository.MovieRepository, imageStore storage.ImageStore, workers int) *PosterWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	return &PosterWorkerPool{
+		MovieRepo:  movieRepo,
+		ImageStore: imageStore,
+		workers:    workers,
+		queue:      make(chan uuid.UUID, 100),
+	}
+}
+
+// Start launches the workers and requeues uploads that were pending or in
+// progress when the server last stopped.
+func (p *PosterWorkerPool) Start() {
+	for i := 0; i < p.workers; i++ {
+		go p.work()
+	}
+
+	movies, err := p.MovieRepo.FindPendingPosterUploads()
+	if err != nil {
+		log.Printf("Warning: failed to load pending poster uploads: %v", err)
+		return
+	}
+	for _, movie := range movies {
+		if movie.PosterStatus == domain.PosterProcessing {
+			// Interrupted mid-way; make it claimable again
+			movie.PosterStatus = domain.PosterPending
+			if err := p.MovieRepo.SavePosterUpload(movie, movie.PosterUploadKey); err != nil {
+				continue
+			}
+		}
+		p.Enqueue(movie.ID)
+	}
+}
+
+// Enqueue schedules processing of the movie's pending upload without
+// blocking the caller.
+func (p *PosterWorkerPool) Enqueue(movieID uuid.UUID) {
+	select {
+	case p.queue <- movieID:
+	default:
+		go func() { p.queue <- movieID }()
+	}
+}
+
+func (p *PosterWorkerPool) work() {
+	for movieID := range p.queue {
+		p.process(movieID)
+	}
+}
+
+func (p *PosterWorkerPool) process(movieID uuid.UUID) {
+	movie, err := p.MovieRepo.FindByID(movieID.String())
+	if err != nil {
+		return
+	}
+	uploadKey := movie.PosterUploadKey
+	claimed, err := p.MovieRepo.ClaimPosterUpload(movie)
+	if err != nil || !claimed {
+		return
+	}
+	if !ownsUploadKey(movie.ID, uploadKey) {
+		p.fail(movie, uploadKey, errors.New("invalid upload key"))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), posterProcessTimeout)
+	defer cancel()
+	oldKey, oldVariants := movie.PosterKey, movie.PosterVariants
+	variants, err := p.processUpload(ctx, movie)
+	if err != nil {
+		p.fail(movie, uploadKey, err)
+		return
+	}
+
+	setPoster(movie, variants)
+	if err := p.MovieRepo.SavePosterUpload(movie, uploadKey); err != nil {
+		// A newer upload or a replacement took over while we were working
+		deleteMoviePoster(ctx, p.ImageStore, movie.ID, "", variants)
+	} else {
+		deleteMoviePoster(ctx, p.ImageStore, movie.ID, oldKey, oldVariants)
+	}
+	p.ImageStore.Delete(ctx, uploadKey)
+}
+
+func (p *PosterWorkerPool) processUpload(ctx context.Context, movie *domain.Movie) ([]domain.ImageVariant, error) {
+	original, err := p.ImageStore.Get(ctx, movie.PosterUploadKey)
+	if err != nil {
+		return nil, errPosterUnavailable
+	}
+	defer original.Close()
+	return storeImage(ctx, p.ImageStore, posterPath(movie.ID), original, imaging.PosterLimits, imaging.PosterSizes)
+}
+
+// fail records a failed attempt. Storage errors are retried with a growing
+// delay; an invalid image fails the upload right away.
+func (p *PosterWorkerPool) fail(movie *domain.Movie, uploadKey string, cause error) {
+	movie.PosterAttempts++
+	movie.PosterError = cause.Error()
+	retry := isRetryablePosterError(cause) && movie.PosterAttempts < posterMaxAttempts
+	if retry {
+		movie.PosterStatus = domain.PosterPending
+	} else {
+		movie.PosterStatus = domain.PosterFailed
+	}
+	if err := p.MovieRepo.SavePosterUpload(movie, uploadKey); err != nil {
+		return
+	}
+	if retry {
+		delay := posterRetryBackoff * time.Duration(movie.PosterAttempts*movie.PosterAttempts)
+		time.AfterFunc(delay, func() { p.Enqueue(movie.ID) })
+	}
+}
+
+var errPosterUnavailable = errors.New("failed to read uploaded poster")
+
+func isRetryablePosterError(err error) bool {
+	return err == errPosterUnavailable || err.Error() == "failed to upload image"
+}
@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	providerSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	countryPattern      = regexp.MustCompile(`^[A-Z]{2}$`)
+	currencyPattern     = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+type ProviderUsecase struct {
+	ProviderRepo repository.ProviderRepository
+	MovieRepo    repository.MovieRepository
+}
+
+func NewProviderUsecase(providerRepo repository.ProviderRepository, movieRepo repository.MovieRepository) *ProviderUsecase {
+	return &ProviderUsecase{ProviderRepo: providerRepo, MovieRepo: movieRepo}
+}
+
+func (u *ProviderUsecase) GetProviders() ([]dto.ProviderResponse, error) {
+	providers, err := u.ProviderRepo.GetProviders()
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.ProviderResponse, len(providers))
+	for i, provider := range providers {
+		resp[i] = toProviderResponse(provider)
+	}
+	return resp, nil
+}
+
+func (u *ProviderUsecase) CreateProvider(req *dto.ProviderRequest) (*dto.ProviderResponse, error) {
+	if !providerSlugPattern.MatchString(req.Slug) {
+		return nil, errors.New("invalid provider slug")
+	}
+	now := time.Now()
+	provider := &domain.Provider{
+		ID:          uuid.New(),
+		Slug:        req.Slug,
+		Name:        req.Name,
+		LogoURL:     req.LogoURL,
+		HomepageURL: req.HomepageURL,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := u.ProviderRepo.CreateProvider(provider); err != nil {
+		return nil, err
+	}
+	resp := toProviderResponse(provider)
+	return &resp, nil
+}
+
+func (u *ProviderUsecase) UpdateProvider(id string, req *dto.ProviderRequest) (*dto.ProviderResponse, error) {
+	if !providerSlugPattern.MatchString(req.Slug) {
+		return nil, errors.New("invalid provider slug")
+	}
+	provider, err := u.ProviderRepo.FindProviderByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Slug != provider.Slug {
+		if _, err := u.ProviderRepo.FindProviderBySlug(req.Slug); err == nil {
+			return nil, errors.New("provider slug already exists")
+		} else if err.Error() != "provider not found" {
+			return nil, err
+		}
+	}
+
+	provider.Slug = req.Slug
+	provider.Name = req.Name
+	provider.LogoURL = req.LogoURL
+	provider.HomepageURL = req.HomepageURL
+	provider.UpdatedAt = time.Now()
+	if err := u.ProviderRepo.UpdateProvider(provider); err != nil {
+		return nil, err
+	}
+	resp := toProviderResponse(provider)
+	return &resp, nil
+}
+
+// DeleteProvider removes the provider and every offer on it.
+func (u *ProviderUsecase) DeleteProvider(id string) error {
+	return u.ProviderRepo.DeleteProvider(id)
+}
+
+// GetMovieAvailability lists where a movie the user can see is offered now.
+func (u *ProviderUsecase) GetMovieAvailability(movieID string, req *dto.GetAvailabilityRequest, userID, role string) ([]dto.AvailabilityResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+
+	availabilities, err := u.ProviderRepo.GetMovieAvailability(movie.ID, repository.AvailabilityFilter{Country: req.Country, Type: req.Type})
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]dto.AvailabilityResponse, len(availabilities))
+	for i, availability := range availabilities {
+		resp[i] = toAvailabilityResponse(availability)
+	}
+	return resp, nil
+}
+
+func (u *ProviderUsecase) CreateAvailability(movieID string, req *dto.CreateAvailabilityRequest, editorID string) (*dto.AvailabilityResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := u.ProviderRepo.FindProviderByID(req.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	updatedBy := uuid.MustParse(editorID)
+	availability := &domain.MovieAvailability{
+		ID:             uuid.New(),
+		MovieID:        movie.ID,
+		ProviderID:     provider.ID,
+		Country:        req.Country,
+		Type:           req.Type,
+		Quality:        req.Quality,
+		Price:          req.Price,
+		Currency:       req.Currency,
+		Link:           req.Link,
+		AvailableUntil: req.AvailableUntil,
+		Source:         domain.AvailabilityManual,
+		UpdatedBy:      &updatedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		Provider:       provider,
+	}
+	if availability.Price == nil {
+		availability.Currency = ""
+	}
+	if err := u.ProviderRepo.CreateAvailability(availability); err != nil {
+		return nil, err
+	}
+	resp := toAvailabilityResponse(availability)
+	return &resp, nil
+}
+
+// UpdateAvailability changes the price, link or end of an offer. An offer
+// from a feed becomes a manual one, so the next feed import keeps the change
+// unless the feed has the same offer.
+func (u *ProviderUsecase) UpdateAvailability(id string, req *dto.UpdateAvailabilityRequest, editorID string) (*dto.AvailabilityResponse, error) {
+	availability, err := u.ProviderRepo.FindAvailabilityByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedBy := uuid.MustParse(editorID)
+	availability.Price = req.Price
+	availability.Currency = req.Currency
+	if availability.Price == nil {
+		availability.Currency = ""
+	}
+	availability.Link = req.Link
+	availability.AvailableUntil = req.AvailableUntil
+	availability.Source = domain.AvailabilityManual
+	availability.UpdatedBy = &updatedBy
+	availability.UpdatedAt = time.Now()
+	if err := u.ProviderRepo.UpdateAvailability(availability); err != nil {
+		return nil, err
+	}
+	resp := toAvailabilityResponse(availability)
+	return &resp, nil
+}
+
+func (u *ProviderUsecase) DeleteAvailability(id string) error {
+	return u.ProviderRepo.DeleteAvailability(id)
+}
+
+// ImportFeed syncs providers and their offers from a feed. Offers that are
+// invalid, or whose provider the feed does not list, are reported and
+// skipped; offers for movies missing from the catalog are counted and
+// skipped.
+func (u *ProviderUsecase) ImportFeed(feed *dto.ProviderFeed) (*dto.ProviderFeedReport, error) {
+	now := time.Now()
+	report := &dto.ProviderFeedReport{}
+
+	providers := make([]*domain.Provider, 0, len(feed.Providers))
+	listed := make(map[string]bool, len(feed.Providers))
+	for _, p := range feed.Providers {
+		slug := strings.ToLower(strings.TrimSpace(p.Slug))
+		if !providerSlugPattern.MatchString(slug) || strings.TrimSpace(p.Name) == "" {
+			return nil, fmt.Errorf("invalid feed provider %q: a slug and a name are required", p.Slug)
+		}
+		if listed[slug] {
+			continue
+		}
+		listed[slug] = true
+		providers = append(providers, &domain.Provider{
+			ID:          uuid.New(),
+			Slug:        slug,
+			Name:        strings.TrimSpace(p.Name),
+			LogoURL:     p.LogoURL,
+			HomepageURL: p.HomepageURL,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		})
+	}
+	report.Providers = len(providers)
+
+	var ids, imdbIDs, tmdbIDs []string
+	valid := make([]int, 0, len(feed.Offers))
+	for i := range feed.Offers {
+		offer := &feed.Offers[i]
+		if err := normalizeFeedOffer(offer, listed); err != nil {
+			report.Invalid = append(report.Invalid, dto.FeedOfferIssue{Index: i, Error: err.Error()})
+			continue
+		}
+		valid = append(valid, i)
+		switch {
+		case offer.MovieID != "":
+			ids = append(ids, offer.MovieID)
+		case offer.ImdbID != "":
+			imdbIDs = append(imdbIDs, offer.ImdbID)
+		default:
+			tmdbIDs = append(tmdbIDs, offer.TmdbID)
+		}
+	}
+	movies, err := u.ProviderRepo.ResolveMovies(ids, imdbIDs, tmdbIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same offer listed twice keeps its last entry
+	type offerKey struct {
+		movieID                          uuid.UUID
+		provider, country, kind, quality string
+	}
+	seen := make(map[offerKey]*domain.MovieAvailability)
+	availabilities := make(map[string][]*domain.MovieAvailability)
+	for _, i := range valid {
+		offer := feed.Offers[i]
+		movieID, ok := movies[offer.MovieID+offer.ImdbID+offer.TmdbID] // Only one of them is set
+		if !ok {
+			report.UnmatchedMovies++
+			continue
+		}
+		availability := &domain.MovieAvailability{
+			ID:             uuid.New(),
+			MovieID:        movieID,
+			Country:        offer.Country,
+			Type:           offer.Type,
+			Quality:        offer.Quality,
+			Price:          offer.Price,
+			Currency:       offer.Currency,
+			Link:           offer.Link,
+			AvailableUntil: offer.AvailableUntil,
+			Source:         domain.AvailabilityFeed,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+		key := offerKey{movieID, offer.Provider, offer.Country, offer.Type, offer.Quality}
+		if previous, ok := seen[key]; ok {
+			*previous = *availability
+			continue
+		}
+		seen[key] = availability
+		availabilities[offer.Provider] = append(availabilities[offer.Provider], availability)
+	}
+
+	if report.Offers, err = u.ProviderRepo.SyncFeed(providers, availabilities); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// normalizeFeedOffer checks an offer and brings its codes into the form
+// stored.
+func normalizeFeedOffer(offer *dto.FeedOffer, providers map[string]bool) error {
+	offer.Provider = strings.ToLower(strings.TrimSpace(offer.Provider))
+	offer.Country = strings.ToUpper(strings.TrimSpace(offer.Country))
+	offer.Type = strings.ToLower(strings.TrimSpace(offer.Type))
+	offer.Quality = strings.ToLower(strings.TrimSpace(offer.Quality))
+	offer.Currency = strings.ToUpper(strings.TrimSpace(offer.Currency))
+
+	// Only the first of the movie's IDs is used
+	switch {
+	case offer.MovieID != "":
+		if _, err := uuid.Parse(offer.MovieID); err != nil {
+			return errors.New("invalid movieId")
+		}
+		offer.ImdbID, offer.TmdbID = "", ""
+	case offer.ImdbID != "":
+		offer.TmdbID = ""
+	case offer.TmdbID == "":
+		return errors.New("movieId, imdbId or tmdbId is required")
+	}
+
+	if !providers[offer.Provider] {
+		return fmt.Errorf("provider %q is not listed in the feed", offer.Provider)
+	}
+	if !countryPattern.MatchString(offer.Country) {
+		return errors.New("country must be an ISO 3166-1 alpha-2 code")
+	}
+	if offer.Type != domain.OfferStream && offer.Type != domain.OfferRent && offer.Type != domain.OfferBuy {
+		return errors.New("type must be stream, rent or buy")
+	}
+	if offer.Quality != domain.QualitySD && offer.Quality != domain.QualityHD && offer.Quality != domain.QualityUHD {
+		return errors.New("quality must be sd, hd or uhd")
+	}
+	if offer.Price != nil {
+		if *offer.Price < 0 || *offer.Price > 99999999 {
+			return errors.New("price is out of range")
+		}
+		if !currencyPattern.MatchString(offer.Currency) {
+			return errors.New("a price needs an ISO 4217 currency")
+		}
+	} else {
+		offer.Currency = ""
+	}
+	if link, err := url.Parse(offer.Link); err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		return errors.New("link must be an http or https URL")
+	}
+	return nil
+}
+
+func toProviderResponse(provider *domain.Provider) dto.ProviderResponse {
+	return dto.ProviderResponse{
+		ID:          provider.ID.String(),
+		Slug:        provider.Slug,
+		Name:        provider.Name,
+		LogoURL:     provider.LogoURL,
+		HomepageURL: provider.HomepageURL,
+	}
+}
+
+func toAvailabilityResponse(availability *domain.MovieAvailability) dto.AvailabilityResponse {
+	resp := dto.AvailabilityResponse{
+		ID:             availability.ID.String(),
+		Country:        availability.Country,
+		Type:           availability.Type,
+		Quality:        availability.Quality,
+		Price:          availability.Price,
+		Currency:       availability.Currency,
+		Link:           availability.Link,
+		AvailableUntil: availability.AvailableUntil,
+		Source:         availability.Source,
+		UpdatedAt:      availability.UpdatedAt,
+	}
+	if availability.Provider != nil {
+		resp.Provider = toProviderResponse(availability.Provider)
+	}
+	return resp
+}
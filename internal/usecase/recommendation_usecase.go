@@ -0,0 +1,354 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/recommend"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxRecommendationSeeds = 50  // Strongest signals of a user's taste that are used
+	maxRecommendations     = 200 // Candidates ranked per request
+	reasonTopRated         = "top_rated"
+)
+
+// Kinds of signal a recommendation can be traced back to.
+const (
+	seedRated     = "rated"
+	seedFavorite  = "favorite"
+	seedWatchlist = "watchlist"
+)
+
+type RecommendationUsecase struct {
+	RecommendationRepo repository.RecommendationRepository
+	MovieRepo          repository.MovieRepository
+	MovieUsecase       *MovieUsecase // Translates and flags listed movies
+}
+
+func NewRecommendationUsecase(recommendationRepo repository.RecommendationRepository, movieRepo repository.MovieRepository, movieUsecase *MovieUsecase) *RecommendationUsecase {
+	return &RecommendationUsecase{RecommendationRepo: recommendationRepo, MovieRepo: movieRepo, MovieUsecase: movieUsecase}
+}
+
+// RefreshSimilarities recomputes the similar movies of every live movie
+// from genres, people, descriptions and ratings, and reports how many pairs
+// were stored.
+func (u *RecommendationUsecase) RefreshSimilarities() (int, error) {
+	items, err := u.RecommendationRepo.LoadItems()
+	if err != nil {
+		return 0, err
+	}
+	ratings, err := u.RecommendationRepo.LoadRatings()
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	computed := recommend.Compute(items, ratings, recommend.DefaultOptions())
+	similarities := make([]domain.MovieSimilarity, len(computed))
+	for i, sim := range computed {
+		similarities[i] = domain.MovieSimilarity{
+			MovieID:      uuid.MustParse(sim.ItemID),
+			SimilarID:    uuid.MustParse(sim.SimilarID),
+			Score:        sim.Score,
+			ContentScore: sim.Content,
+			RatingScore:  sim.Collaborative,
+			Reasons:      nonNil(sim.Reasons),
+			SharedGenres: nonNil(sim.SharedGenres),
+			SharedPeople: nonNil(sim.SharedPeople),
+			ComputedAt:   now,
+		}
+	}
+	if err := u.RecommendationRepo.ReplaceSimilarities(similarities); err != nil {
+		return 0, err
+	}
+	return len(similarities), nil
+}
+
+// GetSimilarMovies lists the live movies most like the given one.
+func (u *RecommendationUsecase) GetSimilarMovies(idOrSlug string, req *dto.GetSimilarMoviesRequest, userID, role string) ([]dto.RecommendedMovieResponse, error) {
+	var movie *domain.Movie
+	var err error
+	if _, parseErr := uuid.Parse(idOrSlug); parseErr == nil {
+		movie, err = u.MovieRepo.FindByID(idOrSlug)
+	} else {
+		movie, err = u.MovieRepo.FindBySlug(idOrSlug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+
+	similarities, err := u.RecommendationRepo.GetSimilar(movie.ID, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	movies := make([]*domain.Movie, len(similarities))
+	for i, sim := range similarities {
+		movies[i] = sim.Similar
+	}
+	movieResponses, err := u.MovieUsecase.movieListResponses(movies, userID, req.Locales)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.RecommendedMovieResponse, len(similarities))
+	for i, sim := range similarities {
+		resp[i] = toRecommendedMovieResponse(movieResponses[i], sim)
+		resp[i].Score = sim.Score
+		resp[i].Explanation = capitalize(describeSimilarity(sim))
+	}
+	return resp, nil
+}
+
+type seed struct {
+	weight float64
+	kind   string
+}
+
+type candidate struct {
+	movie *domain.Movie
+	score float64
+	best  *domain.MovieSimilarity // The pair contributing most to the score
+	gain  float64
+}
+
+// GetRecommendations suggests movies like those the user rated highly, marked
+// as favorites or put on their watchlist, leaving out movies they already
+// know. Users without such signals get the best rated movies. Movies the user
+// can no longer see are not used as signals, so their titles never show up
+// in an explanation.
+func (u *RecommendationUsecase) GetRecommendations(req *dto.GetRecommendationsRequest, userID, role string) (*dto.GetRecommendationsResponse, error) {
+	seeds, known, err := u.tasteOf(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seedIDs := make([]uuid.UUID, 0, len(seeds))
+	for id := range seeds {
+		seedIDs = append(seedIDs, id)
+	}
+	similarities, err := u.RecommendationRepo.GetSimilarToAny(seedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make(map[uuid.UUID]*candidate)
+	for _, sim := range similarities {
+		if known[sim.SimilarID] || !canView(sim.Movie, userID, role) {
+			continue
+		}
+		c := candidates[sim.SimilarID]
+		if c == nil {
+			c = &candidate{movie: sim.Similar}
+			candidates[sim.SimilarID] = c
+		}
+		gain := seeds[sim.MovieID].weight * sim.Score
+		c.score += gain
+		if gain > c.gain {
+			c.best, c.gain = sim, gain
+		}
+	}
+
+	ranked := make([]*candidate, 0, len(candidates))
+	for _, c := range candidates {
+		ranked = append(ranked, c)
+	}
+	if len(ranked) == 0 {
+		if ranked, err = u.topRated(known); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(ranked, func(a, b int) bool {
+		if ranked[a].score != ranked[b].score {
+			return ranked[a].score > ranked[b].score
+		}
+		return ranked[a].movie.ID.String() < ranked[b].movie.ID.String()
+	})
+	if len(ranked) > maxRecommendations {
+		ranked = ranked[:maxRecommendations]
+	}
+
+	start := min((req.Page-1)*req.PageSize, len(ranked))
+	end := min(start+req.PageSize, len(ranked))
+	page := ranked[start:end]
+	movies := make([]*domain.Movie, len(page))
+	for i, c := range page {
+		movies[i] = c.movie
+	}
+	movieResponses, err := u.MovieUsecase.movieListResponses(movies, userID, req.Locales)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.RecommendedMovieResponse, len(page))
+	for i, c := range page {
+		if c.best == nil {
+			resp[i] = dto.RecommendedMovieResponse{
+				MovieResponse: movieResponses[i],
+				Reasons:       []string{reasonTopRated},
+				SharedGenres:  []string{},
+				SharedPeople:  []string{},
+				Explanation:   "Highly rated by our community",
+			}
+		} else {
+			resp[i] = toRecommendedMovieResponse(movieResponses[i], c.best)
+			resp[i].BecauseOf = &dto.MovieRefResponse{ID: c.best.MovieID.String(), Title: c.best.Movie.Title, Slug: c.best.Movie.Slug}
+			resp[i].Explanation = explainRecommendation(c.best, seeds[c.best.MovieID].kind)
+		}
+		resp[i].Score = c.score
+	}
+	return &dto.GetRecommendationsResponse{
+		Movies:     resp,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  int64(len(ranked)),
+	}, nil
+}
+
+// tasteOf weighs the movies a user rated 7 or more, marked as favorites or
+// put on their watchlist, and lists every movie the user already knows.
+func (u *RecommendationUsecase) tasteOf(userID string) (map[uuid.UUID]seed, map[uuid.UUID]bool, error) {
+	reviews, err := u.RecommendationRepo.GetUserReviews(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	saved, err := u.RecommendationRepo.GetUserSavedMovies(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seeds := make(map[uuid.UUID]seed)
+	known := make(map[uuid.UUID]bool)
+	add := func(movieID uuid.UUID, s seed) {
+		if s.weight > seeds[movieID].weight {
+			seeds[movieID] = s
+		}
+	}
+	for _, review := range reviews {
+		known[review.MovieID] = true
+		if review.Rating >= 7 {
+			add(review.MovieID, seed{weight: float64(review.Rating-5) / 5, kind: seedRated})
+		}
+	}
+	for _, item := range saved {
+		known[item.MovieID] = true
+		if item.List == domain.ListFavorites {
+			add(item.MovieID, seed{weight: 1, kind: seedFavorite})
+		} else {
+			add(item.MovieID, seed{weight: 0.5, kind: seedWatchlist})
+		}
+	}
+
+	if len(seeds) > maxRecommendationSeeds {
+		ids := make([]uuid.UUID, 0, len(seeds))
+		for id := range seeds {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(a, b int) bool { return seeds[ids[a]].weight > seeds[ids[b]].weight })
+		for _, id := range ids[maxRecommendationSeeds:] {
+			delete(seeds, id)
+		}
+	}
+	return seeds, known, nil
+}
+
+// topRated ranks the best rated live movies the user does not know yet.
+func (u *RecommendationUsecase) topRated(known map[uuid.UUID]bool) ([]*candidate, error) {
+	movies, _, err := u.MovieRepo.GetMovies(1, maxRecommendations, repository.MovieFilter{Sort: "rating_desc", MinRatingCount: 1})
+	if err != nil {
+		return nil, err
+	}
+	ranked := make([]*candidate, 0, len(movies))
+	for _, movie := range movies {
+		if !known[movie.ID] {
+			ranked = append(ranked, &candidate{movie: movie, score: movie.AverageRating / 10})
+		}
+	}
+	return ranked, nil
+}
+
+func toRecommendedMovieResponse(movie dto.MovieResponse, sim *domain.MovieSimilarity) dto.RecommendedMovieResponse {
+	return dto.RecommendedMovieResponse{
+		MovieResponse: movie,
+		Reasons:       nonNil(sim.Reasons),
+		SharedGenres:  nonNil(sim.SharedGenres),
+		SharedPeople:  nonNil(sim.SharedPeople),
+	}
+}
+
+// explainRecommendation says which of the user's movies a recommendation
+// comes from, e.g. "Because you liked Heat: features Al Pacino".
+func explainRecommendation(sim *domain.MovieSimilarity, kind string) string {
+	var because string
+	switch kind {
+	case seedFavorite:
+		because = fmt.Sprintf("Because %s is one of your favorites", sim.Movie.Title)
+	case seedWatchlist:
+		because = fmt.Sprintf("Because you added %s to your watchlist", sim.Movie.Title)
+	default:
+		because = fmt.Sprintf("Because you liked %s", sim.Movie.Title)
+	}
+	if description := describeSimilarity(sim); description != "" {
+		because += ": " + description
+	}
+	return because
+}
+
+// describeSimilarity puts the reasons of a similarity into words, e.g.
+// "features Al Pacino and shares the genre Crime".
+func describeSimilarity(sim *domain.MovieSimilarity) string {
+	var parts []string
+	if len(sim.SharedPeople) > 0 {
+		people := sim.SharedPeople
+		if len(people) > 3 {
+			people = people[:3]
+		}
+		parts = append(parts, "features "+joinWords(people))
+	}
+	if len(sim.SharedGenres) == 1 {
+		parts = append(parts, "shares the genre "+sim.SharedGenres[0])
+	} else if len(sim.SharedGenres) > 1 {
+		parts = append(parts, "shares the genres "+joinWords(sim.SharedGenres))
+	}
+	for _, reason := range sim.Reasons {
+		switch reason {
+		case recommend.ReasonStory:
+			parts = append(parts, "has a similar story")
+		case recommend.ReasonRatedAlike:
+			parts = append(parts, "is rated alike by viewers")
+		}
+	}
+	return joinWords(parts)
+}
+
+// joinWords joins words as in "a, b and c".
+func joinWords(words []string) string {
+	if len(words) <= 1 {
+		return strings.Join(words, "")
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
+}
+
+func capitalize(text string) string {
+	if text == "" {
+		return text
+	}
+	return strings.ToUpper(text[:1]) + text[1:]
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Moderation actions on a report. Each settles every open report about the
+// same content.
+const (
+	ModerationResolve  = "resolve"
+	ModerationDismiss  = "dismiss"
+	ModerationTakeDown = "take_down"
+)
+
+const reportExcerptLength = 280
+
+type ReportUsecase struct {
+	ReportRepo  repository.ReportRepository
+	MovieRepo   repository.MovieRepository
+	ReviewRepo  repository.ReviewRepository
+	CommentRepo repository.CommentRepository
+}
+
+func NewReportUsecase(reportRepo repository.ReportRepository, movieRepo repository.MovieRepository, reviewRepo repository.ReviewRepository, commentRepo repository.CommentRepository) *ReportUsecase {
+	return &ReportUsecase{ReportRepo: reportRepo, MovieRepo: movieRepo, ReviewRepo: reviewRepo, CommentRepo: commentRepo}
+}
+
+// CreateReport flags a movie, review or comment the user can see.
+func (u *ReportUsecase) CreateReport(req *dto.CreateReportRequest, userID, role string) (*dto.ReportResponse, error) {
+	authorID, movieID, err := u.findTarget(req.TargetType, req.TargetID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if authorID.String() == userID {
+		return nil, errors.New("you cannot report your own content")
+	}
+
+	reporterID := uuid.MustParse(userID)
+	report := &domain.Report{
+		ID:         uuid.New(),
+		TargetType: req.TargetType,
+		TargetID:   uuid.MustParse(req.TargetID),
+		MovieID:    movieID,
+		ReporterID: &reporterID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+		Status:     domain.ReportOpen,
+		CreatedAt:  time.Now(),
+	}
+	if err := u.ReportRepo.Create(report); err != nil {
+		return nil, err
+	}
+	resp := toReportResponse(report)
+	return &resp, nil
+}
+
+// GetModerationQueue lists reports with what they are about, oldest first.
+func (u *ReportUsecase) GetModerationQueue(req *dto.GetModerationQueueRequest) (*dto.GetReportsResponse, error) {
+	filter := repository.ReportFilter{Status: req.Status, TargetType: req.TargetType}
+	reports, totalCount, err := u.ReportRepo.GetReports(filter, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	targetIDs := make([]uuid.UUID, len(reports))
+	for i, report := range reports {
+		targetIDs[i] = report.TargetID
+	}
+	openReports, err := u.ReportRepo.CountOpen(targetIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]dto.ReportResponse, len(reports))
+	for i, report := range reports {
+		responses[i] = toReportResponse(report)
+		responses[i].OpenReports = openReports[report.TargetID]
+		if responses[i].Target, err = u.reportedContent(report); err != nil {
+			return nil, err
+		}
+	}
+	return &dto.GetReportsResponse{
+		Reports:    responses,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
+
+// ModerateReport settles an open report and every other open report about
+// the same content. Taking content down archives a movie, deletes a review
+// or hides a comment.
+func (u *ReportUsecase) ModerateReport(reportID, action string, req *dto.ModerationActionRequest, moderatorID string) (*dto.ModerationActionResponse, error) {
+	report, err := u.ReportRepo.FindByID(reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status != domain.ReportOpen {
+		return nil, errors.New("report is already closed")
+	}
+
+	status := domain.ReportResolved
+	switch action {
+	case ModerationDismiss:
+		status = domain.ReportDismissed
+	case ModerationTakeDown:
+		status = domain.ReportTakenDown
+		if err := u.takeDown(report, req.Note, moderatorID); err != nil {
+			return nil, err
+		}
+	}
+
+	closed, err := u.ReportRepo.Close(report.TargetType, report.TargetID, status, uuid.MustParse(moderatorID), req.Note)
+	if err != nil {
+		return nil, err
+	}
+	if closed == 0 {
+		return nil, errors.New("report is already closed")
+	}
+	return &dto.ModerationActionResponse{Status: status, ClosedReports: closed}, nil
+}
+
+func (u *ReportUsecase) takeDown(report *domain.Report, note, moderatorID string) error {
+	reason := "Taken down by a moderator"
+	if note != "" {
+		reason += ": " + note
+	}
+
+	switch report.TargetType {
+	case domain.ReportTargetMovie:
+		movie, err := u.MovieRepo.FindByID(report.TargetID.String())
+		if err != nil || movie.Status == domain.MovieArchived {
+			return ignoreNotFound(err, "movie not found")
+		}
+		now := time.Now()
+		fromStatus := movie.Status
+		movie.Status = domain.MovieArchived
+		movie.PublishAt = nil
+		return u.MovieRepo.SetStatus(movie, fromStatus, &domain.MovieStatusChange{
+			ID:         uuid.New(),
+			MovieID:    movie.ID,
+			FromStatus: fromStatus,
+			ToStatus:   domain.MovieArchived,
+			Comment:    reason,
+			ChangedBy:  uuid.MustParse(moderatorID),
+			CreatedAt:  now,
+		})
+
+	case domain.ReportTargetReview:
+		review, err := u.ReviewRepo.FindByID(report.TargetID.String())
+		if err != nil {
+			return ignoreNotFound(err, "review not found")
+		}
+		return ignoreNotFound(u.ReviewRepo.Delete(review), "review not found")
+
+	case domain.ReportTargetComment:
+		comment, err := u.CommentRepo.FindByID(report.TargetID.String())
+		if err != nil || comment.Deleted {
+			return ignoreNotFound(err, "comment not found")
+		}
+		hiddenBy := uuid.MustParse(moderatorID)
+		comment.Hidden = true
+		comment.HiddenBy = &hiddenBy
+		comment.HiddenReason = reason
+		return u.CommentRepo.Update(comment)
+	}
+	return nil
+}
+
+// findTarget looks up reported content the user can see and returns its
+// author and movie.
+func (u *ReportUsecase) findTarget(targetType, targetID, userID, role string) (uuid.UUID, uuid.UUID, error) {
+	var authorID, movieID uuid.UUID
+	switch targetType {
+	case domain.ReportTargetMovie:
+		movie, err := u.MovieRepo.FindByID(targetID)
+		if err != nil {
+			return authorID, movieID, err
+		}
+		authorID, movieID = movie.UserID, movie.ID
+	case domain.ReportTargetReview:
+		review, err := u.ReviewRepo.FindByID(targetID)
+		if err != nil {
+			return authorID, movieID, err
+		}
+		authorID, movieID = review.UserID, review.MovieID
+	case domain.ReportTargetComment:
+		comment, err := u.CommentRepo.FindByID(targetID)
+		if err != nil {
+			return authorID, movieID, err
+		}
+		if comment.Deleted {
+			return authorID, movieID, errors.New("comment not found")
+		}
+		authorID, movieID = comment.UserID, comment.MovieID
+	default:
+		return authorID, movieID, errors.New("unknown report target")
+	}
+
+	movie, err := u.MovieRepo.FindByID(movieID.String())
+	if err != nil {
+		return authorID, movieID, err
+	}
+	if !canView(movie, userID, role) {
+		return authorID, movieID, errors.New(targetType + " not found")
+	}
+	return authorID, movieID, nil
+}
+
+// reportedContent shows moderators the content as it is now.
+func (u *ReportUsecase) reportedContent(report *domain.Report) (*dto.ReportedContent, error) {
+	var content dto.ReportedContent
+	var err error
+	switch report.TargetType {
+	case domain.ReportTargetMovie:
+		var movie *domain.Movie
+		if movie, err = u.MovieRepo.FindByID(report.TargetID.String()); err == nil {
+			content = dto.ReportedContent{
+				AuthorID: movie.UserID.String(),
+				Title:    movie.Title,
+				Text:     excerpt(movie.Description, reportExcerptLength),
+				Status:   movie.Status,
+			}
+		}
+		err = ignoreNotFound(err, "movie not found")
+	case domain.ReportTargetReview:
+		var review *domain.Review
+		if review, err = u.ReviewRepo.FindByID(report.TargetID.String()); err == nil {
+			content = dto.ReportedContent{
+				AuthorID: review.UserID.String(),
+				Text:     excerpt(review.Body, reportExcerptLength),
+			}
+		}
+		err = ignoreNotFound(err, "review not found")
+	case domain.ReportTargetComment:
+		var comment *domain.Comment
+		if comment, err = u.CommentRepo.FindByID(report.TargetID.String()); err == nil && !comment.Deleted {
+			content = dto.ReportedContent{
+				AuthorID: comment.UserID.String(),
+				Text:     excerpt(comment.Body, reportExcerptLength),
+			}
+			if comment.Hidden {
+				content.Status = "hidden"
+			}
+		}
+		err = ignoreNotFound(err, "comment not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	content.Gone = content.AuthorID == ""
+	return &content, nil
+}
+
+// ignoreNotFound drops the given not found error, for content that was
+// deleted after it was reported.
+func ignoreNotFound(err error, notFound string) error {
+	if err != nil && err.Error() == notFound {
+		return nil
+	}
+	return err
+}
+
+func excerpt(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length]) + "…"
+}
+
+func toReportResponse(report *domain.Report) dto.ReportResponse {
+	resp := dto.ReportResponse{
+		ID:             report.ID.String(),
+		TargetType:     report.TargetType,
+		TargetID:       report.TargetID.String(),
+		MovieID:        report.MovieID.String(),
+		Reason:         report.Reason,
+		Details:        report.Details,
+		Status:         report.Status,
+		ResolutionNote: report.ResolutionNote,
+		ResolvedAt:     report.ResolvedAt,
+		CreatedAt:      report.CreatedAt,
+	}
+	if report.ReporterID != nil {
+		reporterID := report.ReporterID.String()
+		resp.ReporterID = &reporterID
+	}
+	if report.ResolvedBy != nil {
+		resolvedBy := report.ResolvedBy.String()
+		resp.ResolvedBy = &resolvedBy
+	}
+	return resp
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReviewUsecase struct {
+	ReviewRepo    repository.ReviewRepository
+	MovieRepo     repository.MovieRepository
+	ContentFilter *ContentFilter // Optional
+}
+
+func NewReviewUsecase(reviewRepo repository.ReviewRepository, movieRepo repository.MovieRepository, contentFilter *ContentFilter) *ReviewUsecase {
+	return &ReviewUsecase{ReviewRepo: reviewRepo, MovieRepo: movieRepo, ContentFilter: contentFilter}
+}
+
+func (u *ReviewUsecase) CreateReview(movieID string, req *dto.CreateReviewRequest, userID string) (*dto.ReviewResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !isLive(movie, time.Now()) {
+		return nil, errors.New("movie not found")
+	}
+	if _, err := u.ReviewRepo.FindByMovieAndUser(movieID, userID); err == nil {
+		return nil, errors.New("you have already reviewed this movie")
+	}
+	blocked, err := u.ContentFilter.screen(req.Review)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &domain.Review{
+		ID:      uuid.New(),
+		MovieID: movie.ID,
+		UserID:  uuid.MustParse(userID),
+		Rating:  req.Rating,
+		Body:    req.Review,
+	}
+	if err := u.ReviewRepo.Create(review); err != nil {
+		return nil, err
+	}
+	if err := u.ContentFilter.queue(domain.ReportTargetReview, review.ID, review.MovieID, blocked); err != nil {
+		return nil, err
+	}
+	resp := toReviewResponse(review)
+	return &resp, nil
+}
+
+func (u *ReviewUsecase) UpdateReview(movieID, reviewID string, req *dto.UpdateReviewRequest, userID string) (*dto.ReviewResponse, error) {
+	review, err := u.findMovieReview(movieID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this review")
+	}
+	blocked, err := u.ContentFilter.screen(req.Review)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Rating = req.Rating
+	review.Body = req.Review
+	if err := u.ReviewRepo.Update(review); err != nil {
+		return nil, err
+	}
+	if err := u.ContentFilter.queue(domain.ReportTargetReview, review.ID, review.MovieID, blocked); err != nil {
+		return nil, err
+	}
+	resp := toReviewResponse(review)
+	return &resp, nil
+}
+
+func (u *ReviewUsecase) DeleteReview(movieID, reviewID string, userID string) error {
+	review, err := u.findMovieReview(movieID, reviewID)
+	if err != nil {
+		return err
+	}
+	if review.UserID.String() != userID {
+		return errors.New("forbidden: you do not own this review")
+	}
+	return u.ReviewRepo.Delete(review)
+}
+
+func (u *ReviewUsecase) GetMovieReviews(movieID string, req *dto.GetReviewsRequest, userID, role string) (*dto.GetReviewsResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+	reviews, totalCount, err := u.ReviewRepo.GetMovieReviews(movieID, req.Page, req.PageSize, req.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewResponses := make([]dto.ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		reviewResponses[i] = toReviewResponse(review)
+	}
+
+	return &dto.GetReviewsResponse{
+		Reviews:    reviewResponses,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
+
+func (u *ReviewUsecase) findMovieReview(movieID, reviewID string) (*domain.Review, error) {
+	review, err := u.ReviewRepo.FindByID(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.MovieID.String() != movieID {
+		return nil, errors.New("review not found")
+	}
+	return review, nil
+}
+
+func toReviewResponse(review *domain.Review) dto.ReviewResponse {
+	return dto.ReviewResponse{
+		ID:        review.ID.String(),
+		MovieID:   review.MovieID.String(),
+		UserID:    review.UserID.String(),
+		Rating:    review.Rating,
+		Review:    review.Body,
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
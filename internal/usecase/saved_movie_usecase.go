@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavedMovieUsecase struct {
+	SavedMovieRepo repository.SavedMovieRepository
+	MovieRepo      repository.MovieRepository
+	HistoryRepo    repository.HistoryRepository // Fills in the watchlist's watched status and progress
+}
+
+func NewSavedMovieUsecase(savedMovieRepo repository.SavedMovieRepository, movieRepo repository.MovieRepository, historyRepo repository.HistoryRepository) *SavedMovieUsecase {
+	return &SavedMovieUsecase{SavedMovieRepo: savedMovieRepo, MovieRepo: movieRepo, HistoryRepo: historyRepo}
+}
+
+func (u *SavedMovieUsecase) SaveMovie(userID, list string, req *dto.SaveMovieRequest) (*dto.SavedMovieResponse, error) {
+	movie, err := u.MovieRepo.FindByID(req.MovieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, "") {
+		return nil, errors.New("movie not found")
+	}
+	if _, err := u.SavedMovieRepo.Find(userID, req.MovieID, list); err == nil {
+		return nil, errors.New("movie is already in this list")
+	}
+
+	item := &domain.SavedMovie{
+		ID:      uuid.New(),
+		UserID:  uuid.MustParse(userID),
+		List:    list,
+		MovieID: movie.ID,
+	}
+	// A movie the user already finished goes on the watchlist as watched
+	var progress map[uuid.UUID]*domain.WatchHistory
+	if list == domain.ListWatchlist {
+		progress, err = u.HistoryRepo.GetProgress(item.UserID, []uuid.UUID{movie.ID})
+		if err != nil {
+			return nil, err
+		}
+		if entry := progress[movie.ID]; entry != nil && entry.TimesCompleted > 0 {
+			item.Watched = true
+			item.WatchedAt = entry.CompletedAt
+		}
+	}
+	if err := u.SavedMovieRepo.Add(item); err != nil {
+		return nil, err
+	}
+	item.Movie = movie
+	resp := toSavedMovieResponse(item, progress)
+	return &resp, nil
+}
+
+func (u *SavedMovieUsecase) RemoveMovie(userID, list, movieID string) error {
+	item, err := u.SavedMovieRepo.Find(userID, movieID, list)
+	if err != nil {
+		return err
+	}
+	return u.SavedMovieRepo.Remove(item)
+}
+
+func (u *SavedMovieUsecase) UpdateWatchlistItem(userID, movieID string, req *dto.UpdateWatchlistItemRequest) (*dto.SavedMovieResponse, error) {
+	item, err := u.SavedMovieRepo.Find(userID, movieID, domain.ListWatchlist)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Watched != nil && *req.Watched != item.Watched {
+		item.Watched = *req.Watched
+		item.WatchedAt = nil
+		if item.Watched {
+			now := time.Now()
+			item.WatchedAt = &now
+		}
+		if err := u.SavedMovieRepo.Update(item); err != nil {
+			return nil, err
+		}
+	}
+	if req.Position != nil {
+		if err := u.SavedMovieRepo.Move(item, *req.Position); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := toSavedMovieResponse(item, nil)
+	return &resp, nil
+}
+
+func (u *SavedMovieUsecase) MoveFavorite(userID, movieID string, req *dto.UpdateFavoriteRequest) (*dto.SavedMovieResponse, error) {
+	item, err := u.SavedMovieRepo.Find(userID, movieID, domain.ListFavorites)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.SavedMovieRepo.Move(item, req.Position); err != nil {
+		return nil, err
+	}
+	resp := toSavedMovieResponse(item, nil)
+	return &resp, nil
+}
+
+func (u *SavedMovieUsecase) GetList(userID, list string, req *dto.GetSavedMoviesRequest) (*dto.GetSavedMoviesResponse, error) {
+	watched := req.Watched
+	if list != domain.ListWatchlist {
+		watched = nil
+	}
+	items, totalCount, err := u.SavedMovieRepo.GetList(userID, list, req.Page, req.PageSize, watched)
+	if err != nil {
+		return nil, err
+	}
+
+	var progress map[uuid.UUID]*domain.WatchHistory
+	if list == domain.ListWatchlist {
+		movieIDs := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			movieIDs[i] = item.MovieID
+		}
+		progress, err = u.HistoryRepo.GetProgress(uuid.MustParse(userID), movieIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	itemResponses := make([]dto.SavedMovieResponse, len(items))
+	for i, item := range items {
+		itemResponses[i] = toSavedMovieResponse(item, progress)
+	}
+
+	return &dto.GetSavedMoviesResponse{
+		Items:      itemResponses,
+		PageNumber: req.Page,
+		PageSize:   req.PageSize,
+		TotalSize:  totalCount,
+	}, nil
+}
+
+// toSavedMovieResponse shows the viewing progress of unwatched watchlist
+// movies found in progress, which may be nil.
+func toSavedMovieResponse(item *domain.SavedMovie, progress map[uuid.UUID]*domain.WatchHistory) dto.SavedMovieResponse {
+	resp := dto.SavedMovieResponse{
+		Position: item.Position,
+		AddedAt:  item.CreatedAt,
+	}
+	if item.Movie != nil {
+		resp.Movie = toMovieResponse(item.Movie)
+	}
+	if item.List == domain.ListWatchlist {
+		watched := item.Watched
+		resp.Watched = &watched
+		resp.WatchedAt = item.WatchedAt
+		if entry := progress[item.MovieID]; entry != nil && !item.Watched && !entry.Completed && entry.Progress > 0 {
+			percent := entry.Progress
+			resp.Progress = &percent
+		}
+	}
+	return resp
+}
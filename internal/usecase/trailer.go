@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/pkg/trailer"
+	"log"
+	"time"
+)
+
+const trailerVerifyTimeout = 5 * time.Second
+
+// setTrailer stores the canonical form of a trailer link. New videos are
+// checked with the provider when a verifier is configured; an unreachable
+// provider does not block saving the movie.
+func (u *MovieUsecase) setTrailer(movie *domain.Movie, rawURL string) error {
+	video, err := trailer.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if movie.TrailerProvider == video.Provider && movie.TrailerVideoID == video.ID {
+		movie.Trailer = video.URL()
+		return nil
+	}
+
+	thumbnail := video.ThumbnailURL()
+	if u.Trailers != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), trailerVerifyTimeout)
+		defer cancel()
+		details, err := u.Trailers.Verify(ctx, *video)
+		switch {
+		case errors.Is(err, trailer.ErrVideoNotFound):
+			return err
+		case err != nil:
+			log.Printf("Warning: could not verify trailer %s: %v", video.URL(), err)
+		case details.ThumbnailURL != "":
+			thumbnail = details.ThumbnailURL
+		}
+	}
+
+	movie.Trailer = video.URL()
+	movie.TrailerProvider = video.Provider
+	movie.TrailerVideoID = video.ID
+	movie.TrailerThumbnail = thumbnail
+	return nil
+}
+
+// toTrailerResponse also covers movies saved before trailers were parsed by
+// parsing the stored link on the fly.
+func toTrailerResponse(movie *domain.Movie) *dto.TrailerResponse {
+	video := &trailer.Video{Provider: movie.TrailerProvider, ID: movie.TrailerVideoID}
+	thumbnail := movie.TrailerThumbnail
+	if video.Provider == "" {
+		parsed, err := trailer.Parse(movie.Trailer)
+		if err != nil {
+			return nil
+		}
+		video, thumbnail = parsed, parsed.ThumbnailURL()
+	}
+	return &dto.TrailerResponse{
+		Provider:     video.Provider,
+		VideoID:      video.ID,
+		URL:          video.URL(),
+		EmbedURL:     video.EmbedURL(),
+		ThumbnailURL: thumbnail,
+	}
+}
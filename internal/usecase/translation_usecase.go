@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/domain"
+	"eskalate-movie-api/internal/dto"
+	"eskalate-movie-api/internal/repository"
+	"eskalate-movie-api/pkg/i18n"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TranslationUsecase struct {
+	TranslationRepo repository.TranslationRepository
+	MovieRepo       repository.MovieRepository
+}
+
+func NewTranslationUsecase(translationRepo repository.TranslationRepository, movieRepo repository.MovieRepository) *TranslationUsecase {
+	return &TranslationUsecase{TranslationRepo: translationRepo, MovieRepo: movieRepo}
+}
+
+func (u *TranslationUsecase) GetMovieTranslations(movieID string, userID, role string) ([]dto.TranslationResponse, error) {
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(movie, userID, role) {
+		return nil, errors.New("movie not found")
+	}
+	translations, err := u.TranslationRepo.GetMovieTranslations(movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]dto.TranslationResponse, len(translations))
+	for i, translation := range translations {
+		responses[i] = toTranslationResponse(translation)
+	}
+	return responses, nil
+}
+
+// PutTranslation creates or replaces the movie's texts in one locale.
+func (u *TranslationUsecase) PutTranslation(movieID, locale string, req *dto.PutTranslationRequest, userID string) (*dto.TranslationResponse, error) {
+	locale, err := i18n.Normalize(locale)
+	if err != nil {
+		return nil, err
+	}
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	if movie.UserID.String() != userID {
+		return nil, errors.New("forbidden: you do not own this movie")
+	}
+
+	translation := &domain.MovieTranslation{
+		ID:          uuid.New(),
+		MovieID:     movie.ID,
+		Locale:      locale,
+		Title:       req.Title,
+		Description: req.Description,
+		Tagline:     req.Tagline,
+		UpdatedAt:   time.Now(),
+	}
+	if err := u.TranslationRepo.Save(translation); err != nil {
+		return nil, err
+	}
+	resp := toTranslationResponse(translation)
+	return &resp, nil
+}
+
+func (u *TranslationUsecase) DeleteTranslation(movieID, locale string, userID string) error {
+	locale, err := i18n.Normalize(locale)
+	if err != nil {
+		return err
+	}
+	movie, err := u.MovieRepo.FindByID(movieID)
+	if err != nil {
+		return err
+	}
+	if movie.UserID.String() != userID {
+		return errors.New("forbidden: you do not own this movie")
+	}
+	return u.TranslationRepo.Delete(movie.ID, locale)
+}
+
+// localize swaps the texts of resp for the translation that best serves the
+// preferred locales. Texts missing from the translation, and movies without
+// a suitable translation, stay in the original language.
+func localize(resp *dto.MovieResponse, movie *domain.Movie, translations []*domain.MovieTranslation, preferences []string) {
+	locale, ok := i18n.Select(preferences, availableLocales(movie, translations))
+	if !ok {
+		return
+	}
+	for _, translation := range translations {
+		if translation.Locale != locale {
+			continue
+		}
+		resp.Title = translation.Title
+		if translation.Description != "" {
+			resp.Description = translation.Description
+		}
+		if translation.Tagline != "" {
+			resp.Tagline = translation.Tagline
+		}
+		resp.Locale = locale
+		return
+	}
+}
+
+// availableLocales lists the original language first, then every translation.
+func availableLocales(movie *domain.Movie, translations []*domain.MovieTranslation) []string {
+	locales := make([]string, 0, len(translations)+1)
+	if movie.OriginalLanguage != "" {
+		locales = append(locales, movie.OriginalLanguage)
+	}
+	for _, translation := range translations {
+		if translation.Locale != movie.OriginalLanguage {
+			locales = append(locales, translation.Locale)
+		}
+	}
+	return locales
+}
+
+func toTranslationResponse(translation *domain.MovieTranslation) dto.TranslationResponse {
+	return dto.TranslationResponse{
+		Locale:      translation.Locale,
+		Title:       translation.Title,
+		Description: translation.Description,
+		Tagline:     translation.Tagline,
+		UpdatedAt:   translation.UpdatedAt,
+	}
+}
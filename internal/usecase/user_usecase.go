@@ -35,6 +35,7 @@ func (u *UserUsecase) Signup(req *dto.SignupRequest) (*domain.User, error) {
 		Email:    req.Email,
 		Username: req.Username,
 		Password: hash,
+		Role:     domain.RoleUser,
 	}
 
 	err = u.UserRepo.Create(user)
@@ -64,7 +65,7 @@ func (u *UserUsecase) Login(req *dto.LoginRequest) (string, error) {
 	if !security.CheckPasswordHash(req.Password, user.Password) {
 		return "", errors.New("invalid email or password")
 	}
-	token, err := security.GenerateJWT(user.ID.String(), user.Email)
+	token, err := security.GenerateJWT(user.ID.String(), user.Email, user.Role)
 	if err != nil {
 		return "", errors.New("failed to generate token")
 	}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"eskalate-movie-api/internal/repository"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	viewQueueSize  = 1000
+	viewBatchSize  = 500                // Distinct movies and days buffered before an early flush
+	viewMaxPending = 20 * viewBatchSize // Distinct movies and days kept while writes fail
+)
+
+type viewEvent struct {
+	movieID uuid.UUID
+	day     time.Time
+}
+
+type viewKey struct {
+	viewer  string
+	movieID uuid.UUID
+}
+
+// viewCounts buffers counts per day and movie. While writes fail it holds at
+// most viewMaxPending of them; views of other movies are dropped until the
+// buffer can be written again.
+type viewCounts struct {
+	days    map[time.Time]map[uuid.UUID]int64
+	size    int
+	dropped int64 // Views dropped since the last flush because the buffer was full
+}
+
+func newViewCounts() *viewCounts {
+	return &viewCounts{days: make(map[time.Time]map[uuid.UUID]int64)}
+}
+
+func (p *viewCounts) add(event viewEvent) {
+	counts := p.days[event.day]
+	if counts[event.movieID] == 0 {
+		if p.size >= viewMaxPending {
+			p.dropped++
+			return
+		}
+		if counts == nil {
+			counts = make(map[uuid.UUID]int64)
+			p.days[event.day] = counts
+		}
+		p.size++
+	}
+	counts[event.movieID]++
+}
+
+// ViewTracker counts movie views without slowing down reads. A viewer is
+// counted once per movie and dedupe window; counts are buffered and written
+// in batches. Views still buffered when the server stops are lost.
+type ViewTracker struct {
+	ViewRepo      repository.ViewRepository
+	window        time.Duration
+	flushInterval time.Duration
+	events        chan viewEvent
+
+	mu   sync.Mutex
+	seen map[viewKey]time.Time // When each viewer was last counted
+}
+
+func NewViewTracker(viewRepo repository.ViewRepository, window, flushInterval time.Duration) *ViewTracker {
+	return &ViewTracker{
+		ViewRepo:      viewRepo,
+		window:        window,
+		flushInterval: flushInterval,
+		events:        make(chan viewEvent, viewQueueSize),
+		seen:          make(map[viewKey]time.Time),
+	}
+}
+
+// Start launches the goroutine that writes the buffered counts.
+func (t *ViewTracker) Start() {
+	go t.run()
+}
+
+// Record counts a view of the movie unless the viewer was counted within the
+// window. It never blocks; views are dropped when the queue is full.
+func (t *ViewTracker) Record(movieID uuid.UUID, viewer string) {
+	if t == nil || viewer == "" {
+		return
+	}
+	now := time.Now()
+	key := viewKey{viewer: viewer, movieID: movieID}
+
+	t.mu.Lock()
+	if last, ok := t.seen[key]; ok && now.Sub(last) < t.window {
+		t.mu.Unlock()
+		return
+	}
+	t.seen[key] = now
+	t.mu.Unlock()
+
+	utc := now.UTC()
+	select {
+	case t.events <- viewEvent{movieID: movieID, day: time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)}:
+	default:
+	}
+}
+
+func (t *ViewTracker) run() {
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+
+	pending := newViewCounts()
+	retrying := false // Failed writes are only retried on the ticker
+	for {
+		select {
+		case event := <-t.events:
+			pending.add(event)
+			if pending.size < viewBatchSize || retrying {
+				continue
+			}
+		case <-ticker.C:
+			t.forgetExpired()
+		}
+		pending = t.flush(pending)
+		retrying = pending.size > 0
+	}
+}
+
+// flush writes the pending counts and returns what could not be written, to
+// be retried with the next batch.
+func (t *ViewTracker) flush(pending *viewCounts) *viewCounts {
+	if pending.dropped > 0 {
+		log.Printf("dropped %d movie views because saving views kept failing", pending.dropped)
+	}
+	failed := newViewCounts()
+	for day, counts := range pending.days {
+		if err := t.ViewRepo.AddViews(day, counts); err != nil {
+			log.Printf("failed to save movie views: %v", err)
+			failed.days[day] = counts
+			failed.size += len(counts)
+		}
+	}
+	return failed
+}
+
+// forgetExpired drops dedupe entries older than the window so the map only
+// holds recent viewers.
+func (t *ViewTracker) forgetExpired() {
+	cutoff := time.Now().Add(-t.window)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, last := range t.seen {
+		if last.Before(cutoff) {
+			delete(t.seen, key)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"errors"
+	"eskalate-movie-api/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type fakeViewRepo struct {
+	repository.ViewRepository
+	err   error
+	saved int64
+}
+
+func (r *fakeViewRepo) AddViews(day time.Time, counts map[uuid.UUID]int64) error {
+	if r.err != nil {
+		return r.err
+	}
+	for _, count := range counts {
+		r.saved += count
+	}
+	return nil
+}
+
+func TestViewTrackerCapsPendingCountsWhileWritesFail(t *testing.T) {
+	views := &fakeViewRepo{err: errors.New("database is down")}
+	tracker := NewViewTracker(views, time.Minute, time.Second)
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	pending := newViewCounts()
+	known := uuid.New()
+	pending.add(viewEvent{movieID: known, day: day})
+	for i := 0; i < 3; i++ {
+		for j := 0; j < viewMaxPending; j++ {
+			pending.add(viewEvent{movieID: uuid.New(), day: day})
+		}
+		pending = tracker.flush(pending)
+	}
+	if pending.size != viewMaxPending || pending.dropped != 0 {
+		t.Fatalf("got %d pending counts and %d dropped views after flushing, want %d and 0", pending.size, pending.dropped, viewMaxPending)
+	}
+
+	// Movies already buffered keep counting
+	pending.add(viewEvent{movieID: known, day: day})
+	pending.add(viewEvent{movieID: uuid.New(), day: day})
+	if pending.days[day][known] != 2 || pending.dropped != 1 {
+		t.Fatalf("got %d views of the buffered movie and %d dropped, want 2 and 1", pending.days[day][known], pending.dropped)
+	}
+
+	views.err = nil
+	if pending = tracker.flush(pending); pending.size != 0 {
+		t.Fatalf("%d counts left after a successful flush", pending.size)
+	}
+	if want := int64(viewMaxPending + 1); views.saved != want {
+		t.Fatalf("saved %d views, want %d", views.saved, want)
+	}
+}
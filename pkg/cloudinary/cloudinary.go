@@ -2,37 +2,238 @@ package cloudinary
 
 import (
 	"context"
-	"mime/multipart"
-	"os"
+	"errors"
+	"eskalate-movie-api/pkg/storage"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 )
 
-func UploadPoster(file multipart.File, header *multipart.FileHeader) (string, error) {
-	// Initialize cloudinary using environment variables
-	cld, err := cloudinary.NewFromParams(
-		os.Getenv("CLOUDINARY_CLOUD_NAME"),
-		os.Getenv("CLOUDINARY_API_KEY"),
-		os.Getenv("CLOUDINARY_API_SECRET"),
-	)
+// Store keeps images in Cloudinary. Cloudinary tracks the file extension as
+// the asset format rather than part of the public ID, so the extension is
+// folded into the public ID to keep e.g. poster.jpg and poster.webp apart.
+type Store struct {
+	cld *cloudinary.Cloudinary
+}
+
+func NewStore(cloudName, apiKey, apiSecret string) (*Store, error) {
+	cld, err := cloudinary.NewFromParams(cloudName, apiKey, apiSecret)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	return &Store{cld: cld}, nil
+}
 
-	// Upload file
-	uploadResult, err := cld.Upload.Upload(
-		context.Background(),
-		file,
+func (s *Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*storage.Object, error) {
+	uploadResult, err := s.cld.Upload.Upload(
+		ctx,
+		body,
 		uploader.UploadParams{
-			Folder:         "movie-posters",
-			AllowedFormats: []string{"jpg", "jpeg", "png"},
+			PublicID:       publicID(key),
+			Overwrite:      api.Bool(true),
+			UniqueFilename: api.Bool(false),
 			ResourceType:   "image",
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	if uploadResult.Error.Message != "" {
+		return nil, errors.New(uploadResult.Error.Message)
+	}
+
+	return &storage.Object{Key: key, URL: uploadResult.SecureURL}, nil
+}
+
+// Get downloads the original image from its delivery URL.
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	url, err := s.assetURL(key, false)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("cloudinary GET %s: %s", key, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+// PresignUpload returns a signed upload to the Cloudinary upload API.
+// Cloudinary cannot limit the size of a signed upload, so maxSize is only
+// enforced when the upload is validated afterwards.
+func (s *Store) PresignUpload(ctx context.Context, key, contentType string, maxSize int64, expiry time.Duration) (*storage.DirectUpload, error) {
+	now := time.Now()
+	params := url.Values{
+		"public_id":       {publicID(key)},
+		"timestamp":       {strconv.FormatInt(now.Unix(), 10)},
+		"allowed_formats": {strings.TrimPrefix(path.Ext(key), ".")},
+	}
+	signature, err := api.SignParameters(params, s.cld.Config.Cloud.APISecret)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]string{
+		"api_key":   s.cld.Config.Cloud.APIKey,
+		"signature": signature,
+	}
+	for name := range params {
+		fields[name] = params.Get(name)
+	}
+	// Cloudinary rejects signed uploads after an hour regardless of expiry
+	if expiry > time.Hour {
+		expiry = time.Hour
+	}
+	return &storage.DirectUpload{
+		Method:    http.MethodPost,
+		URL:       "https://api.cloudinary.com/v1_1/" + s.cld.Config.Cloud.CloudName + "/image/upload",
+		Fields:    fields,
+		FileField: "file",
+		ExpiresAt: now.Add(expiry),
+	}, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	result, err := s.destroy(ctx, publicID(key))
+	if err != nil {
+		return err
+	}
+	if result == "not found" {
+		// Images uploaded before formats were folded into the public ID
+		_, err = s.destroy(ctx, strings.TrimSuffix(key, path.Ext(key)))
+	}
+	return err
+}
+
+func (s *Store) destroy(ctx context.Context, publicID string) (string, error) {
+	result, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID:     publicID,
+		ResourceType: "image",
+		Invalidate:   api.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	if result.Error.Message != "" {
+		return "", errors.New(result.Error.Message)
+	}
+	return result.Result, nil
+}
+
+func (s *Store) URL(key string) string {
+	url, _ := s.assetURL(key, false)
+	return url
+}
+
+// SignedURL returns a URL carrying a Cloudinary signature. Cloudinary
+// signatures do not expire, so expiry is ignored.
+func (s *Store) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.assetURL(key, true)
+}
+
+// KeyFromURL understands delivery URLs of this cloud as returned by URL,
+// SignedURL and the upload API, with or without a version segment.
+func (s *Store) KeyFromURL(rawURL string) (string, bool) {
+	rawURL, _, _ = strings.Cut(rawURL, "?")
+	rawURL = strings.Replace(rawURL, "http://", "https://", 1)
+	rest, ok := strings.CutPrefix(rawURL, "https://res.cloudinary.com/"+s.cld.Config.Cloud.CloudName+"/image/upload/")
+	if !ok {
+		return "", false
+	}
+	segments := strings.Split(rest, "/")
+	if len(segments) > 1 && strings.HasPrefix(segments[0], "s--") {
+		segments = segments[1:]
+	}
+	if len(segments) > 1 && versionSegment.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+
+	asset := strings.Join(segments, "/")
+	ext := path.Ext(asset)
+	if ext == "" {
+		return "", false
+	}
+	return keyOf(strings.TrimSuffix(asset, ext), ext[1:]), true
+}
+
+// List walks the Admin API listing of uploaded images under prefix.
+func (s *Store) List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	var objects []storage.ObjectInfo
+	cursor := ""
+	for {
+		result, err := s.cld.Admin.Assets(ctx, admin.AssetsParams{
+			AssetType:    api.Image,
+			DeliveryType: "upload",
+			Prefix:       prefix,
+			MaxResults:   500,
+			NextCursor:   cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if result.Error.Message != "" {
+			return nil, errors.New(result.Error.Message)
+		}
+
+		for _, asset := range result.Assets {
+			objects = append(objects, storage.ObjectInfo{
+				Key:          keyOf(asset.PublicID, asset.Format),
+				Size:         int64(asset.Bytes),
+				LastModified: asset.CreatedAt,
+			})
+		}
+		if result.NextCursor == "" {
+			return objects, nil
+		}
+		cursor = result.NextCursor
+	}
+}
+
+func (s *Store) assetURL(key string, signed bool) (string, error) {
+	// Delivery URLs take the public ID followed by the format extension
+	image, err := s.cld.Image(publicID(key) + path.Ext(key))
 	if err != nil {
 		return "", err
 	}
+	image.Config.URL.Secure = true
+	image.Config.URL.SignURL = signed
+	return image.String()
+}
 
-	return uploadResult.SecureURL, nil
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// keyOf reverses publicID. Images uploaded before formats were folded into
+// the public ID map to the public ID plus their format.
+func keyOf(publicID, format string) string {
+	if base, ok := strings.CutSuffix(publicID, "_"+format); ok {
+		return base + "." + format
+	}
+	return publicID + "." + format
+}
+
+func publicID(key string) string {
+	ext := path.Ext(key)
+	if ext == "" {
+		return key
+	}
+	return strings.TrimSuffix(key, ext) + "_" + ext[1:]
 }
@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"errors"
+	"sort"
+
+	"golang.org/x/text/language"
+)
+
+var ErrInvalidLocale = errors.New("locale must be a language tag such as en, fr or pt-BR")
+
+// Normalize validates a BCP 47 language tag and returns it in canonical
+// case, e.g. "pt-br" becomes "pt-BR".
+func Normalize(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return "", ErrInvalidLocale
+	}
+	return tag.String(), nil
+}
+
+// Preferences lists the locales a client asked for, most preferred first. An
+// explicit lang parameter wins over the Accept-Language header; invalid
+// entries are ignored.
+func Preferences(lang, acceptLanguage string) []string {
+	if lang != "" {
+		if locale, err := Normalize(lang); err == nil {
+			return []string{locale}
+		}
+	}
+	if acceptLanguage == "" {
+		return nil
+	}
+	tags, q, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil
+	}
+	var locales []string
+	for i, tag := range tags {
+		// The "*" wildcard parses as "mul"; the original serves it anyway
+		if q[i] > 0 && tag != language.Und && tag.String() != "mul" {
+			locales = append(locales, tag.String())
+		}
+	}
+	return locales
+}
+
+// Select picks the available locale that best serves the preferences. For
+// each preference in turn it tries the exact locale, then the bare language
+// and then any regional variant of the language, so "pt-BR" falls back to
+// "pt" and then "pt-PT". It reports false when nothing matches.
+func Select(preferences, available []string) (string, bool) {
+	if len(preferences) == 0 || len(available) == 0 {
+		return "", false
+	}
+	sorted := append([]string(nil), available...)
+	sort.Strings(sorted)
+
+	for _, preference := range preferences {
+		for _, locale := range sorted {
+			if locale == preference {
+				return locale, true
+			}
+		}
+		base := baseLanguage(preference)
+		for _, locale := range sorted {
+			if locale == base {
+				return locale, true
+			}
+		}
+		for _, locale := range sorted {
+			if baseLanguage(locale) == base {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
+
+func baseLanguage(locale string) string {
+	base, _ := language.Make(locale).Base()
+	return base.String()
+}
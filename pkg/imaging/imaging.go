@@ -0,0 +1,204 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	FormatJPEG = "jpeg"
+	FormatWebP = "webp"
+)
+
+var (
+	ErrTooLarge          = errors.New("image is too large")
+	ErrUnsupportedFormat = errors.New("image must be a JPEG, PNG or WebP file")
+)
+
+// Limits bound what an uploaded image may be.
+type Limits struct {
+	MaxBytes  int64
+	MinWidth  int
+	MinHeight int
+	MaxWidth  int
+	MaxHeight int
+}
+
+// Size is a named target width. Images are never upscaled.
+type Size struct {
+	Name  string
+	Width int
+}
+
+// Variant is one encoded rendition of a processed image.
+type Variant struct {
+	Name        string
+	Format      string
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+var PosterLimits = Limits{
+	MaxBytes:  10 << 20,
+	MinWidth:  200,
+	MinHeight: 300,
+	MaxWidth:  8000,
+	MaxHeight: 8000,
+}
+
+var PosterSizes = []Size{
+	{Name: "thumbnail", Width: 185},
+	{Name: "card", Width: 500},
+	{Name: "full", Width: 1280},
+}
+
+// MediaLimits apply to gallery images, which may be landscape backdrops.
+var MediaLimits = Limits{
+	MaxBytes:  15 << 20,
+	MinWidth:  200,
+	MinHeight: 200,
+	MaxWidth:  8000,
+	MaxHeight: 8000,
+}
+
+var MediaSizes = []Size{
+	{Name: "thumbnail", Width: 300},
+	{Name: "medium", Width: 780},
+	{Name: "full", Width: 1920},
+}
+
+// Process validates an uploaded image by its actual content rather than the
+// declared content type, then renders every size as JPEG and, where it comes
+// out smaller, as WebP. The WebP encoder is lossless, so photographs usually
+// only get the JPEG while flat artwork gets both. Metadata such as EXIF is
+// dropped by re-encoding, after applying the EXIF orientation.
+func Process(r io.Reader, limits Limits, sizes []Size) ([]Variant, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limits.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limits.MaxBytes {
+		return nil, ErrTooLarge
+	}
+
+	contentType, _, err := DetectFormat(data)
+	if err != nil {
+		return nil, err
+	}
+	var decode func(io.Reader) (image.Image, error)
+	var decodeConfig func(io.Reader) (image.Config, error)
+	switch contentType {
+	case "image/jpeg":
+		decode, decodeConfig = jpeg.Decode, jpeg.DecodeConfig
+	case "image/png":
+		decode, decodeConfig = png.Decode, png.DecodeConfig
+	case "image/webp":
+		decode, decodeConfig = webp.Decode, webp.DecodeConfig
+	}
+
+	// Check dimensions before decoding so huge images are never expanded
+	config, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width < limits.MinWidth || config.Height < limits.MinHeight {
+		return nil, fmt.Errorf("image must be at least %dx%d pixels", limits.MinWidth, limits.MinHeight)
+	}
+	if config.Width > limits.MaxWidth || config.Height > limits.MaxHeight {
+		return nil, fmt.Errorf("image must be at most %dx%d pixels", limits.MaxWidth, limits.MaxHeight)
+	}
+
+	decoded, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	img := toNRGBA(decoded)
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, exifOrientation(data))
+	}
+
+	variants := make([]Variant, 0, len(sizes)*2)
+	for _, size := range sizes {
+		resized := resize(img, size.Width)
+		width, height := resized.Bounds().Dx(), resized.Bounds().Dy()
+
+		var jpegData bytes.Buffer
+		if err := jpeg.Encode(&jpegData, flatten(resized), &jpeg.Options{Quality: 85}); err != nil {
+			return nil, err
+		}
+		variants = append(variants, Variant{
+			Name: size.Name, Format: FormatJPEG, ContentType: "image/jpeg", Extension: ".jpg",
+			Width: width, Height: height, Data: jpegData.Bytes(),
+		})
+
+		var webpData bytes.Buffer
+		if err := encodeWebP(&webpData, nearLossless(resized, 2)); err != nil {
+			return nil, err
+		}
+		if webpData.Len() >= jpegData.Len() {
+			continue
+		}
+		variants = append(variants, Variant{
+			Name: size.Name, Format: FormatWebP, ContentType: "image/webp", Extension: ".webp",
+			Width: width, Height: height, Data: webpData.Bytes(),
+		})
+	}
+	return variants, nil
+}
+
+// DetectFormat sniffs the content type of an image from its first bytes and
+// returns it with the matching file extension.
+func DetectFormat(header []byte) (contentType, extension string, err error) {
+	switch contentType = http.DetectContentType(header); contentType {
+	case "image/jpeg":
+		return contentType, ".jpg", nil
+	case "image/png":
+		return contentType, ".png", nil
+	case "image/webp":
+		return contentType, ".webp", nil
+	}
+	return "", "", ErrUnsupportedFormat
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	bounds := img.Bounds()
+	out := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), img, bounds.Min, draw.Src)
+	return out
+}
+
+func resize(img *image.NRGBA, width int) *image.NRGBA {
+	bounds := img.Bounds()
+	if bounds.Dx() <= width {
+		return img
+	}
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	out := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(out, out.Bounds(), img, bounds, xdraw.Src, nil)
+	return out
+}
+
+// flatten composites the image onto white since JPEG has no alpha channel.
+func flatten(img *image.NRGBA) *image.RGBA {
+	out := image.NewRGBA(img.Bounds())
+	draw.Draw(out, out.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(out, out.Bounds(), img, img.Bounds().Min, draw.Over)
+	return out
+}
@@ -0,0 +1,91 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func encodePNG(t *testing.T, img image.Image) *bytes.Reader {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+// photo is a smooth gradient with sensor-like noise, which lossless
+// encoding handles poorly.
+func photo(width, height int) *image.NRGBA {
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := img.PixOffset(x, y)
+			for c := 0; c < 3; c++ {
+				v := (x*(c+1)+y*(3-c))*255/(width+height)/2 + rng.Intn(24)
+				img.Pix[i+c] = uint8(v)
+			}
+			img.Pix[i+3] = 0xff
+		}
+	}
+	return img
+}
+
+func formats(variants []Variant) map[string]map[string]Variant {
+	byName := make(map[string]map[string]Variant)
+	for _, variant := range variants {
+		if byName[variant.Name] == nil {
+			byName[variant.Name] = make(map[string]Variant)
+		}
+		byName[variant.Name][variant.Format] = variant
+	}
+	return byName
+}
+
+func TestProcessKeepsWebPOnlyWhenSmaller(t *testing.T) {
+	artwork, err := Process(encodePNG(t, stripes(800, 1200)), PosterLimits, PosterSizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range PosterSizes {
+		variant, ok := formats(artwork)[size.Name][FormatWebP]
+		if !ok {
+			t.Fatalf("artwork has no %s WebP variant", size.Name)
+		}
+		decoded, err := webp.DecodeConfig(bytes.NewReader(variant.Data))
+		if err != nil {
+			t.Fatalf("decoding %s WebP: %v", size.Name, err)
+		}
+		if decoded.Width != variant.Width || decoded.Height != variant.Height || variant.Width != min(size.Width, 800) {
+			t.Fatalf("%s WebP is %dx%d, variant says %dx%d", size.Name, decoded.Width, decoded.Height, variant.Width, variant.Height)
+		}
+		if jpeg := formats(artwork)[size.Name][FormatJPEG]; len(variant.Data) >= len(jpeg.Data) {
+			t.Fatalf("%s WebP has %d bytes, JPEG only %d", size.Name, len(variant.Data), len(jpeg.Data))
+		}
+	}
+
+	photographic, err := Process(encodePNG(t, photo(800, 1200)), PosterLimits, PosterSizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, byFormat := range formats(photographic) {
+		if _, ok := byFormat[FormatJPEG]; !ok {
+			t.Fatalf("photo has no %s JPEG variant", name)
+		}
+		if webpVariant, ok := byFormat[FormatWebP]; ok {
+			t.Fatalf("photo kept a %s WebP of %d bytes, JPEG is %d", name, len(webpVariant.Data), len(byFormat[FormatJPEG].Data))
+		}
+	}
+}
+
+func TestProcessRejectsSmallImages(t *testing.T) {
+	if _, err := Process(encodePNG(t, stripes(100, 100)), PosterLimits, PosterSizes); err == nil {
+		t.Fatal("accepted an image below the minimum size")
+	}
+}
@@ -0,0 +1,484 @@
+package imaging
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"image"
+	"io"
+)
+
+// Lossless WebP (VP8L) encoder. The standard library and golang.org/x/image
+// only decode WebP, so posters are encoded here with the subtract-green and
+// predictor transforms, run-length backward references to the left or top
+// pixel, and per-channel Huffman coding. There is no color cache or general
+// LZ77 matching, which keeps the encoder small at some cost in compression.
+
+const (
+	vp8lSignature        = 0x2f
+	predictorTransform   = 0
+	subtractGreen        = 2
+	predictorSizeBits    = 9 // 512x512 blocks share a predictor
+	predictorModeClamped = 12
+	numLiteralCodes      = 256
+	numLengthCodes       = 24
+	numDistanceCodes     = 40
+	maxCodeLength        = 15
+	maxCodeLengthCodeLen = 7
+	minRunLength         = 3
+	maxRunLength         = 4096
+	distanceCodeTop      = 1 // 2D neighbourhood code for the pixel above
+	distanceCodeLeft     = 2 // 2D neighbourhood code for the pixel to the left
+)
+
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+func encodeWebP(w io.Writer, img *image.NRGBA) error {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	argb := make([]uint32, width*height)
+	hasAlpha := false
+	for y := 0; y < height; y++ {
+		row := img.Pix[y*img.Stride:]
+		for x := 0; x < width; x++ {
+			p := row[x*4:]
+			argb[y*width+x] = uint32(p[3])<<24 | uint32(p[0])<<16 | uint32(p[1])<<8 | uint32(p[2])
+			if p[3] != 0xff {
+				hasAlpha = true
+			}
+		}
+	}
+
+	bw := &bitWriter{}
+	bw.write(vp8lSignature, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3) // version
+
+	// Subtract green transform
+	bw.write(1, 1)
+	bw.write(subtractGreen, 2)
+	for i, p := range argb {
+		g := (p >> 8) & 0xff
+		r := ((p >> 16) - g) & 0xff
+		b := (p - g) & 0xff
+		argb[i] = p&0xff00ff00 | r<<16 | b
+	}
+
+	// Predictor transform with a single mode for the whole image
+	bw.write(1, 1)
+	bw.write(predictorTransform, 2)
+	bw.write(predictorSizeBits-2, 3)
+	blockSize := 1 << predictorSizeBits
+	modes := make([]uint32, ((width+blockSize-1)/blockSize)*((height+blockSize-1)/blockSize))
+	for i := range modes {
+		modes[i] = predictorModeClamped << 8
+	}
+	writeEntropyImage(bw, modes, (width+blockSize-1)/blockSize, false)
+	argb = predictResiduals(argb, width, height)
+
+	bw.write(0, 1) // no more transforms
+	writeEntropyImage(bw, argb, width, true)
+	data := bw.bytes()
+
+	size := len(data)
+	padding := size & 1
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], uint32(4+8+size+padding))
+	copy(header[8:], "WEBPVP8L")
+	binary.LittleEndian.PutUint32(header[16:], uint32(size))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if padding == 1 {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	return nil
+}
+
+// predictResiduals replaces every pixel by its difference to the clamped
+// gradient prediction of its left, top and top-left neighbours.
+func predictResiduals(argb []uint32, width, height int) []uint32 {
+	residuals := make([]uint32, len(argb))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := y*width + x
+			var pred uint32
+			switch {
+			case x == 0 && y == 0:
+				pred = 0xff000000
+			case y == 0:
+				pred = argb[i-1]
+			case x == 0:
+				pred = argb[i-width]
+			default:
+				pred = clampAddSubtractFull(argb[i-1], argb[i-width], argb[i-width-1])
+			}
+			residuals[i] = subPixels(argb[i], pred)
+		}
+	}
+	return residuals
+}
+
+func clampAddSubtractFull(l, t, tl uint32) uint32 {
+	var out uint32
+	for shift := 0; shift < 32; shift += 8 {
+		v := int((l>>shift)&0xff) + int((t>>shift)&0xff) - int((tl>>shift)&0xff)
+		if v < 0 {
+			v = 0
+		} else if v > 255 {
+			v = 255
+		}
+		out |= uint32(v) << shift
+	}
+	return out
+}
+
+func subPixels(a, b uint32) uint32 {
+	alphaGreen := 0x00ff00ff + (a & 0xff00ff00) - (b & 0xff00ff00)
+	redBlue := 0xff00ff00 + (a & 0x00ff00ff) - (b & 0x00ff00ff)
+	return (alphaGreen & 0xff00ff00) | (redBlue & 0x00ff00ff)
+}
+
+// token is either a literal pixel or, when length > 0, a copy of length
+// pixels from the neighbour identified by distanceCode.
+type token struct {
+	pixel        uint32
+	length       int
+	distanceCode int
+}
+
+// tokenize replaces runs of pixels equal to their left or top neighbour by
+// backward references.
+func tokenize(argb []uint32, width int) []token {
+	var tokens []token
+	for i := 0; i < len(argb); {
+		leftRun, topRun := 0, 0
+		if i > 0 {
+			for leftRun < maxRunLength && i+leftRun < len(argb) && argb[i+leftRun] == argb[i+leftRun-1] {
+				leftRun++
+			}
+		}
+		if i >= width {
+			for topRun < maxRunLength && i+topRun < len(argb) && argb[i+topRun] == argb[i+topRun-width] {
+				topRun++
+			}
+		}
+
+		switch {
+		case leftRun >= minRunLength && leftRun >= topRun:
+			tokens = append(tokens, token{length: leftRun, distanceCode: distanceCodeLeft})
+			i += leftRun
+		case topRun >= minRunLength:
+			tokens = append(tokens, token{length: topRun, distanceCode: distanceCodeTop})
+			i += topRun
+		default:
+			tokens = append(tokens, token{pixel: argb[i]})
+			i++
+		}
+	}
+	return tokens
+}
+
+// prefixEncode splits a length or distance code into its prefix symbol and
+// extra bits.
+func prefixEncode(value int) (prefix int, extraBits uint, extra uint32) {
+	d := value - 1
+	if d < 4 {
+		return d, 0, 0
+	}
+	highBit := 0
+	for (d >> (highBit + 1)) > 0 {
+		highBit++
+	}
+	second := (d >> (highBit - 1)) & 1
+	extraBits = uint(highBit - 1)
+	return 2*highBit + second, extraBits, uint32(d) & (1<<extraBits - 1)
+}
+
+// writeEntropyImage writes an image with its five prefix codes. Only the
+// top-level image carries the meta prefix code bit.
+func writeEntropyImage(bw *bitWriter, argb []uint32, width int, topLevel bool) {
+	bw.write(0, 1) // no color cache
+	if topLevel {
+		bw.write(0, 1) // single prefix code group
+	}
+
+	tokens := tokenize(argb, width)
+	green := make([]int, numLiteralCodes+numLengthCodes)
+	red := make([]int, numLiteralCodes)
+	blue := make([]int, numLiteralCodes)
+	alpha := make([]int, numLiteralCodes)
+	distance := make([]int, numDistanceCodes)
+	for _, t := range tokens {
+		if t.length > 0 {
+			lengthPrefix, _, _ := prefixEncode(t.length)
+			distancePrefix, _, _ := prefixEncode(t.distanceCode)
+			green[numLiteralCodes+lengthPrefix]++
+			distance[distancePrefix]++
+			continue
+		}
+		green[(t.pixel>>8)&0xff]++
+		red[(t.pixel>>16)&0xff]++
+		blue[t.pixel&0xff]++
+		alpha[t.pixel>>24]++
+	}
+
+	codes := make([]prefixCode, 5)
+	for i, counts := range [][]int{green, red, blue, alpha, distance} {
+		codes[i] = newPrefixCode(huffmanLengths(counts, maxCodeLength))
+		writePrefixCode(bw, codes[i].lengths)
+	}
+
+	for _, t := range tokens {
+		if t.length > 0 {
+			lengthPrefix, lengthBits, lengthExtra := prefixEncode(t.length)
+			distancePrefix, distanceBits, distanceExtra := prefixEncode(t.distanceCode)
+			codes[0].write(bw, numLiteralCodes+lengthPrefix)
+			bw.write(lengthExtra, lengthBits)
+			codes[4].write(bw, distancePrefix)
+			bw.write(distanceExtra, distanceBits)
+			continue
+		}
+		codes[0].write(bw, int((t.pixel>>8)&0xff))
+		codes[1].write(bw, int((t.pixel>>16)&0xff))
+		codes[2].write(bw, int(t.pixel&0xff))
+		codes[3].write(bw, int(t.pixel>>24))
+	}
+}
+
+func writePrefixCode(bw *bitWriter, lengths []int) {
+	var used []int
+	for symbol, length := range lengths {
+		if length > 0 {
+			used = append(used, symbol)
+		}
+	}
+
+	// A single symbol (or none at all) is written as a simple code, which
+	// decodes with zero bits per symbol
+	if len(used) <= 1 {
+		symbol := 0
+		if len(used) == 1 {
+			symbol = used[0]
+		}
+		bw.write(1, 1) // simple code
+		bw.write(0, 1) // one symbol
+		if symbol < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(symbol), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(symbol), 8)
+		}
+		return
+	}
+
+	bw.write(0, 1) // normal code
+	histogram := make([]int, len(codeLengthCodeOrder))
+	for _, length := range lengths {
+		histogram[length]++
+	}
+	codeLengthCode := newPrefixCode(huffmanLengths(histogram, maxCodeLengthCodeLen))
+
+	numCodes := 4
+	for i, symbol := range codeLengthCodeOrder {
+		if codeLengthCode.lengths[symbol] > 0 && i+1 > numCodes {
+			numCodes = i + 1
+		}
+	}
+	bw.write(uint32(numCodes-4), 4)
+	for _, symbol := range codeLengthCodeOrder[:numCodes] {
+		bw.write(uint32(codeLengthCode.lengths[symbol]), 3)
+	}
+
+	bw.write(0, 1) // code lengths for the whole alphabet follow
+	for _, length := range lengths {
+		codeLengthCode.write(bw, length)
+	}
+}
+
+// prefixCode holds canonical Huffman codes, bit-reversed for the LSB-first
+// bit writer.
+type prefixCode struct {
+	lengths []int
+	codes   []uint32
+	single  bool
+}
+
+func newPrefixCode(lengths []int) prefixCode {
+	var lengthCount [maxCodeLength + 1]int
+	used := 0
+	for _, length := range lengths {
+		if length > 0 {
+			lengthCount[length]++
+			used++
+		}
+	}
+
+	var nextCode [maxCodeLength + 2]uint32
+	code := uint32(0)
+	for length := 1; length <= maxCodeLength; length++ {
+		code = (code + uint32(lengthCount[length-1])) << 1
+		nextCode[length] = code
+	}
+
+	codes := make([]uint32, len(lengths))
+	for symbol, length := range lengths {
+		if length == 0 {
+			continue
+		}
+		codes[symbol] = reverseBits(nextCode[length], length)
+		nextCode[length]++
+	}
+	return prefixCode{lengths: lengths, codes: codes, single: used <= 1}
+}
+
+func (c prefixCode) write(bw *bitWriter, symbol int) {
+	if c.single {
+		return
+	}
+	bw.write(c.codes[symbol], uint(c.lengths[symbol]))
+}
+
+func reverseBits(code uint32, length int) uint32 {
+	var reversed uint32
+	for i := 0; i < length; i++ {
+		reversed = reversed<<1 | (code>>i)&1
+	}
+	return reversed
+}
+
+// huffmanLengths returns code lengths no longer than maxLength. Rare symbols
+// are boosted until the tree is shallow enough.
+func huffmanLengths(counts []int, maxLength int) []int {
+	for floor := 1; ; floor *= 2 {
+		lengths, depth := buildHuffman(counts, floor)
+		if depth <= maxLength {
+			return lengths
+		}
+	}
+}
+
+type huffmanNode struct {
+	weight      int
+	symbol      int
+	left, right *huffmanNode
+}
+
+type nodeHeap []*huffmanNode
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].weight != h[j].weight {
+		return h[i].weight < h[j].weight
+	}
+	return h[i].symbol < h[j].symbol
+}
+func (h nodeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x interface{}) { *h = append(*h, x.(*huffmanNode)) }
+func (h *nodeHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+func buildHuffman(counts []int, floor int) ([]int, int) {
+	lengths := make([]int, len(counts))
+	h := &nodeHeap{}
+	for symbol, count := range counts {
+		if count > 0 {
+			if count < floor {
+				count = floor
+			}
+			*h = append(*h, &huffmanNode{weight: count, symbol: symbol})
+		}
+	}
+	switch h.Len() {
+	case 0:
+		return lengths, 0
+	case 1:
+		lengths[(*h)[0].symbol] = 1
+		return lengths, 1
+	}
+
+	heap.Init(h)
+	next := len(counts)
+	for h.Len() > 1 {
+		a := heap.Pop(h).(*huffmanNode)
+		b := heap.Pop(h).(*huffmanNode)
+		heap.Push(h, &huffmanNode{weight: a.weight + b.weight, symbol: next, left: a, right: b})
+		next++
+	}
+
+	maxDepth := 0
+	var walk func(n *huffmanNode, depth int)
+	walk = func(n *huffmanNode, depth int) {
+		if n.left == nil {
+			lengths[n.symbol] = depth
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+			return
+		}
+		walk(n.left, depth+1)
+		walk(n.right, depth+1)
+	}
+	walk(heap.Pop(h).(*huffmanNode), 0)
+	return lengths, maxDepth
+}
+
+type bitWriter struct {
+	buf  []byte
+	acc  uint64
+	bits uint
+}
+
+func (w *bitWriter) write(value uint32, n uint) {
+	w.acc |= uint64(value) << w.bits
+	w.bits += n
+	for w.bits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.bits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.bits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.bits = 0, 0
+	}
+	return w.buf
+}
+
+// nearLossless rounds color channels to multiples of 1<<bits, like the near
+// lossless mode of libwebp. The differences left by the predictor transform
+// then fall into far fewer symbols, which shrinks photographic images a lot
+// for a loss that is hard to see. Alpha is kept as is.
+func nearLossless(img *image.NRGBA, bits uint) *image.NRGBA {
+	step := 1 << bits
+	max := 256 - step
+	out := image.NewNRGBA(img.Bounds())
+	for i := 0; i < len(img.Pix); i++ {
+		if i%4 == 3 {
+			out.Pix[i] = img.Pix[i]
+			continue
+		}
+		v := (int(img.Pix[i]) + step/2) &^ (step - 1)
+		if v > max {
+			v = max
+		}
+		out.Pix[i] = uint8(v)
+	}
+	return out
+}
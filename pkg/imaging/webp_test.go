@@ -0,0 +1,89 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/image/webp"
+)
+
+func roundTrip(t *testing.T, img *image.NRGBA) *image.NRGBA {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encodeWebP(&buf, img); err != nil {
+		t.Fatalf("encoding: %v", err)
+	}
+	decoded, err := webp.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decoding: %v", err)
+	}
+	return toNRGBA(decoded)
+}
+
+func assertSamePixels(t *testing.T, got, want *image.NRGBA) {
+	t.Helper()
+	if got.Bounds().Dx() != want.Bounds().Dx() || got.Bounds().Dy() != want.Bounds().Dy() {
+		t.Fatalf("got %v, want %v", got.Bounds(), want.Bounds())
+	}
+	for y := 0; y < want.Bounds().Dy(); y++ {
+		for x := 0; x < want.Bounds().Dx(); x++ {
+			if g, w := got.NRGBAAt(x, y), want.NRGBAAt(x, y); g != w {
+				t.Fatalf("pixel (%d, %d) is %v, want %v", x, y, g, w)
+			}
+		}
+	}
+}
+
+func noise(width, height int, alpha bool) *image.NRGBA {
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	rng.Read(img.Pix)
+	if !alpha {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+	}
+	return img
+}
+
+// stripes has long runs of equal pixels, which are encoded as back references.
+func stripes(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := img.PixOffset(x, y)
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = uint8(y/7*40), uint8(x/50*90), 200, 0xff
+		}
+	}
+	return img
+}
+
+func TestEncodeWebPRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		img  *image.NRGBA
+	}{
+		{"single pixel", noise(1, 1, false)},
+		{"noise", noise(37, 23, false)},
+		{"noise with alpha", noise(64, 48, true)},
+		{"flat", stripes(1, 300)},
+		{"stripes", stripes(300, 200)},
+		{"several predictor blocks", stripes(600, 1100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSamePixels(t, roundTrip(t, tt.img), tt.img)
+		})
+	}
+}
+
+func TestNearLosslessRoundsColorsOnly(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	copy(img.Pix, []uint8{1, 2, 255, 7})
+	got := nearLossless(img, 2).Pix
+	if want := []uint8{0, 4, 252, 7}; !bytes.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
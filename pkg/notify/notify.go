@@ -0,0 +1,68 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Mention tells a user that someone mentioned them in a comment.
+type Mention struct {
+	UserID    string    `json:"userId"`
+	Username  string    `json:"username"`
+	AuthorID  string    `json:"authorId"`
+	MovieID   string    `json:"movieId"`
+	CommentID string    `json:"commentId"`
+	Excerpt   string    `json:"excerpt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// MentionNotifier delivers mention notifications, e.g. by email or push.
+type MentionNotifier interface {
+	NotifyMention(ctx context.Context, mention Mention) error
+}
+
+// LogNotifier writes mentions to the log. It stands in for a real channel
+// during development.
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyMention(ctx context.Context, mention Mention) error {
+	log.Printf("notify: @%s was mentioned in comment %s on movie %s", mention.Username, mention.CommentID, mention.MovieID)
+	return nil
+}
+
+// WebhookNotifier posts each mention as JSON to a URL, leaving delivery to
+// another service.
+type WebhookNotifier struct {
+	URL    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (n *WebhookNotifier) NotifyMention(ctx context.Context, mention Mention) error {
+	body, err := json.Marshal(map[string]any{"type": "comment.mention", "mention": mention})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("mention webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrDeclined means the provider refused the charge, e.g. for insufficient
+// funds. The customer may retry with another payment method.
+var ErrDeclined = errors.New("payment declined")
+
+// DeclineToken makes the fake provider decline a charge.
+const DeclineToken = "decline"
+
+// Charge asks for an amount in a currency. Token identifies the customer's
+// payment method with the provider; Reference is our own identifier, used by
+// providers to avoid charging twice.
+type Charge struct {
+	Reference string
+	Amount    float64
+	Currency  string
+	Token     string
+}
+
+// Provider takes and refunds payments.
+type Provider interface {
+	Name() string
+	// Charge takes the payment and returns the provider's reference for it.
+	Charge(ctx context.Context, charge Charge) (string, error)
+	// Refund pays back a charge in full.
+	Refund(ctx context.Context, reference string) error
+}
+
+// Fake accepts every charge except those with DeclineToken, without moving
+// any money. It stands in for a real provider during development and tests.
+type Fake struct {
+	mu       sync.Mutex
+	charges  map[string]Charge // By provider reference
+	refunded map[string]bool
+	byOwnRef map[string]string
+}
+
+func NewFake() *Fake {
+	return &Fake{charges: make(map[string]Charge), refunded: make(map[string]bool), byOwnRef: make(map[string]string)}
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Charge(ctx context.Context, charge Charge) (string, error) {
+	if charge.Token == DeclineToken {
+		return "", ErrDeclined
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	// Charging the same reference again returns the first charge
+	if reference, ok := f.byOwnRef[charge.Reference]; ok {
+		return reference, nil
+	}
+	reference := fmt.Sprintf("fake_%d", len(f.charges)+1)
+	f.charges[reference] = charge
+	f.byOwnRef[charge.Reference] = reference
+	return reference, nil
+}
+
+func (f *Fake) Refund(ctx context.Context, reference string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.charges[reference]; !ok {
+		return errors.New("unknown charge " + reference)
+	}
+	f.refunded[reference] = true
+	return nil
+}
+
+// Refunded reports whether a charge was refunded.
+func (f *Fake) Refunded(reference string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.refunded[reference]
+}
@@ -0,0 +1,378 @@
+package recommend
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reasons two items are considered similar.
+const (
+	ReasonGenres     = "shared_genres"
+	ReasonPeople     = "shared_people"
+	ReasonStory      = "similar_story"
+	ReasonRatedAlike = "rated_alike"
+)
+
+// Item is what content-based similarity looks at.
+type Item struct {
+	ID     string
+	Genres []string
+	People []Person
+	Text   string // Description and tagline
+}
+
+// Person is someone credited on an item.
+type Person struct {
+	ID   string
+	Name string
+}
+
+// Rating is a user's rating of an item.
+type Rating struct {
+	UserID string
+	ItemID string
+	Value  float64
+}
+
+// Similarity is how much an item resembles another, with why.
+type Similarity struct {
+	ItemID        string
+	SimilarID     string
+	Score         float64 // Content, raised by Collaborative, from 0 to 1
+	Content       float64
+	Collaborative float64
+	Reasons       []string
+	SharedGenres  []string
+	SharedPeople  []string // Names
+}
+
+type Options struct {
+	TopK          int     // Similar items kept per item
+	MinScore      float64 // Weaker similarities are dropped
+	GenreWeight   float64
+	PeopleWeight  float64
+	TextWeight    float64
+	RatingWeight  float64 // How far the collaborative score can raise the content score
+	MinCoRaters   int     // Users who must have rated both items for a collaborative score
+	Shrinkage     float64 // Damps collaborative scores backed by few users
+	MaxTermShare  float64 // Terms in a larger share of texts are ignored
+	ReasonMinimum float64 // Minimum text or collaborative score to be given as a reason
+}
+
+func DefaultOptions() Options {
+	return Options{
+		TopK:          20,
+		MinScore:      0.1,
+		GenreWeight:   0.4,
+		PeopleWeight:  0.3,
+		TextWeight:    0.3,
+		RatingWeight:  0.4,
+		MinCoRaters:   2,
+		Shrinkage:     5,
+		MaxTermShare:  0.5,
+		ReasonMinimum: 0.1,
+	}
+}
+
+// vector is a sparse vector of unit length.
+type vector map[string]float64
+
+type posting struct {
+	item   int
+	weight float64
+}
+
+// space is one kind of feature with an inverted index to find the items
+// sharing a feature without comparing every pair.
+type space struct {
+	vectors []vector
+	index   map[string][]posting
+}
+
+func newSpace(vectors []vector) *space {
+	s := &space{vectors: vectors, index: make(map[string][]posting)}
+	for i, v := range vectors {
+		for feature, weight := range v {
+			s.index[feature] = append(s.index[feature], posting{item: i, weight: weight})
+		}
+	}
+	return s
+}
+
+// cosines adds the cosine similarity of item i to every item sharing a
+// feature with it to sums and counts the shared features.
+func (s *space) cosines(i int, sums map[int]float64, shared map[int]int) {
+	for feature, weight := range s.vectors[i] {
+		for _, p := range s.index[feature] {
+			if p.item != i {
+				sums[p.item] += weight * p.weight
+				if shared != nil {
+					shared[p.item]++
+				}
+			}
+		}
+	}
+}
+
+// Compute finds the most similar items of every item.
+func Compute(items []Item, ratings []Rating, opts Options) []Similarity {
+	genres := make([]vector, len(items))
+	people := make([]vector, len(items))
+	names := make(map[string]string)
+	for i, item := range items {
+		genres[i] = binaryVector(lowerAll(item.Genres))
+		ids := make([]string, len(item.People))
+		for j, person := range item.People {
+			ids[j] = person.ID
+			names[person.ID] = person.Name
+		}
+		people[i] = binaryVector(ids)
+	}
+	spaces := []*space{newSpace(genres), newSpace(people), newSpace(tfidf(items, opts.MaxTermShare))}
+	weights := []float64{opts.GenreWeight, opts.PeopleWeight, opts.TextWeight}
+	ratingSpace, rated := ratingVectors(items, ratings)
+
+	var similarities []Similarity
+	for i, item := range items {
+		scores := make([]map[int]float64, len(spaces))
+		candidates := make(map[int]bool)
+		for k, s := range spaces {
+			scores[k] = make(map[int]float64)
+			s.cosines(i, scores[k], nil)
+			for j := range scores[k] {
+				candidates[j] = true
+			}
+		}
+		collaborative := make(map[int]float64)
+		coRaters := make(map[int]int)
+		if rated[i] {
+			ratingSpace.cosines(i, collaborative, coRaters)
+			for j := range collaborative {
+				candidates[j] = true
+			}
+		}
+
+		type match struct {
+			Similarity
+			j int
+		}
+		var found []match
+		for j := range candidates {
+			// Only weigh the kinds of features both items have, so a missing
+			// description does not count against a movie
+			content, total := 0.0, 0.0
+			for k, s := range spaces {
+				if len(s.vectors[i]) > 0 && len(s.vectors[j]) > 0 {
+					content += weights[k] * scores[k][j]
+					total += weights[k]
+				}
+			}
+			if total > 0 {
+				content /= total
+			}
+
+			// Ratings only add evidence: movies rated alike move closer, but a
+			// lack of ratings never pushes them apart
+			sim := Similarity{ItemID: item.ID, SimilarID: items[j].ID, Content: content, Score: content}
+			if n := coRaters[j]; rated[i] && rated[j] && n >= opts.MinCoRaters {
+				sim.Collaborative = math.Max(0, collaborative[j]) * float64(n) / (float64(n) + opts.Shrinkage)
+				sim.Score = 1 - (1-content)*(1-opts.RatingWeight*sim.Collaborative)
+			}
+			if sim.Score >= opts.MinScore {
+				found = append(found, match{sim, j})
+			}
+		}
+
+		sort.Slice(found, func(a, b int) bool {
+			if found[a].Score != found[b].Score {
+				return found[a].Score > found[b].Score
+			}
+			return found[a].SimilarID < found[b].SimilarID
+		})
+		if len(found) > opts.TopK {
+			found = found[:opts.TopK]
+		}
+		for _, m := range found {
+			explain(&m.Similarity, &items[i], &items[m.j], scores[2][m.j], names, opts)
+			similarities = append(similarities, m.Similarity)
+		}
+	}
+	return similarities
+}
+
+// explain fills in why two items are similar.
+func explain(sim *Similarity, a, b *Item, text float64, names map[string]string, opts Options) {
+	sim.SharedGenres = intersect(a.Genres, b.Genres)
+	for _, person := range a.People {
+		for _, other := range b.People {
+			if person.ID == other.ID && !contains(sim.SharedPeople, names[person.ID]) {
+				sim.SharedPeople = append(sim.SharedPeople, names[person.ID])
+			}
+		}
+	}
+	if len(sim.SharedGenres) > 0 {
+		sim.Reasons = append(sim.Reasons, ReasonGenres)
+	}
+	if len(sim.SharedPeople) > 0 {
+		sim.Reasons = append(sim.Reasons, ReasonPeople)
+	}
+	if text >= opts.ReasonMinimum {
+		sim.Reasons = append(sim.Reasons, ReasonStory)
+	}
+	if sim.Collaborative >= opts.ReasonMinimum {
+		sim.Reasons = append(sim.Reasons, ReasonRatedAlike)
+	}
+}
+
+// ratingVectors turns ratings into item vectors over users, each rating
+// relative to the user's mean so generous and harsh raters compare fairly.
+// Users with a single rating say nothing about how items relate.
+func ratingVectors(items []Item, ratings []Rating) (*space, []bool) {
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		index[item.ID] = i
+	}
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, r := range ratings {
+		if _, ok := index[r.ItemID]; ok {
+			sums[r.UserID] += r.Value
+			counts[r.UserID]++
+		}
+	}
+
+	vectors := make([]vector, len(items))
+	rated := make([]bool, len(items))
+	for _, r := range ratings {
+		i, ok := index[r.ItemID]
+		if !ok || counts[r.UserID] < 2 {
+			continue
+		}
+		centered := r.Value - sums[r.UserID]/float64(counts[r.UserID])
+		if centered == 0 {
+			continue
+		}
+		if vectors[i] == nil {
+			vectors[i] = make(vector)
+		}
+		vectors[i][r.UserID] = centered
+		rated[i] = true
+	}
+	for _, v := range vectors {
+		normalize(v)
+	}
+	return newSpace(vectors), rated
+}
+
+// tfidf weighs the words of each item's text by how rare they are across
+// items. Words found in a single text or in more than maxShare of them
+// cannot tell items apart and are left out.
+func tfidf(items []Item, maxShare float64) []vector {
+	terms := make([]map[string]int, len(items))
+	df := make(map[string]int)
+	for i, item := range items {
+		terms[i] = make(map[string]int)
+		for _, word := range tokenize(item.Text) {
+			if terms[i][word] == 0 {
+				df[word]++
+			}
+			terms[i][word]++
+		}
+	}
+
+	n := float64(len(items))
+	vectors := make([]vector, len(items))
+	for i, counts := range terms {
+		v := make(vector)
+		for word, count := range counts {
+			if df[word] < 2 || (n >= 10 && float64(df[word]) > maxShare*n) {
+				continue
+			}
+			v[word] = (1 + math.Log(float64(count))) * math.Log(n/float64(df[word]))
+		}
+		vectors[i] = normalize(v)
+	}
+	return vectors
+}
+
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) >= 3 && !stopWords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+func binaryVector(features []string) vector {
+	v := make(vector, len(features))
+	for _, feature := range features {
+		if feature != "" {
+			v[feature] = 1
+		}
+	}
+	return normalize(v)
+}
+
+func normalize(v vector) vector {
+	norm := 0.0
+	for _, weight := range v {
+		norm += weight * weight
+	}
+	if norm == 0 {
+		return v
+	}
+	norm = math.Sqrt(norm)
+	for feature := range v {
+		v[feature] /= norm
+	}
+	return v
+}
+
+func lowerAll(values []string) []string {
+	lowered := make([]string, len(values))
+	for i, value := range values {
+		lowered[i] = strings.ToLower(strings.TrimSpace(value))
+	}
+	return lowered
+}
+
+// intersect lists the values of a also in b, ignoring case.
+func intersect(a, b []string) []string {
+	var shared []string
+	for _, value := range a {
+		for _, other := range b {
+			if strings.EqualFold(value, other) && !contains(shared, value) {
+				shared = append(shared, value)
+			}
+		}
+	}
+	return shared
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// stopWords are common English words that say nothing about a story.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "his": true, "her": true, "their": true,
+	"from": true, "into": true, "who": true, "when": true, "where": true, "what": true, "which": true,
+	"that": true, "this": true, "these": true, "those": true, "are": true, "was": true, "were": true,
+	"has": true, "have": true, "had": true, "but": true, "not": true, "all": true, "one": true,
+	"after": true, "before": true, "about": true, "they": true, "them": true, "she": true, "him": true,
+	"its": true, "out": true, "must": true, "can": true, "will": true, "while": true, "only": true,
+	"over": true, "than": true, "then": true, "there": true, "been": true, "being": true, "also": true,
+	"film": true, "movie": true, "story": true,
+}
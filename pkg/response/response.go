@@ -37,6 +37,17 @@ func NewErrorResponse(message string, errors []string) BaseResponse {
 	}
 }
 
+// NewErrorResponseWithObject creates an error response that carries data the
+// client needs to resolve the error, such as conflicting records
+func NewErrorResponseWithObject(message string, data interface{}, errors []string) BaseResponse {
+	return BaseResponse{
+		Success: false,
+		Message: message,
+		Object:  data,
+		Errors:  errors,
+	}
+}
+
 // NewPaginatedResponse creates a new paginated response
 func NewPaginatedResponse(message string, data interface{}, page, pageSize, totalSize int) PaginatedResponse {
 	return PaginatedResponse{
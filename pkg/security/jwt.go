@@ -8,10 +8,13 @@ import (
 
 var jwtSecret = []byte("supersecretkey")
 
-func GenerateJWT(userID, email string) (string, error) {
+// GenerateJWT issues a token for the user. The role is read from the token
+// by the admin middleware, so a role change applies on the next login.
+func GenerateJWT(userID, email, role string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
+		"role":    role,
 		"exp":     time.Now().Add(time.Hour * 72).Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
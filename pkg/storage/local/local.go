@@ -0,0 +1,144 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"eskalate-movie-api/pkg/storage"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Store keeps images on the local filesystem. The API serves the directory
+// itself, so every object is public.
+type Store struct {
+	root    string
+	baseURL string
+}
+
+func New(root, baseURL string) (*Store, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &Store{root: root, baseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// Root is the directory objects are written to.
+func (s *Store) Root() string {
+	return s.root
+}
+
+// RoutePath is the URL path the API must serve Root under.
+func (s *Store) RoutePath() string {
+	u, err := url.Parse(s.baseURL)
+	if err != nil || u.Path == "" {
+		return "/"
+	}
+	return u.Path
+}
+
+func (s *Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*storage.Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	// Write to a temporary file first so readers never see partial images
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+
+	return &storage.Object{Key: key, URL: s.URL(key)}, nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	var objects []storage.ObjectInfo
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Skip temporary files of uploads in progress
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, storage.ObjectInfo{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	return objects, err
+}
+
+func (s *Store) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+func (s *Store) KeyFromURL(rawURL string) (string, bool) {
+	rawURL, _, _ = strings.Cut(rawURL, "?")
+	key, ok := strings.CutPrefix(rawURL, s.baseURL+"/")
+	if !ok || key == "" {
+		return "", false
+	}
+	return key, true
+}
+
+func (s *Store) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.URL(key), nil
+}
+
+func (s *Store) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || strings.HasPrefix(clean, ".."+string(filepath.Separator)) || clean == ".." {
+		return "", errors.New("invalid object key")
+	}
+	return filepath.Join(s.root, clean), nil
+}
@@ -0,0 +1,229 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"eskalate-movie-api/pkg/storage"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Config describes an S3-compatible bucket such as AWS S3 or MinIO.
+type Config struct {
+	Endpoint     string // e.g. https://s3.eu-central-1.amazonaws.com or http://localhost:9000
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	PublicURL    string // Optional base URL objects are publicly served from, e.g. a CDN
+	UsePathStyle bool   // Address the bucket as endpoint/bucket instead of bucket.endpoint; required for MinIO
+}
+
+// Store keeps images in an S3-compatible bucket using signature version 4
+// requests, so no SDK is needed.
+type Store struct {
+	cfg      Config
+	endpoint *url.URL
+	client   *http.Client
+}
+
+func New(cfg Config) (*Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("s3 endpoint and bucket are required")
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &Store{cfg: cfg, endpoint: endpoint, client: &http.Client{Timeout: 5 * time.Minute}}, nil
+}
+
+func (s *Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*storage.Object, error) {
+	if size < 0 {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+		size = int64(len(data))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if err := s.do(req, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	return &storage.Object{Key: key, URL: s.URL(key)}, nil
+}
+
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.send(req, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	// S3 answers 204 whether or not the object existed
+	return s.do(req, http.StatusNoContent, http.StatusNotFound)
+}
+
+func (s *Store) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return strings.TrimRight(s.cfg.PublicURL, "/") + "/" + encodePath(key)
+	}
+	return s.objectURL(key).String()
+}
+
+func (s *Store) KeyFromURL(rawURL string) (string, bool) {
+	rawURL, _, _ = strings.Cut(rawURL, "?") // presigned URLs carry their signature in the query
+	prefixes := []string{strings.TrimSuffix(s.objectURL("").String(), "/") + "/"}
+	if s.cfg.PublicURL != "" {
+		prefixes = append(prefixes, strings.TrimRight(s.cfg.PublicURL, "/")+"/")
+	}
+	for _, prefix := range prefixes {
+		if escaped, ok := strings.CutPrefix(rawURL, prefix); ok && escaped != "" {
+			key, err := url.PathUnescape(escaped)
+			if err != nil {
+				return "", false
+			}
+			return key, true
+		}
+	}
+	return "", false
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List pages through ListObjectsV2 until every object under prefix is seen.
+func (s *Store) List(ctx context.Context, prefix string) ([]storage.ObjectInfo, error) {
+	bucketURL := s.objectURL("")
+	bucketURL.Path = strings.TrimSuffix(bucketURL.Path, "/")
+	bucketURL.RawPath = encodePath(bucketURL.Path)
+
+	var objects []storage.ObjectInfo
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		bucketURL.RawQuery = query.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, bucketURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.send(req, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			objects = append(objects, storage.ObjectInfo{Key: object.Key, Size: object.Size, LastModified: object.LastModified})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// PresignUpload returns a POST policy upload to the bucket.
+func (s *Store) PresignUpload(ctx context.Context, key, contentType string, maxSize int64, expiry time.Duration) (*storage.DirectUpload, error) {
+	bucketURL := s.objectURL("")
+	now := time.Now()
+	return &storage.DirectUpload{
+		Method:    http.MethodPost,
+		URL:       bucketURL.String(),
+		Fields:    s.presignPost(key, contentType, maxSize, expiry, now),
+		FileField: "file",
+		ExpiresAt: now.Add(expiry),
+	}, nil
+}
+
+func (s *Store) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return s.presign(http.MethodGet, s.objectURL(key), expiry, time.Now()), nil
+}
+
+func (s *Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	if s.cfg.UsePathStyle {
+		u.Path = u.Path + "/" + s.cfg.Bucket + "/" + key
+	} else {
+		u.Host = s.cfg.Bucket + "." + u.Host
+		u.Path = u.Path + "/" + key
+	}
+	u.RawPath = encodePath(u.Path)
+	return &u
+}
+
+func (s *Store) do(req *http.Request, expected ...int) error {
+	resp, err := s.send(req, expected...)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// send signs and performs req. The caller must close the body of the
+// returned response.
+func (s *Store) send(req *http.Request, expected ...int) (*http.Response, error) {
+	s.sign(req, time.Now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return resp, nil
+		}
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("s3 %s %s: %s: %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(detail)))
+}
@@ -0,0 +1,191 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	amzDateLayout    = "20060102T150405Z"
+	amzDayLayout     = "20060102"
+)
+
+// sign adds an AWS signature version 4 Authorization header to req. The
+// payload is left unsigned so bodies can be streamed.
+func (s *Store) sign(req *http.Request, now time.Time) {
+	now = now.UTC()
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", now.Format(amzDateLayout))
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	if req.ContentLength > 0 {
+		req.Header.Set("Content-Length", strconv.FormatInt(req.ContentLength, 10))
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, strings.ToLower(name))
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := s.scope(now)
+	signature := s.signature(now, scope, canonicalRequest)
+	req.Header.Del("Host")
+	req.Header.Set("Authorization", signingAlgorithm+
+		" Credential="+s.cfg.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+signature)
+}
+
+// presign returns u with query-string authentication valid for expiry.
+func (s *Store) presign(method string, u *url.URL, expiry time.Duration, now time.Time) string {
+	now = now.UTC()
+	scope := s.scope(now)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", signingAlgorithm)
+	query.Set("X-Amz-Credential", s.cfg.AccessKey+"/"+scope)
+	query.Set("X-Amz-Date", now.Format(amzDateLayout))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expiry.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, scope, canonicalRequest))
+	signed := *u
+	signed.RawQuery = canonicalQuery(query)
+	return signed.String()
+}
+
+func (s *Store) scope(now time.Time) string {
+	return now.Format(amzDayLayout) + "/" + s.cfg.Region + "/s3/aws4_request"
+}
+
+func (s *Store) signature(now time.Time, scope, canonicalRequest string) string {
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.Format(amzDateLayout),
+		scope,
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	return hex.EncodeToString(hmacSHA256(s.signingKey(now), stringToSign))
+}
+
+func (s *Store) signingKey(now time.Time) []byte {
+	key := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), now.Format(amzDayLayout))
+	key = hmacSHA256(key, s.cfg.Region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+// presignPost builds the form fields of a browser-based POST upload. The
+// signed policy pins the key and content type and limits the size.
+func (s *Store) presignPost(key, contentType string, maxSize int64, expiry time.Duration, now time.Time) map[string]string {
+	now = now.UTC()
+	credential := s.cfg.AccessKey + "/" + s.scope(now)
+	fields := map[string]string{
+		"key":              key,
+		"Content-Type":     contentType,
+		"x-amz-algorithm":  signingAlgorithm,
+		"x-amz-credential": credential,
+		"x-amz-date":       now.Format(amzDateLayout),
+	}
+
+	conditions := []interface{}{
+		map[string]string{"bucket": s.cfg.Bucket},
+		[]interface{}{"content-length-range", 1, maxSize},
+	}
+	for name, value := range fields {
+		conditions = append(conditions, map[string]string{name: value})
+	}
+	policy, _ := json.Marshal(map[string]interface{}{
+		"expiration": now.Add(expiry).Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+
+	encoded := base64.StdEncoding.EncodeToString(policy)
+	fields["policy"] = encoded
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(s.signingKey(now), encoded))
+	return fields
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, encode(key, true)+"="+encode(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// encodePath escapes an object path the way signature version 4 expects,
+// keeping slashes.
+func encodePath(path string) string {
+	return encode(path, false)
+}
+
+func encode(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Object identifies a stored image. Key is backend independent and is what
+// gets persisted; URL is the public address of the object.
+type Object struct {
+	Key string
+	URL string
+}
+
+// ObjectInfo describes a stored object as returned by List.
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// DirectUpload tells a client how to upload an object straight to the store:
+// a multipart form request to URL carrying Fields followed by the file in
+// FileField.
+type DirectUpload struct {
+	Method    string
+	URL       string
+	Fields    map[string]string
+	FileField string
+	ExpiresAt time.Time
+}
+
+// DirectUploader is implemented by stores that accept uploads from clients
+// without passing through the API. The store enforces the constraints where
+// it can; the uploaded object must be validated before use either way.
+type DirectUploader interface {
+	PresignUpload(ctx context.Context, key, contentType string, maxSize int64, expiry time.Duration) (*DirectUpload, error)
+}
+
+// ImageStore is implemented by every backend that can hold uploaded images.
+type ImageStore interface {
+	// Put stores body under key. size may be -1 when unknown.
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*Object, error)
+	// Get opens the object stored under key. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns every object whose key starts with prefix.
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// URL returns the public URL of the object stored under key.
+	URL(key string) string
+	// KeyFromURL returns the key of the object a URL produced by this store
+	// points to. ok is false for URLs served from anywhere else.
+	KeyFromURL(rawURL string) (key string, ok bool)
+	// SignedURL returns a URL that grants read access to the object for the
+	// given duration. Backends without access control return the public URL.
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
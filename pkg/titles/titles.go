@@ -0,0 +1,116 @@
+package titles
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// leadingArticles are dropped from the start of a normalized title, so "The
+// Matrix" and "Matrix" compare equal.
+var leadingArticles = []string{"the ", "a ", "an "}
+
+// Normalize reduces a title to a form in which trivially different spellings
+// of the same title are equal: lower case, without accents, punctuation,
+// a leading article or repeated spaces. "&" is read as "and".
+func Normalize(title string) string {
+	folded := fold(title)
+
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+	for i, word := range words {
+		words[i] = strings.ReplaceAll(word, "'", "")
+	}
+	normalized := strings.Join(words, " ")
+
+	for _, article := range leadingArticles {
+		if rest, ok := strings.CutPrefix(normalized, article); ok && rest != "" {
+			return rest
+		}
+	}
+	return normalized
+}
+
+// maxSlugLength bounds the title part of a slug.
+const maxSlugLength = 80
+
+// Slug turns a title and release year into a URL path segment such as
+// "the-matrix-1999". The year is left out when it is zero.
+func Slug(title string, year int) string {
+	folded := fold(title)
+	folded = strings.ReplaceAll(folded, "'", "")
+
+	words := strings.FieldsFunc(folded, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9')
+	})
+	slug := ""
+	for _, word := range words {
+		if len(slug)+len(word)+1 > maxSlugLength && slug != "" {
+			break
+		}
+		if slug != "" {
+			slug += "-"
+		}
+		slug += word
+	}
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+	}
+	if slug == "" {
+		slug = "movie"
+	}
+	if year > 0 {
+		slug += "-" + strconv.Itoa(year)
+	}
+	return slug
+}
+
+// maxSlugSuffix keeps the numeric suffixes of FreeSlug apart from release
+// years, so "heat-1995" is not mistaken for a variant of "heat".
+const maxSlugSuffix = 999
+
+// HasSlugBase reports whether slug is base itself or base with a numeric
+// suffix added to tell it apart from another movie's slug.
+func HasSlugBase(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(slug, base+"-")
+	if !ok || suffix == "" || suffix[0] == '0' {
+		return false
+	}
+	n, err := strconv.Atoi(suffix)
+	return err == nil && n >= 2 && n <= maxSlugSuffix
+}
+
+// FreeSlug returns the first of base, base-2, base-3 and so on that is not
+// taken. Past maxSlugSuffix it falls back to a random suffix.
+func FreeSlug(base string, taken []string) string {
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+	slug := base
+	for n := 2; used[slug]; n++ {
+		if n > maxSlugSuffix {
+			return base + "-" + strconv.FormatInt(rand.Int63(), 36)
+		}
+		slug = base + "-" + strconv.Itoa(n)
+	}
+	return slug
+}
+
+// fold lower-cases a title, strips accents and spells out "&".
+func fold(title string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), title)
+	if err != nil {
+		folded = title
+	}
+	return strings.ReplaceAll(strings.ToLower(folded), "&", " and ")
+}
@@ -0,0 +1,83 @@
+package trailer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultYouTubeOEmbedURL = "https://www.youtube.com/oembed"
+	DefaultVimeoOEmbedURL   = "https://vimeo.com/api/oembed.json"
+)
+
+// ErrVideoNotFound means the provider does not know the video or does not
+// allow it to be embedded.
+var ErrVideoNotFound = errors.New("trailer video does not exist or cannot be embedded")
+
+// Details is what a provider reports about an existing video.
+type Details struct {
+	Title        string
+	ThumbnailURL string
+}
+
+// Verifier checks that a video exists.
+type Verifier interface {
+	Verify(ctx context.Context, video Video) (*Details, error)
+}
+
+// OEmbedClient verifies videos through the providers' oEmbed endpoints.
+// Endpoints can be pointed at a local stub for development and tests.
+type OEmbedClient struct {
+	YouTubeURL string
+	VimeoURL   string
+	client     *http.Client
+}
+
+func NewOEmbedClient(youtubeURL, vimeoURL string) *OEmbedClient {
+	if youtubeURL == "" {
+		youtubeURL = DefaultYouTubeOEmbedURL
+	}
+	if vimeoURL == "" {
+		vimeoURL = DefaultVimeoOEmbedURL
+	}
+	return &OEmbedClient{YouTubeURL: youtubeURL, VimeoURL: vimeoURL, client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (c *OEmbedClient) Verify(ctx context.Context, video Video) (*Details, error) {
+	endpoint := c.YouTubeURL
+	if video.Provider == ProviderVimeo {
+		endpoint = c.VimeoURL
+	}
+	query := url.Values{"url": {video.URL()}, "format": {"json"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return nil, ErrVideoNotFound
+	default:
+		return nil, fmt.Errorf("oembed %s: %s", video.Provider, resp.Status)
+	}
+
+	var body struct {
+		Title        string `json:"title"`
+		ThumbnailURL string `json:"thumbnail_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	return &Details{Title: body.Title, ThumbnailURL: body.ThumbnailURL}, nil
+}
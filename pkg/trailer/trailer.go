@@ -0,0 +1,107 @@
+// Package trailer parses trailer links into a provider and video ID and
+// builds the canonical, embed and thumbnail URLs for them.
+package trailer
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	ProviderYouTube = "youtube"
+	ProviderVimeo   = "vimeo"
+)
+
+var ErrNotAVideo = errors.New("trailerUrl must link to a YouTube or Vimeo video")
+
+var (
+	youtubeID = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoID   = regexp.MustCompile(`^[0-9]{6,11}$`)
+)
+
+// Video identifies a trailer independently of the URL form it was given in.
+type Video struct {
+	Provider string
+	ID       string
+}
+
+// Parse accepts YouTube watch, shorts, embed, live and youtu.be links and
+// Vimeo video, channel and player links. Channel, playlist and other pages
+// that are not a single video are rejected.
+func Parse(rawURL string) (*Video, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, ErrNotAVideo
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var video *Video
+	switch host {
+	case "youtube.com", "m.youtube.com", "music.youtube.com", "youtube-nocookie.com":
+		switch {
+		case len(segments) == 1 && segments[0] == "watch":
+			video = &Video{Provider: ProviderYouTube, ID: u.Query().Get("v")}
+		case len(segments) == 2 && (segments[0] == "shorts" || segments[0] == "embed" || segments[0] == "live" || segments[0] == "v"):
+			video = &Video{Provider: ProviderYouTube, ID: segments[1]}
+		}
+	case "youtu.be":
+		if len(segments) == 1 {
+			video = &Video{Provider: ProviderYouTube, ID: segments[0]}
+		}
+	case "vimeo.com":
+		// vimeo.com/<id>, vimeo.com/<id>/<unlisted hash>,
+		// vimeo.com/channels/<name>/<id>
+		switch {
+		case len(segments) >= 1 && vimeoID.MatchString(segments[0]):
+			video = &Video{Provider: ProviderVimeo, ID: segments[0]}
+		case len(segments) == 3 && segments[0] == "channels":
+			video = &Video{Provider: ProviderVimeo, ID: segments[2]}
+		}
+	case "player.vimeo.com":
+		if len(segments) == 2 && segments[0] == "video" {
+			video = &Video{Provider: ProviderVimeo, ID: segments[1]}
+		}
+	}
+
+	if video == nil || !video.valid() {
+		return nil, ErrNotAVideo
+	}
+	return video, nil
+}
+
+func (v Video) valid() bool {
+	switch v.Provider {
+	case ProviderYouTube:
+		return youtubeID.MatchString(v.ID)
+	case ProviderVimeo:
+		return vimeoID.MatchString(v.ID)
+	}
+	return false
+}
+
+// URL is the canonical watch page, which is what gets stored.
+func (v Video) URL() string {
+	if v.Provider == ProviderVimeo {
+		return "https://vimeo.com/" + v.ID
+	}
+	return "https://www.youtube.com/watch?v=" + v.ID
+}
+
+func (v Video) EmbedURL() string {
+	if v.Provider == ProviderVimeo {
+		return "https://player.vimeo.com/video/" + v.ID
+	}
+	return "https://www.youtube.com/embed/" + v.ID
+}
+
+// ThumbnailURL is derived from the ID for YouTube. Vimeo thumbnails can only
+// be looked up, so it is empty for them.
+func (v Video) ThumbnailURL() string {
+	if v.Provider == ProviderYouTube {
+		return "https://i.ytimg.com/vi/" + v.ID + "/hqdefault.jpg"
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package wordfilter
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// leet undoes common letter substitutions inside words, so "b4d" matches
+// "bad". Words made only of digits are left alone.
+var leet = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s")
+
+// Filter finds blocked words and phrases in text. Matching ignores case,
+// accents and common letter substitutions, and only matches whole words, so
+// blocking "ass" does not flag "class".
+type Filter struct {
+	terms [][]string // Each term as its words
+}
+
+// New builds a filter from a list of words and phrases. Blank terms are
+// ignored.
+func New(terms []string) *Filter {
+	f := &Filter{}
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		words := tokenize(term)
+		key := strings.Join(words, " ")
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		f.terms = append(f.terms, words)
+	}
+	return f
+}
+
+// Load reads terms from a file with one word or phrase per line. Blank lines
+// and lines starting with # are skipped.
+func Load(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var terms []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			terms = append(terms, line)
+		}
+	}
+	return terms, scanner.Err()
+}
+
+// Empty reports whether the filter blocks nothing.
+func (f *Filter) Empty() bool {
+	return f == nil || len(f.terms) == 0
+}
+
+// Match lists the blocked terms found in any of the texts, in the order the
+// filter was configured with.
+func (f *Filter) Match(texts ...string) []string {
+	if f.Empty() {
+		return nil
+	}
+	var words []string
+	for _, text := range texts {
+		// Keep texts apart so a phrase cannot span two fields
+		words = append(words, tokenize(text)...)
+		words = append(words, "")
+	}
+
+	var matches []string
+	for _, term := range f.terms {
+		if contains(words, term) {
+			matches = append(matches, strings.Join(term, " "))
+		}
+	}
+	return matches
+}
+
+func contains(words, term []string) bool {
+	for i := 0; i+len(term) <= len(words); i++ {
+		match := true
+		for j, word := range term {
+			if words[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenize splits text into lower-case words without accents.
+func tokenize(text string) []string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		folded = text
+	}
+	fields := strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '@' && r != '$' && r != '\''
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.ReplaceAll(field, "'", "")
+		if strings.IndexFunc(field, unicode.IsLetter) >= 0 {
+			field = leet.Replace(field)
+		}
+		field = strings.Trim(field, "@$")
+		if field != "" {
+			words = append(words, field)
+		}
+	}
+	return words
+}